@@ -0,0 +1,239 @@
+package json
+
+import (
+	"container/list"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// CachePolicy is the policy of the caches of the compiled encoders and decoders.
+type CachePolicy struct {
+	maxTypes int64 // 0 for unbounded and negative for disabled
+}
+
+var (
+	// CachePolicyUnbounded keeps all compiled types in the caches. This is the default.
+	CachePolicyUnbounded = CachePolicy{}
+
+	// CachePolicyDisabled compiles types on every Marshal and Unmarshal without the caches.
+	CachePolicyDisabled = CachePolicy{maxTypes: -1}
+)
+
+// CachePolicyLRU keeps at most maxTypes compiled types in each of the encoder and decoder caches
+// and evicts the least recently used type. maxTypes less than 1 disables the caches.
+// Every lookup of the LRU cache takes a lock shared by all goroutines to update the recency,
+// so it is slower than CachePolicyUnbounded when many goroutines marshal or unmarshal at once.
+func CachePolicyLRU(maxTypes int) CachePolicy {
+	if maxTypes < 1 {
+		return CachePolicyDisabled
+	}
+	return CachePolicy{maxTypes: int64(maxTypes)}
+}
+
+// SetCachePolicy sets the policy of the caches of the compiled encoders and decoders,
+// and clears the caches. Types compiled with different options,
+// such as the formats of time.Time, are cached separately.
+func SetCachePolicy(policy CachePolicy) {
+	cachedOpcode.setPolicy(policy)
+	cachedDecoder.setPolicy(policy)
+}
+
+// CacheStats is the statistics of the cache of the compiled encoders or decoders.
+type CacheStats struct {
+	Hits   uint64      // number of lookups that found the compiled type
+	Misses uint64      // number of lookups that did not find the compiled type
+	Types  []TypeStats // types in the cache
+}
+
+// TypeStats is the statistics of a type in the cache.
+type TypeStats struct {
+	Type        reflect.Type
+	Opcodes     int           // number of opcodes of the compiled encoder without the indent, 0 for the decoder
+	CompileTime time.Duration // time spent on compiling
+	Hits        uint64        // number of lookups that found the type
+}
+
+// EncodeCacheStats returns the statistics of the cache of the compiled encoders.
+func EncodeCacheStats() CacheStats {
+	return cachedOpcode.stats()
+}
+
+// DecodeCacheStats returns the statistics of the cache of the compiled decoders.
+// The types are the pointer types passed to Unmarshal and Decode.
+func DecodeCacheStats() CacheStats {
+	return cachedDecoder.stats()
+}
+
+// cacheKey is the key of the compiled type and the options that change the compiled code.
+type cacheKey struct {
+	typeptr        uintptr
+	caseSensitive  bool
+	timeFormat     TimeFormat
+	durationFormat DurationFormat
+	custom         string
+	generated      bool // whether the methods generated by cmd/jsongen decode and encode their types
+}
+
+// newCustomKey returns cacheKey.custom for the functions registered for a single call,
+// which maps the registered types to the addresses of the functions.
+// The address of a function is not reused while the cached codes refer to it,
+// and a function they do not refer to has not changed them.
+func newCustomKey(fns map[uintptr]uintptr) string {
+	typeptrs := make([]uintptr, 0, len(fns))
+	for typeptr := range fns {
+		typeptrs = append(typeptrs, typeptr)
+	}
+	sort.Slice(typeptrs, func(i, j int) bool { return typeptrs[i] < typeptrs[j] })
+	b := make([]byte, 0, len(typeptrs)*2*8)
+	for _, typeptr := range typeptrs {
+		b = appendUintptr(b, typeptr)
+		b = appendUintptr(b, fns[typeptr])
+	}
+	return string(b)
+}
+
+func appendUintptr(b []byte, v uintptr) []byte {
+	for i := 0; i < 8; i++ {
+		b = append(b, byte(v>>(8*uint(i))))
+	}
+	return b
+}
+
+type cacheEntry struct {
+	hits        uint64
+	key         cacheKey
+	value       interface{}
+	opcodes     int
+	compileTime time.Duration
+}
+
+// codeCache is the cache of the compiled types with CachePolicy.
+// The unbounded cache is lock free for lookups, and the LRU cache locks mu for all operations.
+// The LRU cache is not sharded, because the least recently used type is evicted from all types.
+type codeCache struct {
+	hits     uint64
+	misses   uint64
+	maxTypes int64
+	mu       sync.Mutex
+	entries  sync.Map // map[cacheKey]*cacheEntry for the unbounded cache
+	lru      *list.List
+	lruElems map[cacheKey]*list.Element
+}
+
+var (
+	cachedOpcode  codeCache
+	cachedDecoder codeCache
+)
+
+func (c *codeCache) get(key cacheKey) interface{} {
+	var entry *cacheEntry
+	switch maxTypes := atomic.LoadInt64(&c.maxTypes); {
+	case maxTypes == 0:
+		if v, ok := c.entries.Load(key); ok {
+			entry = v.(*cacheEntry)
+		}
+	case maxTypes > 0:
+		c.mu.Lock()
+		if elem, ok := c.lruElems[key]; ok {
+			c.lru.MoveToFront(elem)
+			entry = elem.Value.(*cacheEntry)
+		}
+		c.mu.Unlock()
+	}
+	if entry == nil {
+		atomic.AddUint64(&c.misses, 1)
+		return nil
+	}
+	atomic.AddUint64(&c.hits, 1)
+	atomic.AddUint64(&entry.hits, 1)
+	return entry.value
+}
+
+func (c *codeCache) set(key cacheKey, value interface{}, opcodes int, compileTime time.Duration) {
+	entry := &cacheEntry{
+		key:         key,
+		value:       value,
+		opcodes:     opcodes,
+		compileTime: compileTime,
+	}
+	// maxTypes is read under mu, so that the entry is not stored
+	// with the policy replaced by setPolicy in the meantime.
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch maxTypes := atomic.LoadInt64(&c.maxTypes); {
+	case maxTypes == 0:
+		c.entries.Store(key, entry)
+	case maxTypes > 0:
+		if c.lruElems == nil {
+			c.lru = list.New()
+			c.lruElems = map[cacheKey]*list.Element{}
+		}
+		if elem, ok := c.lruElems[key]; ok {
+			elem.Value = entry
+			c.lru.MoveToFront(elem)
+		} else {
+			c.lruElems[key] = c.lru.PushFront(entry)
+		}
+		for int64(c.lru.Len()) > maxTypes {
+			oldest := c.lru.Back()
+			c.lru.Remove(oldest)
+			delete(c.lruElems, oldest.Value.(*cacheEntry).key)
+		}
+	}
+}
+
+func (c *codeCache) setPolicy(policy CachePolicy) {
+	c.mu.Lock()
+	atomic.StoreInt64(&c.maxTypes, policy.maxTypes)
+	c.clearLocked()
+	c.mu.Unlock()
+}
+
+func (c *codeCache) clear() {
+	c.mu.Lock()
+	c.clearLocked()
+	c.mu.Unlock()
+}
+
+func (c *codeCache) clearLocked() {
+	c.entries.Range(func(k, _ interface{}) bool {
+		c.entries.Delete(k)
+		return true
+	})
+	c.lru = nil
+	c.lruElems = nil
+}
+
+func (c *codeCache) stats() CacheStats {
+	stats := CacheStats{
+		Hits:   atomic.LoadUint64(&c.hits),
+		Misses: atomic.LoadUint64(&c.misses),
+	}
+	add := func(entry *cacheEntry) {
+		stats.Types = append(stats.Types, TypeStats{
+			Type:        rtype2type(*(**rtype)(unsafe.Pointer(&entry.key.typeptr))),
+			Opcodes:     entry.opcodes,
+			CompileTime: entry.compileTime,
+			Hits:        atomic.LoadUint64(&entry.hits),
+		})
+	}
+	if atomic.LoadInt64(&c.maxTypes) == 0 {
+		c.entries.Range(func(_, v interface{}) bool {
+			add(v.(*cacheEntry))
+			return true
+		})
+		return stats
+	}
+	c.mu.Lock()
+	if c.lru != nil {
+		for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+			add(elem.Value.(*cacheEntry))
+		}
+	}
+	c.mu.Unlock()
+	return stats
+}
@@ -38,6 +38,21 @@ type fieldType struct {
 	StringTagField string
 }
 
+// optionHeadType is the head operations of the struct field tag options
+// that are not specialized by the field type.
+type optionHeadType struct {
+	Head             string
+	PtrHead          string
+	AnonymousHead    string
+	AnonymousPtrHead string
+}
+
+// optionConvType converts the specialized operation of a field to the option operation.
+type optionConvType struct {
+	From string
+	To   string
+}
+
 func _main() error {
 	tmpl, err := template.New("").Parse(`// Code generated by cmd/generator. DO NOT EDIT!
 package json
@@ -103,6 +118,12 @@ func (t opType) headToPtrHead() opType {
     return op{{ $type.StringTagPtrHead }}
   case op{{ $type.AnonymousStringTagHead }}:
     return op{{ $type.AnonymousStringTagPtrHead }}
+{{- end }}
+{{- range $type := .OptionHeadTypes }}
+  case op{{ $type.Head }}:
+    return op{{ $type.PtrHead }}
+  case op{{ $type.AnonymousHead }}:
+    return op{{ $type.AnonymousPtrHead }}
 {{- end }}
   }
   return t
@@ -123,6 +144,12 @@ func (t opType) headToAnonymousHead() opType {
     return op{{ $type.AnonymousStringTagHead }}
   case op{{ $type.StringTagPtrHead }}:
     return op{{ $type.AnonymousStringTagPtrHead }}
+{{- end }}
+{{- range $type := .OptionHeadTypes }}
+  case op{{ $type.Head }}:
+    return op{{ $type.AnonymousHead }}
+  case op{{ $type.PtrHead }}:
+    return op{{ $type.AnonymousPtrHead }}
 {{- end }}
   }
   return t
@@ -167,6 +194,12 @@ func (t opType) ptrHeadToHead() opType {
     return op{{ $type.StringTagHead }}
   case op{{ $type.AnonymousStringTagPtrHead }}:
     return op{{ $type.AnonymousStringTagHead }}
+{{- end }}
+{{- range $type := .OptionHeadTypes }}
+  case op{{ $type.PtrHead }}:
+    return op{{ $type.Head }}
+  case op{{ $type.AnonymousPtrHead }}:
+    return op{{ $type.AnonymousHead }}
 {{- end }}
   }
   return t
@@ -192,6 +225,36 @@ func (t opType) fieldToStringTagField() opType {
   return t
 }
 
+func (t opType) toOmitEmptyStringTag() opType {
+  switch t {
+{{- range $type := .OmitEmptyStringTagTypes }}
+  case op{{ $type.From }}:
+    return op{{ $type.To }}
+{{- end }}
+  }
+  return t
+}
+
+func (t opType) toOmitZero() opType {
+  switch t {
+{{- range $type := .OmitZeroTypes }}
+  case op{{ $type.From }}:
+    return op{{ $type.To }}
+{{- end }}
+  }
+  return t
+}
+
+func (t opType) toOmitZeroMethod() opType {
+  switch t {
+{{- range $type := .OmitZeroMethodTypes }}
+  case op{{ $type.From }}:
+    return op{{ $type.To }}
+{{- end }}
+  }
+  return t
+}
+
 `)
 	if err != nil {
 		return err
@@ -224,6 +287,10 @@ func (t opType) fieldToStringTagField() opType {
 		{"Interface", "InterfaceIndent", "Op"},
 		{"InterfaceEnd", "InterfaceEndIndent", "Op"},
 		{"Ptr", "PtrIndent", "Op"},
+		{"Custom", "CustomIndent", "Op"},
+		{"AppendJSON", "AppendJSONIndent", "Op"},
+		{"Time", "TimeIndent", "Op"},
+		{"Duration", "DurationIndent", "Op"},
 		{"SliceHead", "SliceHeadIndent", "SliceHead"},
 		{"RootSliceHead", "RootSliceHeadIndent", "SliceHead"},
 		{"SliceElem", "SliceElemIndent", "SliceElem"},
@@ -252,6 +319,21 @@ func (t opType) fieldToStringTagField() opType {
 		{"StructField", "StructFieldIndent", "StructField"},
 		{"StructFieldOmitEmpty", "StructFieldOmitEmptyIndent", "StructField"},
 		{"StructFieldStringTag", "StructFieldStringTagIndent", "StructField"},
+		{"StructFieldHeadOmitEmptyStringTag", "StructFieldHeadOmitEmptyStringTagIndent", "StructField"},
+		{"StructFieldPtrHeadOmitEmptyStringTag", "StructFieldPtrHeadOmitEmptyStringTagIndent", "StructField"},
+		{"StructFieldAnonymousHeadOmitEmptyStringTag", "StructFieldAnonymousHeadOmitEmptyStringTagIndent", "StructField"},
+		{"StructFieldPtrAnonymousHeadOmitEmptyStringTag", "StructFieldPtrAnonymousHeadOmitEmptyStringTagIndent", "StructField"},
+		{"StructFieldOmitEmptyStringTag", "StructFieldOmitEmptyStringTagIndent", "StructField"},
+		{"StructFieldHeadOmitZero", "StructFieldHeadOmitZeroIndent", "StructField"},
+		{"StructFieldPtrHeadOmitZero", "StructFieldPtrHeadOmitZeroIndent", "StructField"},
+		{"StructFieldAnonymousHeadOmitZero", "StructFieldAnonymousHeadOmitZeroIndent", "StructField"},
+		{"StructFieldPtrAnonymousHeadOmitZero", "StructFieldPtrAnonymousHeadOmitZeroIndent", "StructField"},
+		{"StructFieldOmitZero", "StructFieldOmitZeroIndent", "StructField"},
+		{"StructFieldHeadOmitZeroMethod", "StructFieldHeadOmitZeroMethodIndent", "StructField"},
+		{"StructFieldPtrHeadOmitZeroMethod", "StructFieldPtrHeadOmitZeroMethodIndent", "StructField"},
+		{"StructFieldAnonymousHeadOmitZeroMethod", "StructFieldAnonymousHeadOmitZeroMethodIndent", "StructField"},
+		{"StructFieldPtrAnonymousHeadOmitZeroMethod", "StructFieldPtrAnonymousHeadOmitZeroMethodIndent", "StructField"},
+		{"StructFieldOmitZeroMethod", "StructFieldOmitZeroMethodIndent", "StructField"},
 		{"StructFieldRecursiveEnd", "StructFieldRecursiveEndIndent", "Op"},
 		{"StructEnd", "StructEndIndent", "StructField"},
 		{"StructAnonymousEnd", "StructAnonymousEndIndent", "StructField"},
@@ -263,6 +345,17 @@ func (t opType) fieldToStringTagField() opType {
 			Code:     "Op",
 		})
 	}
+	for _, typ := range []string{
+		"Int", "Int8", "Int16", "Int32", "Int64",
+		"Uint", "Uint8", "Uint16", "Uint32", "Uint64",
+	} {
+		// integer encoded as JSON string for map key
+		opTypes = append(opTypes, opType{
+			Op:       fmt.Sprintf("%sString", typ),
+			IndentOp: fmt.Sprintf("%sStringIndent", typ),
+			Code:     "Op",
+		})
+	}
 	for _, prefix := range []string{
 		"StructFieldHead",
 		"StructFieldHeadOmitEmpty",
@@ -365,17 +458,112 @@ func (t opType) fieldToStringTagField() opType {
 		})
 	}
 
+	optionHeadTypes := []optionHeadType{}
+	for _, option := range []string{"OmitEmptyStringTag", "OmitZero", "OmitZeroMethod"} {
+		optionHeadTypes = append(optionHeadTypes, optionHeadType{
+			Head:             fmt.Sprintf("StructFieldHead%s", option),
+			PtrHead:          fmt.Sprintf("StructFieldPtrHead%s", option),
+			AnonymousHead:    fmt.Sprintf("StructFieldAnonymousHead%s", option),
+			AnonymousPtrHead: fmt.Sprintf("StructFieldPtrAnonymousHead%s", option),
+		})
+	}
+	for _, typ := range optionHeadTypes {
+		optionHeadTypes = append(optionHeadTypes, optionHeadType{
+			Head:             fmt.Sprintf("%sIndent", typ.Head),
+			PtrHead:          fmt.Sprintf("%sIndent", typ.PtrHead),
+			AnonymousHead:    fmt.Sprintf("%sIndent", typ.AnonymousHead),
+			AnonymousPtrHead: fmt.Sprintf("%sIndent", typ.AnonymousPtrHead),
+		})
+	}
+
+	scalarTypes := []string{
+		"Int", "Int8", "Int16", "Int32", "Int64",
+		"Uint", "Uint8", "Uint16", "Uint32", "Uint64",
+		"Float32", "Float64", "Bool", "String",
+	}
+	isScalarType := map[string]bool{}
+	for _, typ := range scalarTypes {
+		isScalarType[typ] = true
+	}
+
+	// both omitempty and string options are encoded by the operations
+	// that are not specialized, because the string option applies only to scalar types.
+	omitEmptyStringTagTypes := []optionConvType{}
+	for _, prim := range scalarTypes {
+		for _, indent := range []string{"", "Indent"} {
+			omitEmptyStringTagTypes = append(omitEmptyStringTagTypes,
+				optionConvType{
+					From: fmt.Sprintf("StructFieldHead%s%s", prim, indent),
+					To:   fmt.Sprintf("StructFieldHeadOmitEmptyStringTag%s", indent),
+				},
+				optionConvType{
+					From: fmt.Sprintf("StructFieldPtrHead%s%s", prim, indent),
+					To:   fmt.Sprintf("StructFieldPtrHeadOmitEmptyStringTag%s", indent),
+				},
+				optionConvType{
+					From: fmt.Sprintf("StructField%s%s", prim, indent),
+					To:   fmt.Sprintf("StructFieldOmitEmptyStringTag%s", indent),
+				},
+			)
+		}
+	}
+
+	// omitzero omits the same values as omitempty for scalar types,
+	// so they are encoded by the specialized omitempty operations.
+	// The zero value of the other types is checked by the operations that are not specialized.
+	omitZeroTypes := []optionConvType{}
+	omitZeroMethodTypes := []optionConvType{}
+	for _, prim := range append([]string{""}, primitiveTypesUpper...) {
+		for _, indent := range []string{"", "Indent"} {
+			for _, head := range []string{"Head", "PtrHead"} {
+				from := fmt.Sprintf("StructField%s%s%s", head, prim, indent)
+				to := fmt.Sprintf("StructField%sOmitZero%s", head, indent)
+				if isScalarType[prim] {
+					to = fmt.Sprintf("StructField%sOmitEmpty%s%s", head, prim, indent)
+				}
+				omitZeroTypes = append(omitZeroTypes, optionConvType{From: from, To: to})
+				omitZeroMethodTypes = append(omitZeroMethodTypes, optionConvType{
+					From: from,
+					To:   fmt.Sprintf("StructField%sOmitZeroMethod%s", head, indent),
+				})
+			}
+			for _, field := range []string{"StructField", "StructFieldPtr"} {
+				if field == "StructFieldPtr" && prim == "" {
+					continue
+				}
+				from := fmt.Sprintf("%s%s%s", field, prim, indent)
+				to := fmt.Sprintf("StructFieldOmitZero%s", indent)
+				if field == "StructField" && isScalarType[prim] {
+					to = fmt.Sprintf("StructFieldOmitEmpty%s%s", prim, indent)
+				}
+				omitZeroTypes = append(omitZeroTypes, optionConvType{From: from, To: to})
+				omitZeroMethodTypes = append(omitZeroMethodTypes, optionConvType{
+					From: from,
+					To:   fmt.Sprintf("StructFieldOmitZeroMethod%s", indent),
+				})
+			}
+		}
+	}
+
 	var b bytes.Buffer
 	if err := tmpl.Execute(&b, struct {
-		CodeTypes  []string
-		OpTypes    []opType
-		HeadTypes  []headType
-		FieldTypes []fieldType
+		CodeTypes               []string
+		OpTypes                 []opType
+		HeadTypes               []headType
+		FieldTypes              []fieldType
+		OptionHeadTypes         []optionHeadType
+		OmitEmptyStringTagTypes []optionConvType
+		OmitZeroTypes           []optionConvType
+		OmitZeroMethodTypes     []optionConvType
 	}{
-		CodeTypes:  codeTypes,
-		OpTypes:    opTypes,
-		HeadTypes:  headTypes,
-		FieldTypes: fieldTypes,
+		CodeTypes:               codeTypes,
+		OpTypes:                 opTypes,
+		HeadTypes:               headTypes,
+		FieldTypes:              fieldTypes,
+		OptionHeadTypes:         optionHeadTypes,
+		OmitEmptyStringTagTypes: omitEmptyStringTagTypes,
+		OmitZeroTypes:           omitZeroTypes,
+		OmitZeroMethodTypes:     omitZeroMethodTypes,
 	}); err != nil {
 		return err
 	}
@@ -0,0 +1,42 @@
+// Package example has the types whose MarshalJSON and UnmarshalJSON methods are generated by jsongen,
+// and tests that the generated code encodes and decodes them in the same way as Marshal and Unmarshal.
+package example
+
+import "time"
+
+//go:generate go run github.com/goccy/go-json/cmd/jsongen -type User,Address -output example_json.go
+
+type Status string
+
+type Base struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at,omitzero"`
+}
+
+type Meta struct {
+	Version uint16 `json:"version,string"`
+	Note    string `json:"note,omitempty"`
+}
+
+type Address struct {
+	City    string  `json:"city"`
+	Zip     *string `json:"zip"`
+	Primary bool    `json:"primary,omitempty"`
+}
+
+type User struct {
+	Base
+	*Meta
+	Name     string            `json:"name"`
+	Status   Status            `json:"status,omitempty"`
+	Age      int8              `json:"age,string"`
+	Score    float64           `json:"score"`
+	Ratio    *float32          `json:"ratio"`
+	Home     Address           `json:"home"`
+	Work     *Address          `json:"work,omitempty"`
+	Tags     []string          `json:"tags"`
+	Attrs    map[string]string `json:"attrs,omitempty"`
+	Timeout  time.Duration     `json:"timeout"`
+	Password string            `json:"-"`
+	secret   string
+}
@@ -0,0 +1,242 @@
+// Code generated by jsongen. DO NOT EDIT.
+
+package example
+
+import (
+	"strconv"
+
+	"github.com/goccy/go-json"
+	"github.com/goccy/go-json/jsongen/runtime"
+)
+
+// MarshalJSON implements json.Marshaler.
+func (v User) MarshalJSON() ([]byte, error) {
+	return v.AppendJSON(make([]byte, 0, 960))
+}
+
+// AppendJSON appends the JSON encoding of v to b.
+func (v *User) AppendJSON(b []byte) ([]byte, error) {
+	var err error
+	b = append(b, '{')
+	b = append(b, "\"id\":"...)
+	b = strconv.AppendInt(b, int64(v.Base.ID), 10)
+	b = append(b, ',')
+	if !v.Base.CreatedAt.IsZero() {
+		b = append(b, "\"created_at\":"...)
+		if b, err = runtime.AppendTime(b, v.Base.CreatedAt); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	if v.Meta != nil {
+		b = append(b, "\"version\":"...)
+		b = append(b, '"')
+		b = strconv.AppendUint(b, uint64(v.Meta.Version), 10)
+		b = append(b, '"')
+		b = append(b, ',')
+	}
+	if v.Meta != nil {
+		if v.Meta.Note != "" {
+			b = append(b, "\"note\":"...)
+			b = runtime.AppendString(b, string(v.Meta.Note))
+			b = append(b, ',')
+		}
+	}
+	b = append(b, "\"name\":"...)
+	b = runtime.AppendString(b, string(v.Name))
+	b = append(b, ',')
+	if v.Status != "" {
+		b = append(b, "\"status\":"...)
+		b = runtime.AppendString(b, string(v.Status))
+		b = append(b, ',')
+	}
+	b = append(b, "\"age\":"...)
+	b = append(b, '"')
+	b = strconv.AppendInt(b, int64(v.Age), 10)
+	b = append(b, '"')
+	b = append(b, ',')
+	b = append(b, "\"score\":"...)
+	if b, err = runtime.AppendFloat64(b, float64(v.Score)); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	b = append(b, "\"ratio\":"...)
+	if v.Ratio == nil {
+		b = append(b, "null"...)
+	} else {
+		if b, err = runtime.AppendFloat32(b, float32(*v.Ratio)); err != nil {
+			return nil, err
+		}
+	}
+	b = append(b, ',')
+	b = append(b, "\"home\":"...)
+	if b, err = v.Home.AppendJSON(b); err != nil {
+		return nil, err
+	}
+	b = append(b, ',')
+	if v.Work != nil {
+		b = append(b, "\"work\":"...)
+		if v.Work == nil {
+			b = append(b, "null"...)
+		} else if b, err = v.Work.AppendJSON(b); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, "\"tags\":"...)
+	if v.Tags == nil {
+		b = append(b, "null"...)
+	} else {
+		b = append(b, '[')
+		for i0 := range v.Tags {
+			if i0 != 0 {
+				b = append(b, ',')
+			}
+			b = runtime.AppendString(b, string(v.Tags[i0]))
+		}
+		b = append(b, ']')
+	}
+	b = append(b, ',')
+	if len(v.Attrs) != 0 {
+		b = append(b, "\"attrs\":"...)
+		if b, err = json.AppendMarshal(b, &v.Attrs); err != nil {
+			return nil, err
+		}
+		b = append(b, ',')
+	}
+	b = append(b, "\"timeout\":"...)
+	b = strconv.AppendInt(b, int64(v.Timeout), 10)
+	b = append(b, ',')
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+	} else {
+		b = append(b, '}')
+	}
+	return b, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *User) UnmarshalJSON(data []byte) error {
+	return runtime.UnmarshalObject(data, v, func(key, value []byte) error {
+		for {
+			switch string(key) {
+			case "id", "ID":
+				return runtime.UnmarshalInt(value, &v.Base.ID)
+			case "created_at", "CreatedAt":
+				return json.Unmarshal(value, &v.Base.CreatedAt)
+			case "version", "Version":
+				if v.Meta == nil {
+					v.Meta = new(Meta)
+				}
+				value, err := runtime.Unquote(value)
+				if err != nil {
+					return err
+				}
+				return runtime.UnmarshalUint(value, &v.Meta.Version)
+			case "note", "Note":
+				if v.Meta == nil {
+					v.Meta = new(Meta)
+				}
+				return runtime.UnmarshalString(value, &v.Meta.Note)
+			case "name", "Name":
+				return runtime.UnmarshalString(value, &v.Name)
+			case "status", "Status":
+				return runtime.UnmarshalString(value, &v.Status)
+			case "age", "Age":
+				value, err := runtime.Unquote(value)
+				if err != nil {
+					return err
+				}
+				return runtime.UnmarshalInt(value, &v.Age)
+			case "score", "Score":
+				return runtime.UnmarshalFloat(value, &v.Score)
+			case "ratio", "Ratio":
+				if string(value) == "null" {
+					v.Ratio = nil
+					return nil
+				}
+				if v.Ratio == nil {
+					v.Ratio = new(float32)
+				}
+				return runtime.UnmarshalFloat(value, v.Ratio)
+			case "home", "Home":
+				return v.Home.UnmarshalJSON(value)
+			case "work", "Work":
+				if string(value) == "null" {
+					v.Work = nil
+					return nil
+				}
+				if v.Work == nil {
+					v.Work = new(Address)
+				}
+				return v.Work.UnmarshalJSON(value)
+			case "tags", "Tags":
+				return json.Unmarshal(value, &v.Tags)
+			case "attrs", "Attrs":
+				return json.Unmarshal(value, &v.Attrs)
+			case "timeout", "Timeout":
+				return runtime.UnmarshalInt(value, &v.Timeout)
+			}
+			if key = runtime.LowerKey(key); key == nil {
+				return nil
+			}
+		}
+	})
+}
+
+// MarshalJSON implements json.Marshaler.
+func (v Address) MarshalJSON() ([]byte, error) {
+	return v.AppendJSON(make([]byte, 0, 256))
+}
+
+// AppendJSON appends the JSON encoding of v to b.
+func (v *Address) AppendJSON(b []byte) ([]byte, error) {
+	b = append(b, '{')
+	b = append(b, "\"city\":"...)
+	b = runtime.AppendString(b, string(v.City))
+	b = append(b, ',')
+	b = append(b, "\"zip\":"...)
+	if v.Zip == nil {
+		b = append(b, "null"...)
+	} else {
+		b = runtime.AppendString(b, string(*v.Zip))
+	}
+	b = append(b, ',')
+	if v.Primary {
+		b = append(b, "\"primary\":"...)
+		b = strconv.AppendBool(b, bool(v.Primary))
+		b = append(b, ',')
+	}
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+	} else {
+		b = append(b, '}')
+	}
+	return b, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *Address) UnmarshalJSON(data []byte) error {
+	return runtime.UnmarshalObject(data, v, func(key, value []byte) error {
+		for {
+			switch string(key) {
+			case "city", "City":
+				return runtime.UnmarshalString(value, &v.City)
+			case "zip", "Zip":
+				if string(value) == "null" {
+					v.Zip = nil
+					return nil
+				}
+				if v.Zip == nil {
+					v.Zip = new(string)
+				}
+				return runtime.UnmarshalString(value, v.Zip)
+			case "primary", "Primary":
+				return runtime.UnmarshalBool(value, &v.Primary)
+			}
+			if key = runtime.LowerKey(key); key == nil {
+				return nil
+			}
+		}
+	})
+}
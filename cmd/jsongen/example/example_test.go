@@ -0,0 +1,338 @@
+package example
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/goccy/go-json"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	zip := "100-0001"
+	ratio := float32(0.5)
+	tests := []struct {
+		name     string
+		v        User
+		expected string
+	}{
+		{
+			name:     "zero",
+			expected: `{"id":0,"name":"","age":"0","score":0,"ratio":null,"home":{"city":"","zip":null},"tags":null,"timeout":0}`,
+		},
+		{
+			name: "all",
+			v: User{
+				Base:    Base{ID: 1, CreatedAt: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+				Meta:    &Meta{Version: 3, Note: "<note>"},
+				Name:    "Gopher \"go\"\n",
+				Status:  "active",
+				Age:     -12,
+				Score:   1e21,
+				Ratio:   &ratio,
+				Home:    Address{City: "Tokyo", Zip: &zip, Primary: true},
+				Work:    &Address{City: "Osaka"},
+				Tags:    []string{"a", "b"},
+				Attrs:   map[string]string{"k": "v"},
+				Timeout: time.Second,
+			},
+			expected: `{"id":1,"created_at":"2020-01-02T03:04:05Z","version":"3","note":"\u003cnote\u003e",` +
+				`"name":"Gopher \"go\"\n","status":"active","age":"-12","score":1e+21,"ratio":0.5,` +
+				`"home":{"city":"Tokyo","zip":"100-0001","primary":true},"work":{"city":"Osaka","zip":null},` +
+				`"tags":["a","b"],"attrs":{"k":"v"},"timeout":1000000000}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.v.MarshalJSON()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.expected != string(got) {
+				t.Fatalf("expected %s but got %s", test.expected, got)
+			}
+			var decoded User
+			if err := json.Unmarshal(got, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(test.v, decoded) {
+				t.Fatalf("expected %+v but got %+v", test.v, decoded)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	t.Run("keys", func(t *testing.T) {
+		var v User
+		src := `{"ID":1,"Name":"a","NAME":"b","unknown":[1,{"x":2}],"version":"7","home":{"city":"東京"},"ratio":null}`
+		if err := json.Unmarshal([]byte(src), &v); err != nil {
+			t.Fatal(err)
+		}
+		if v.ID != 1 || v.Name != "b" || v.Meta.Version != 7 || v.Home.City != "東京" || v.Ratio != nil {
+			t.Fatalf("unexpected value %+v", v)
+		}
+	})
+	t.Run("keys differing in case", func(t *testing.T) {
+		src := `{"Id":1,"nAmE":"a","AGE":"2","Home":{"CITY":"b","ZIP":"c"},"WORK":{"City":"d"},"Timeout":3,"CREATED_AT":"2020-01-02T03:04:05Z"}`
+		var expected vmUser
+		if err := json.Unmarshal([]byte(src), &expected); err != nil {
+			t.Fatal(err)
+		}
+		var got User
+		if err := json.Unmarshal([]byte(src), &got); err != nil {
+			t.Fatal(err)
+		}
+		e, err := json.Marshal(expected)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g, err := json.Marshal(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(e) != string(g) {
+			t.Fatalf("expected %s but got %s", e, g)
+		}
+		if got.ID != 1 || got.Name != "a" || got.Age != 2 || got.Home.City != "b" || got.Work.City != "d" || got.Timeout != 3 {
+			t.Fatalf("unexpected value %+v", got)
+		}
+	})
+	t.Run("null", func(t *testing.T) {
+		ratio := float32(1)
+		v := User{Name: "a", Ratio: &ratio}
+		if err := json.Unmarshal([]byte(`{"name":null,"ratio":null}`), &v); err != nil {
+			t.Fatal(err)
+		}
+		if v.Name != "a" || v.Ratio != nil {
+			t.Fatalf("unexpected value %+v", v)
+		}
+	})
+	t.Run("type mismatch", func(t *testing.T) {
+		for _, src := range []string{`{"age":"x"}`, `{"score":"1"}`, `{"id":1.5}`, `[]`} {
+			var v User
+			err := json.Unmarshal([]byte(src), &v)
+			if _, ok := err.(*json.UnmarshalTypeError); !ok {
+				t.Fatalf("%s: expected *json.UnmarshalTypeError but got %T: %v", src, err, err)
+			}
+		}
+	})
+}
+
+func TestMarshal(t *testing.T) {
+	zip := "100-0001"
+	v := User{
+		Base: Base{ID: 1},
+		Name: "<a>",
+		Home: Address{City: "Tokyo", Zip: &zip},
+		Work: &Address{City: "Osaka"},
+		Tags: []string{"a"},
+	}
+	expected, err := v.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range []interface{}{v, &v} {
+		got, err := json.Marshal(x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(expected) != string(got) {
+			t.Fatalf("expected %s but got %s", expected, got)
+		}
+	}
+	got, err := json.Marshal(struct {
+		Users []User  `json:"users"`
+		User  *User   `json:"user"`
+		Nil   *User   `json:"nil"`
+		Addr  Address `json:"addr"`
+	}{Users: []User{v}, User: &v, Addr: v.Home})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := `{"users":[` + string(expected) + `],"user":` + string(expected) + `,"nil":null,"addr":{"city":"Tokyo","zip":"100-0001"}}`; e != string(got) {
+		t.Fatalf("expected %s but got %s", e, got)
+	}
+	generated, err := json.Marshal(newBenchmarkUser())
+	if err != nil {
+		t.Fatal(err)
+	}
+	vm, err := json.Marshal(newBenchmarkVMUser())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(vm) != string(generated) {
+		t.Fatalf("expected %s but got %s", vm, generated)
+	}
+	got, err = json.MarshalIndent(v.Home, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := "{\n  \"city\": \"Tokyo\",\n  \"zip\": \"100-0001\"\n}"; e != string(got) {
+		t.Fatalf("expected %s but got %s", e, got)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	upper := func(b []byte, p unsafe.Pointer) ([]byte, error) {
+		return json.AppendMarshal(b, strings.ToUpper(*(*string)(p)))
+	}
+	tests := []struct {
+		name string
+		opts []json.EncodeOption
+	}{
+		{name: "FilterFields", opts: []json.EncodeOption{json.FilterFields("name", "home.city", "work")}},
+		{name: "EncodeTimeFormat", opts: []json.EncodeOption{json.EncodeTimeFormat(json.TimeFormatUnix)}},
+		{name: "EncodeDurationFormat", opts: []json.EncodeOption{json.EncodeDurationFormat(json.DurationFormatString)}},
+		{name: "CustomEncoder", opts: []json.EncodeOption{json.CustomEncoder(reflect.TypeOf(""), upper)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected, err := json.MarshalWithOption(newBenchmarkVMUser(), test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.MarshalWithOption(newBenchmarkUser(), test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(expected) != string(got) {
+				t.Fatalf("expected %s but got %s", expected, got)
+			}
+		})
+	}
+	t.Run("SetEscapeHTML", func(t *testing.T) {
+		var expected, got bytes.Buffer
+		for _, x := range []struct {
+			buf *bytes.Buffer
+			v   interface{}
+		}{{&expected, newBenchmarkVMUser()}, {&got, newBenchmarkUser()}} {
+			enc := json.NewEncoder(x.buf)
+			enc.SetEscapeHTML(false)
+			reflect.ValueOf(x.v).Elem().FieldByName("Name").SetString("<Gopher>")
+			if err := enc.Encode(x.v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if expected.String() != got.String() {
+			t.Fatalf("expected %s but got %s", expected.String(), got.String())
+		}
+	})
+	t.Run("DecodeDisallowUnknownFields", func(t *testing.T) {
+		var v User
+		if err := json.UnmarshalWithOption([]byte(`{"name":"a","unknown":1}`), &v, json.DecodeDisallowUnknownFields()); err == nil {
+			t.Fatal("expected unknown field error")
+		}
+	})
+	t.Run("DecodeCaseSensitive", func(t *testing.T) {
+		var v User
+		if err := json.UnmarshalWithOption([]byte(`{"NAME":"a","home":{"City":"b"}}`), &v, json.DecodeCaseSensitive()); err != nil {
+			t.Fatal(err)
+		}
+		if v.Name != "" || v.Home.City != "" {
+			t.Fatalf("unexpected value %+v", v)
+		}
+	})
+	t.Run("DecodeCollectErrors", func(t *testing.T) {
+		var v User
+		err := json.UnmarshalWithOption([]byte(`{"score":"1","name":"a","home":{"city":1}}`), &v, json.DecodeCollectErrors())
+		errs, ok := err.(json.UnmarshalTypeErrors)
+		if !ok || len(errs) != 2 {
+			t.Fatalf("expected 2 errors but got %T: %v", err, err)
+		}
+		if v.Name != "a" {
+			t.Fatalf("unexpected value %+v", v)
+		}
+	})
+	t.Run("DecodeDurationFormat", func(t *testing.T) {
+		var v User
+		if err := json.UnmarshalWithOption([]byte(`{"timeout":"1s"}`), &v, json.DecodeDurationFormat(json.DurationFormatString)); err != nil {
+			t.Fatal(err)
+		}
+		if v.Timeout != time.Second {
+			t.Fatalf("unexpected value %+v", v)
+		}
+	})
+}
+
+// vmUser has the same fields as User without the generated methods, so it is encoded by the opcode VM.
+type vmUser struct {
+	Base
+	*Meta
+	Name     string            `json:"name"`
+	Status   Status            `json:"status,omitempty"`
+	Age      int8              `json:"age,string"`
+	Score    float64           `json:"score"`
+	Ratio    *float32          `json:"ratio"`
+	Home     vmAddress         `json:"home"`
+	Work     *vmAddress        `json:"work,omitempty"`
+	Tags     []string          `json:"tags"`
+	Attrs    map[string]string `json:"attrs,omitempty"`
+	Timeout  time.Duration     `json:"timeout"`
+	Password string            `json:"-"`
+}
+
+type vmAddress struct {
+	City    string  `json:"city"`
+	Zip     *string `json:"zip"`
+	Primary bool    `json:"primary,omitempty"`
+}
+
+func newBenchmarkUser() *User {
+	zip := "100-0001"
+	ratio := float32(0.5)
+	return &User{
+		Base:    Base{ID: 1, CreatedAt: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+		Meta:    &Meta{Version: 3},
+		Name:    "Gopher",
+		Status:  "active",
+		Age:     12,
+		Score:   98.5,
+		Ratio:   &ratio,
+		Home:    Address{City: "Tokyo", Zip: &zip, Primary: true},
+		Work:    &Address{City: "Osaka"},
+		Tags:    []string{"a", "b", "c"},
+		Timeout: time.Second,
+	}
+}
+
+func newBenchmarkVMUser() *vmUser {
+	u := newBenchmarkUser()
+	work := vmAddress(*u.Work)
+	return &vmUser{
+		Base:    u.Base,
+		Meta:    u.Meta,
+		Name:    u.Name,
+		Status:  u.Status,
+		Age:     u.Age,
+		Score:   u.Score,
+		Ratio:   u.Ratio,
+		Home:    vmAddress(u.Home),
+		Work:    &work,
+		Tags:    u.Tags,
+		Timeout: u.Timeout,
+	}
+}
+
+func Benchmark_Encode_User_Generated(b *testing.B) {
+	v := newBenchmarkUser()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func Benchmark_Encode_User_VM(b *testing.B) {
+	v := newBenchmarkVMUser()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
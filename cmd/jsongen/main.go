@@ -0,0 +1,750 @@
+// Command jsongen generates the MarshalJSON and UnmarshalJSON methods of struct types,
+// which encode and decode the types without the opcode VM and the runtime compile step of go-json.
+// It also generates the AppendJSON method, which Marshal calls to append the encoding
+// without copying and compacting the output of MarshalJSON.
+// The generated code calls the functions of the package github.com/goccy/go-json/jsongen/runtime.
+//
+// Usage:
+//
+//	jsongen -type User,Order [-output user_json.go] [dir]
+//
+// The methods follow the same rules as Marshal and Unmarshal for the field order, the field names,
+// the omitempty, omitzero and string options of the struct tag and the fields of the embedded structs.
+// The fields of the types that are not specialized, such as slices and maps, are encoded and decoded by Marshal and Unmarshal,
+// and the fields of the types generated together call the generated code directly.
+// Marshal and Unmarshal call the generated code only without the options that change the encoding and decoding,
+// such as FilterFields and DecodeDisallowUnknownFields, and otherwise encode and decode the types like the other structs.
+// The generated code does not use the custom encoders and decoders registered by RegisterEncoder and RegisterDecoder.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/goccy/go-json/jsongen/runtime"
+)
+
+const (
+	jsonPkgPath    = "github.com/goccy/go-json"
+	runtimePkgPath = "github.com/goccy/go-json/jsongen/runtime"
+)
+
+var (
+	typeNames = flag.String("type", "", "comma-separated list of struct type names; must be set")
+	output    = flag.String("output", "", "output file name; default <dir>/<type>_json.go")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: jsongen -type T[,T...] [-output file] [dir]\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	names := strings.Split(*typeNames, ",")
+	path := *output
+	if path == "" {
+		path = filepath.Join(dir, strings.ToLower(names[0])+"_json.go")
+	}
+	if err := _main(dir, names, path); err != nil {
+		fmt.Fprintf(os.Stderr, "jsongen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func _main(dir string, names []string, path string) error {
+	pkg, err := loadPackage(dir, path)
+	if err != nil {
+		return err
+	}
+	src, err := generate(pkg, names)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, src, 0644)
+}
+
+// loadPackage type-checks the package in dir except the output file generated before.
+func loadPackage(dir, output string) (*types.Package, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	files := []*ast.File{}
+	for _, name := range bp.GoFiles {
+		path := filepath.Join(dir, name)
+		if filepath.Clean(path) == filepath.Clean(output) {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	return conf.Check(bp.Name, fset, files, nil)
+}
+
+type embeddedPtr struct {
+	selector string     // selector of the embedded pointer from the receiver
+	elem     types.Type // type that the embedded pointer points to
+}
+
+type field struct {
+	key       string // JSON key
+	name      string // Go field name
+	tagged    bool
+	omitEmpty bool
+	omitZero  bool
+	quoted    bool
+	selector  string        // selector of the field from the receiver such as Base.ID
+	embedded  []embeddedPtr // embedded pointers on the selector
+	typ       types.Type
+}
+
+type kind int
+
+const (
+	kindOther kind = iota
+	kindBool
+	kindString
+	kindInt
+	kindUint
+	kindFloat32
+	kindFloat64
+)
+
+type generator struct {
+	pkg      *types.Package
+	named    map[*types.Named]bool // types to generate
+	imports  map[string]string     // import path to package name
+	usesJSON bool                  // whether the generated code calls the json package
+	buf      bytes.Buffer
+	loops    int // number of the loops generated for the current type
+}
+
+func generate(pkg *types.Package, names []string) ([]byte, error) {
+	g := &generator{
+		pkg:     pkg,
+		named:   map[*types.Named]bool{},
+		imports: map[string]string{jsonPkgPath: "json", runtimePkgPath: "runtime"},
+	}
+	namedTypes := []*types.Named{}
+	for _, name := range names {
+		obj, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("type %s is not found in package %s", name, pkg.Name())
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			return nil, fmt.Errorf("type %s is not a defined type", name)
+		}
+		if _, ok := named.Underlying().(*types.Struct); !ok {
+			return nil, fmt.Errorf("type %s is not a struct type", name)
+		}
+		for _, method := range []string{"MarshalJSON", "AppendJSON", "UnmarshalJSON"} {
+			if obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(named), false, pkg, method); obj != nil {
+				return nil, fmt.Errorf("type %s already has %s", name, method)
+			}
+		}
+		g.named[named] = true
+		namedTypes = append(namedTypes, named)
+	}
+	for _, named := range namedTypes {
+		g.generateType(named)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by jsongen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg.Name())
+	stdPaths, paths := []string{}, []string{}
+	for path := range g.imports {
+		if path == jsonPkgPath && !g.usesJSON {
+			continue
+		}
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			paths = append(paths, path)
+		} else {
+			stdPaths = append(stdPaths, path)
+		}
+	}
+	sort.Strings(stdPaths)
+	sort.Strings(paths)
+	for _, path := range append(append(stdPaths, ""), paths...) {
+		name := g.imports[path]
+		switch {
+		case path == "":
+			b.WriteString("\n")
+		case path == jsonPkgPath || name == filepath.Base(path):
+			fmt.Fprintf(&b, "%q\n", path)
+		default:
+			fmt.Fprintf(&b, "%s %q\n", name, path)
+		}
+	}
+	b.WriteString(")\n")
+	b.Write(g.buf.Bytes())
+	return format.Source(b.Bytes())
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// qualifier returns the name of the package in the generated code and imports it.
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg == g.pkg {
+		return ""
+	}
+	if name, exists := g.imports[pkg.Path()]; exists {
+		return name
+	}
+	name := pkg.Name()
+	for i := 1; g.usedImportName(name); i++ {
+		name = fmt.Sprintf("%s%d", pkg.Name(), i)
+	}
+	g.imports[pkg.Path()] = name
+	return name
+}
+
+func (g *generator) usedImportName(name string) bool {
+	for _, used := range g.imports {
+		if used == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) use(path string) {
+	if _, exists := g.imports[path]; !exists {
+		g.imports[path] = filepath.Base(path)
+	}
+}
+
+func (g *generator) typeString(typ types.Type) string {
+	return types.TypeString(typ, g.qualifier)
+}
+
+func (g *generator) generateType(named *types.Named) {
+	name := named.Obj().Name()
+	fields := g.structFields(named.Underlying().(*types.Struct), "", nil, map[*types.Named]bool{named: true})
+
+	g.printf("\n// MarshalJSON implements json.Marshaler.\n")
+	g.printf("func (v %s) MarshalJSON() ([]byte, error) {\n", name)
+	g.printf("return v.AppendJSON(make([]byte, 0, %d))\n}\n", 64*(len(fields)+1))
+
+	g.printf("\n// AppendJSON appends the JSON encoding of v to b.\n")
+	g.printf("func (v *%s) AppendJSON(b []byte) ([]byte, error) {\n", name)
+	var code bytes.Buffer
+	usesErr := false
+	g.loops = 0
+	for _, f := range fields {
+		for _, ptr := range f.embedded {
+			fmt.Fprintf(&code, "if v.%s != nil {\n", ptr.selector)
+		}
+		x := "v." + f.selector
+		cond := g.nonEmptyCond(f, x)
+		if cond != "" {
+			fmt.Fprintf(&code, "if %s {\n", cond)
+		}
+		key := append(runtime.AppendString(nil, f.key), ':')
+		fmt.Fprintf(&code, "b = append(b, %s...)\n", strconv.Quote(string(key)))
+		value, err := g.encodeValue(x, f.typ, f.quoted)
+		code.WriteString(value)
+		usesErr = usesErr || err
+		code.WriteString("b = append(b, ',')\n")
+		if cond != "" {
+			code.WriteString("}\n")
+		}
+		for range f.embedded {
+			code.WriteString("}\n")
+		}
+	}
+	if usesErr {
+		g.printf("var err error\n")
+	}
+	g.printf("b = append(b, '{')\n")
+	g.buf.Write(code.Bytes())
+	g.printf("if b[len(b)-1] == ',' {\nb[len(b)-1] = '}'\n} else {\nb = append(b, '}')\n}\n")
+	g.printf("return b, nil\n}\n")
+
+	g.printf("\n// UnmarshalJSON implements json.Unmarshaler.\n")
+	g.printf("func (v *%s) UnmarshalJSON(data []byte) error {\n", name)
+	g.printf("return runtime.UnmarshalObject(data, v, func(key, value []byte) error {\n")
+	// the key that matches no field is looked up again by its lower case like the decoder
+	g.printf("for {\n")
+	g.printf("switch string(key) {\n")
+	for i, keys := range decodeKeys(fields) {
+		if len(keys) == 0 {
+			continue
+		}
+		quoted := make([]string, 0, len(keys))
+		for _, key := range keys {
+			quoted = append(quoted, strconv.Quote(key))
+		}
+		g.printf("case %s:\n", strings.Join(quoted, ", "))
+		f := fields[i]
+		for _, ptr := range f.embedded {
+			g.printf("if v.%s == nil {\nv.%s = new(%s)\n}\n", ptr.selector, ptr.selector, g.typeString(ptr.elem))
+		}
+		g.decodeValue("v."+f.selector, f.typ, f.quoted)
+	}
+	g.printf("}\nif key = runtime.LowerKey(key); key == nil {\nreturn nil\n}\n}\n})\n}\n")
+}
+
+// structFields returns the fields of st encoded in order with the same rules as the encoder and decoder:
+// the fields of embedded structs are promoted unless the embedded field has the key in the tag,
+// the field of the parent struct has priority over the promoted fields,
+// and the tagged field has priority if multiple embedded structs have the same key.
+func (g *generator) structFields(st *types.Struct, selector string, ptrs []embeddedPtr, visiting map[*types.Named]bool) []*field {
+	fields := []*field{}
+	keys := map[string]bool{}
+	promoted := map[string][]*field{}
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		tag := reflect.StructTag(st.Tag(i)).Get("json")
+		if isIgnoredField(v, tag) {
+			continue
+		}
+		f := fieldFromTag(v, tag)
+		f.selector = selector + v.Name()
+		f.embedded = ptrs
+		if v.Anonymous() && !f.tagged {
+			typ := v.Type()
+			ptr, isPtr := typ.(*types.Pointer)
+			if isPtr {
+				typ = ptr.Elem()
+			}
+			if embedded, ok := typ.Underlying().(*types.Struct); ok {
+				named, _ := typ.(*types.Named)
+				if visiting[named] {
+					// embedded recursive type does not promote fields
+					continue
+				}
+				embeddedPtrs := ptrs
+				if isPtr {
+					embeddedPtrs = append(append([]embeddedPtr{}, ptrs...), embeddedPtr{selector: f.selector, elem: typ})
+				}
+				visiting[named] = true
+				for _, promotedField := range g.structFields(embedded, f.selector+".", embeddedPtrs, visiting) {
+					promoted[promotedField.key] = append(promoted[promotedField.key], promotedField)
+					fields = append(fields, promotedField)
+				}
+				delete(visiting, named)
+				continue
+			}
+		}
+		keys[f.key] = true
+		fields = append(fields, f)
+	}
+	removed := map[*field]bool{}
+	for key, promotedFields := range promoted {
+		if keys[key] {
+			for _, f := range promotedFields {
+				removed[f] = true
+			}
+			continue
+		}
+		if len(promotedFields) == 1 {
+			continue
+		}
+		tagged := []*field{}
+		for _, f := range promotedFields {
+			if f.tagged {
+				tagged = append(tagged, f)
+			} else {
+				removed[f] = true
+			}
+		}
+		if len(tagged) > 1 {
+			for _, f := range tagged {
+				removed[f] = true
+			}
+		}
+	}
+	result := make([]*field, 0, len(fields))
+	for _, f := range fields {
+		if !removed[f] {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+func isIgnoredField(v *types.Var, tag string) bool {
+	if !v.Exported() {
+		if !v.Anonymous() {
+			return true
+		}
+		typ := v.Type()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		if _, ok := typ.Underlying().(*types.Struct); !ok {
+			return true
+		}
+	}
+	return tag == "-"
+}
+
+func fieldFromTag(v *types.Var, tag string) *field {
+	f := &field{key: v.Name(), name: v.Name(), typ: v.Type()}
+	opts := strings.Split(tag, ",")
+	if opts[0] != "" && isValidTag(opts[0]) {
+		f.key = opts[0]
+		f.tagged = true
+	}
+	for _, opt := range opts[1:] {
+		switch opt {
+		case "omitempty":
+			f.omitEmpty = true
+		case "omitzero":
+			f.omitZero = true
+		case "string":
+			f.quoted = true
+		}
+	}
+	return f
+}
+
+func isValidTag(s string) bool {
+	for _, c := range s {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:<=>?@[]^_{|}~ ", c):
+			// Backslash and quote chars are reserved, but
+			// otherwise any punctuation chars are allowed
+			// in a tag name.
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
+
+// decodeKeys returns the keys of the object that are decoded into each field in the same way as the decoder.
+// The JSON key of the later field has priority for the same key,
+// and the Go field name and the lower case of the JSON key are the aliases,
+// which never take the JSON key of another field and are taken by the first field.
+func decodeKeys(fields []*field) [][]string {
+	fieldIdx := map[string]int{}
+	order := []string{}
+	for i, f := range fields {
+		if _, exists := fieldIdx[f.key]; !exists {
+			order = append(order, f.key)
+		}
+		fieldIdx[f.key] = i
+	}
+	for i, f := range fields {
+		for _, alias := range []string{f.name, strings.ToLower(f.key)} {
+			if _, exists := fieldIdx[alias]; !exists {
+				order = append(order, alias)
+				fieldIdx[alias] = i
+			}
+		}
+	}
+	keys := make([][]string, len(fields))
+	for _, key := range order {
+		i := fieldIdx[key]
+		keys[i] = append(keys[i], key)
+	}
+	return keys
+}
+
+// scalarKind returns the kind of typ encoded and decoded by the specialized code.
+func scalarKind(typ types.Type) kind {
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok || hasMarshalMethod(typ) {
+		return kindOther
+	}
+	info := basic.Info()
+	switch {
+	case info&types.IsBoolean != 0:
+		return kindBool
+	case info&types.IsString != 0:
+		return kindString
+	case info&types.IsInteger != 0 && info&types.IsUnsigned != 0:
+		return kindUint
+	case info&types.IsInteger != 0:
+		return kindInt
+	case basic.Kind() == types.Float32:
+		return kindFloat32
+	case basic.Kind() == types.Float64:
+		return kindFloat64
+	}
+	return kindOther
+}
+
+func hasMarshalMethod(typ types.Type) bool {
+	for _, method := range []string{"MarshalJSON", "MarshalText", "UnmarshalJSON", "UnmarshalText"} {
+		if obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(typ), false, nil, method); obj != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isZeroMethod returns whether typ has IsZero method, and whether the method has the pointer receiver.
+func isZeroMethod(typ types.Type) (bool, bool) {
+	obj, _, _ := types.LookupFieldOrMethod(typ, true, nil, "IsZero")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false, false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 || !types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool]) {
+		return false, false
+	}
+	_, isPtr := sig.Recv().Type().(*types.Pointer)
+	if _, isPtrType := typ.(*types.Pointer); isPtrType {
+		isPtr = false
+	}
+	return true, isPtr
+}
+
+// nonEmptyCond returns the condition to encode the field with the omitempty or omitzero option.
+func (g *generator) nonEmptyCond(f *field, x string) string {
+	if f.omitZero {
+		if ok, isPtrRecv := isZeroMethod(f.typ); ok {
+			switch f.typ.Underlying().(type) {
+			case *types.Pointer, *types.Interface:
+				return fmt.Sprintf("%s != nil && !%s.IsZero()", x, x)
+			}
+			if isPtrRecv {
+				return fmt.Sprintf("!(&%s).IsZero()", x)
+			}
+			return fmt.Sprintf("!%s.IsZero()", x)
+		}
+		if !omitZeroAsEmpty(f) {
+			return g.nonZeroCond(f.typ, x)
+		}
+	}
+	if f.omitEmpty || f.omitZero {
+		return nonEmptyCond(f.typ, x)
+	}
+	return ""
+}
+
+// omitZeroAsEmpty reports whether the omitzero option omits the same values as omitempty.
+func omitZeroAsEmpty(f *field) bool {
+	switch typ := f.typ.Underlying().(type) {
+	case *types.Basic:
+		return typ.Info()&(types.IsBoolean|types.IsNumeric|types.IsString) != 0
+	case *types.Slice, *types.Map:
+		return f.omitEmpty
+	}
+	return false
+}
+
+func nonEmptyCond(typ types.Type, x string) string {
+	switch typ := typ.Underlying().(type) {
+	case *types.Basic:
+		info := typ.Info()
+		switch {
+		case info&types.IsBoolean != 0:
+			return x
+		case info&types.IsString != 0:
+			return fmt.Sprintf("%s != \"\"", x)
+		case info&types.IsNumeric != 0:
+			return fmt.Sprintf("%s != 0", x)
+		case typ.Kind() == types.UnsafePointer:
+			return fmt.Sprintf("%s != nil", x)
+		}
+	case *types.Pointer, *types.Interface, *types.Chan, *types.Signature:
+		return fmt.Sprintf("%s != nil", x)
+	case *types.Slice, *types.Map, *types.Array:
+		return fmt.Sprintf("len(%s) != 0", x)
+	}
+	return ""
+}
+
+func (g *generator) nonZeroCond(typ types.Type, x string) string {
+	switch typ.Underlying().(type) {
+	case *types.Pointer, *types.Interface, *types.Chan, *types.Signature, *types.Slice, *types.Map:
+		return fmt.Sprintf("%s != nil", x)
+	case *types.Struct, *types.Array:
+		if types.Comparable(typ) {
+			return fmt.Sprintf("%s != (%s{})", x, g.typeString(typ))
+		}
+		g.use("reflect")
+		return fmt.Sprintf("!reflect.ValueOf(%s).IsZero()", x)
+	}
+	return nonEmptyCond(typ, x)
+}
+
+// encodeValue returns the code to append the value x of typ, and whether the code uses the variable err.
+// The values of the types that are not specialized are appended by json.AppendMarshal,
+// and their pointer is passed so that the value is not copied into interface{}.
+func (g *generator) encodeValue(x string, typ types.Type, quoted bool) (string, bool) {
+	if code, usesErr, ok := g.encodeSpecialized(x, typ, quoted); ok {
+		return code, usesErr
+	}
+	g.usesJSON = true
+	return fmt.Sprintf("if b, err = json.AppendMarshal(b, &%s); err != nil {\nreturn nil, err\n}\n", x), true
+}
+
+// encodeSpecialized returns the code to append the value x of typ without json.AppendMarshal,
+// whether the code uses the variable err, and false if typ is not specialized.
+func (g *generator) encodeSpecialized(x string, typ types.Type, quoted bool) (string, bool, bool) {
+	if named, ok := typ.(*types.Named); ok && g.named[named] {
+		return fmt.Sprintf("if b, err = %s.AppendJSON(b); err != nil {\nreturn nil, err\n}\n", x), true, true
+	}
+	if isTimeType(typ) {
+		return fmt.Sprintf("if b, err = runtime.AppendTime(b, %s); err != nil {\nreturn nil, err\n}\n", x), true, true
+	}
+	if scalarKind(typ) != kindOther {
+		code, usesErr := g.encodeScalar(x, typ, quoted)
+		return code, usesErr, true
+	}
+	if ptr, ok := typ.(*types.Pointer); ok {
+		if named, ok := ptr.Elem().(*types.Named); ok && g.named[named] {
+			code := fmt.Sprintf("if %s == nil {\nb = append(b, \"null\"...)\n} else if b, err = %s.AppendJSON(b); err != nil {\nreturn nil, err\n}\n", x, x)
+			return code, true, true
+		}
+		code, usesErr, ok := g.encodeSpecialized("*"+x, ptr.Elem(), quoted)
+		if !ok {
+			return "", false, false
+		}
+		return fmt.Sprintf("if %s == nil {\nb = append(b, \"null\"...)\n} else {\n%s}\n", x, code), usesErr, true
+	}
+	if hasMarshalMethod(typ) {
+		return "", false, false
+	}
+	var (
+		elem    types.Type
+		nilable bool
+	)
+	switch typ := typ.Underlying().(type) {
+	case *types.Slice:
+		if basic, ok := typ.Elem().Underlying().(*types.Basic); ok && basic.Kind() == types.Uint8 {
+			// []byte is encoded as the base64 string
+			return "", false, false
+		}
+		elem, nilable = typ.Elem(), true
+	case *types.Array:
+		elem = typ.Elem()
+	default:
+		return "", false, false
+	}
+	i := fmt.Sprintf("i%d", g.loops)
+	g.loops++
+	elemCode, usesErr, ok := g.encodeSpecialized(fmt.Sprintf("%s[%s]", x, i), elem, false)
+	if !ok {
+		return "", false, false
+	}
+	code := fmt.Sprintf("b = append(b, '[')\nfor %[1]s := range %[2]s {\nif %[1]s != 0 {\nb = append(b, ',')\n}\n%[3]s}\nb = append(b, ']')\n", i, x, elemCode)
+	if nilable {
+		code = fmt.Sprintf("if %s == nil {\nb = append(b, \"null\"...)\n} else {\n%s}\n", x, code)
+	}
+	return code, usesErr, true
+}
+
+func isTimeType(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time"
+}
+
+func (g *generator) encodeScalar(x string, typ types.Type, quoted bool) (string, bool) {
+	var (
+		code    string
+		usesErr bool
+	)
+	switch scalarKind(typ) {
+	case kindBool:
+		g.use("strconv")
+		code = fmt.Sprintf("b = strconv.AppendBool(b, bool(%s))\n", x)
+	case kindInt:
+		g.use("strconv")
+		code = fmt.Sprintf("b = strconv.AppendInt(b, int64(%s), 10)\n", x)
+	case kindUint:
+		g.use("strconv")
+		code = fmt.Sprintf("b = strconv.AppendUint(b, uint64(%s), 10)\n", x)
+	case kindFloat32:
+		code = fmt.Sprintf("if b, err = runtime.AppendFloat32(b, float32(%s)); err != nil {\nreturn nil, err\n}\n", x)
+		usesErr = true
+	case kindFloat64:
+		code = fmt.Sprintf("if b, err = runtime.AppendFloat64(b, float64(%s)); err != nil {\nreturn nil, err\n}\n", x)
+		usesErr = true
+	case kindString:
+		if quoted {
+			return fmt.Sprintf("b = runtime.AppendString(b, string(runtime.AppendString(nil, string(%s))))\n", x), false
+		}
+		return fmt.Sprintf("b = runtime.AppendString(b, string(%s))\n", x), false
+	}
+	if quoted {
+		code = "b = append(b, '\"')\n" + code + "b = append(b, '\"')\n"
+	}
+	return code, usesErr
+}
+
+// decodeValue writes the code to decode value into x of typ and return the error.
+func (g *generator) decodeValue(x string, typ types.Type, quoted bool) {
+	if named, ok := typ.(*types.Named); ok && g.named[named] {
+		g.printf("return %s.UnmarshalJSON(value)\n", x)
+		return
+	}
+	if ptr, ok := typ.(*types.Pointer); ok {
+		elem := ptr.Elem()
+		named, isNamed := elem.(*types.Named)
+		if (isNamed && g.named[named]) || scalarKind(elem) != kindOther {
+			g.printf("if string(value) == \"null\" {\n%s = nil\nreturn nil\n}\n", x)
+			g.printf("if %s == nil {\n%s = new(%s)\n}\n", x, x, g.typeString(elem))
+			if isNamed && g.named[named] {
+				g.printf("return %s.UnmarshalJSON(value)\n", x)
+				return
+			}
+			g.decodeScalar(x, elem, quoted)
+			return
+		}
+	}
+	if scalarKind(typ) != kindOther {
+		g.decodeScalar("&"+x, typ, quoted)
+		return
+	}
+	g.usesJSON = true
+	g.printf("return json.Unmarshal(value, &%s)\n", x)
+}
+
+// decodeScalar writes the code to decode value into the pointer p to typ.
+func (g *generator) decodeScalar(p string, typ types.Type, quoted bool) {
+	if quoted {
+		g.printf("value, err := runtime.Unquote(value)\nif err != nil {\nreturn err\n}\n")
+	}
+	switch scalarKind(typ) {
+	case kindBool:
+		g.printf("return runtime.UnmarshalBool(value, %s)\n", p)
+	case kindString:
+		g.printf("return runtime.UnmarshalString(value, %s)\n", p)
+	case kindInt:
+		g.printf("return runtime.UnmarshalInt(value, %s)\n", p)
+	case kindUint:
+		g.printf("return runtime.UnmarshalUint(value, %s)\n", p)
+	case kindFloat32, kindFloat64:
+		g.printf("return runtime.UnmarshalFloat(value, %s)\n", p)
+	}
+}
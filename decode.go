@@ -5,7 +5,7 @@ import (
 	"io"
 	"reflect"
 	"strconv"
-	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -16,41 +16,50 @@ func (d Delim) String() string {
 }
 
 type decoder interface {
-	decode([]byte, int64, unsafe.Pointer) (int64, error)
+	decode(*decodeRuntimeContext, []byte, int64, unsafe.Pointer) (int64, error)
 	decodeStream(*stream, unsafe.Pointer) error
 }
 
-type Decoder struct {
-	s                     *stream
+// decodeRuntimeContext holds the settings of a single decode call.
+// It is passed through the decode path and shared with the stream.
+type decodeRuntimeContext struct {
+	useNumber             bool
 	disallowUnknownFields bool
-	structTypeToDecoder   map[uintptr]decoder
-}
-
-type decoderMap struct {
-	sync.Map
+	caseSensitive         bool
+	collectErrors         bool
+	strictTagOptions      bool
+	timeFormat            TimeFormat
+	durationFormat        DurationFormat
 }
 
-func (m *decoderMap) get(k uintptr) decoder {
-	if v, ok := m.Load(k); ok {
-		return v.(decoder)
-	}
-	return nil
+type Decoder struct {
+	s                   *stream
+	ctx                 decodeRuntimeContext
+	structTypeToDecoder map[uintptr]decoder
+	customDecoders      map[uintptr]DecoderFunc
+	customDecodersKey   string
+	tokenState          int
+	tokenStack          []int
 }
 
-func (m *decoderMap) set(k uintptr, dec decoder) {
-	m.Store(k, dec)
-}
+// states of the token stream read by Decoder.Token
+const (
+	tokenTopValue = iota
+	tokenArrayStart
+	tokenArrayValue
+	tokenArrayComma
+	tokenObjectStart
+	tokenObjectKey
+	tokenObjectColon
+	tokenObjectValue
+	tokenObjectComma
+)
 
 var (
-	cachedDecoder     decoderMap
 	unmarshalJSONType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
 	unmarshalTextType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
 )
 
-func init() {
-	cachedDecoder = decoderMap{}
-}
-
 const (
 	nul = '\000'
 )
@@ -60,11 +69,21 @@ const (
 // The decoder introduces its own buffering and may
 // read data from r beyond the JSON values requested.
 func NewDecoder(r io.Reader) *Decoder {
-	s := &stream{r: r}
-	s.read()
-	return &Decoder{
-		s: s,
-	}
+	d := &Decoder{}
+	d.s = newStream(r, &d.ctx)
+	d.s.read()
+	return d
+}
+
+// Reset discards the buffered input and the state of Token, and makes d read from r.
+// The settings such as UseNumber and DisallowUnknownFields and the compiled decoders are kept,
+// so that a Decoder can be pooled and reused for many readers.
+// The input is read into new buffers, because the decoded strings may refer to the previous ones.
+func (d *Decoder) Reset(r io.Reader) {
+	*d.s = stream{r: r, chunkSize: d.s.chunkSize, ctx: &d.ctx}
+	d.s.read()
+	d.tokenState = tokenTopValue
+	d.tokenStack = d.tokenStack[:0]
 }
 
 // Buffered returns a reader of the data remaining in the Decoder's
@@ -80,7 +99,44 @@ func (d *Decoder) validateType(typ *rtype, p uintptr) error {
 	return nil
 }
 
-func (d *Decoder) decode(src []byte, header *interfaceHeader) error {
+// decoderSet is the compiled decoder cached for the type.
+type decoderSet struct {
+	dec      decoder
+	tagCheck structTagCheck
+}
+
+func (d *Decoder) compileToGetDecoder(typeptr uintptr, typ *rtype) (decoder, error) {
+	if len(d.customDecoders) > 0 && d.customDecodersKey == "" {
+		d.customDecodersKey = d.customKey()
+	}
+	key := cacheKey{
+		typeptr:        typeptr,
+		caseSensitive:  d.ctx.caseSensitive,
+		timeFormat:     d.ctx.timeFormat,
+		durationFormat: d.ctx.durationFormat,
+		custom:         d.customDecodersKey,
+		generated:      d.usesGeneratedCode(),
+	}
+	decSet, _ := cachedDecoder.get(key).(*decoderSet)
+	if decSet == nil {
+		start := time.Now()
+		d.structTypeToDecoder = map[uintptr]decoder{}
+		dec, err := d.compileHead(typ)
+		if err != nil {
+			return nil, err
+		}
+		decSet = &decoderSet{dec: dec}
+		cachedDecoder.set(key, decSet, 0, time.Since(start))
+	}
+	if d.ctx.strictTagOptions {
+		if err := decSet.tagCheck.check(typ); err != nil {
+			return nil, err
+		}
+	}
+	return decSet.dec, nil
+}
+
+func (d *Decoder) decode(src []byte, cursor int64, header *interfaceHeader) error {
 	typ := header.typ
 	typeptr := uintptr(unsafe.Pointer(typ))
 
@@ -91,33 +147,38 @@ func (d *Decoder) decode(src []byte, header *interfaceHeader) error {
 	if err := d.validateType(copiedType, ptr); err != nil {
 		return err
 	}
-	dec := cachedDecoder.get(typeptr)
-	if dec == nil {
-		d.structTypeToDecoder = map[uintptr]decoder{}
-		compiledDec, err := d.compileHead(copiedType)
-		if err != nil {
-			return err
-		}
-		cachedDecoder.set(typeptr, compiledDec)
-		dec = compiledDec
-	}
-	if _, err := dec.decode(src, 0, header.ptr); err != nil {
+	dec, err := d.compileToGetDecoder(typeptr, copiedType)
+	if err != nil {
 		return err
 	}
+	if _, err := dec.decode(&d.ctx, src, cursor, header.ptr); err != nil {
+		return d.decodeError(err)
+	}
 	return nil
 }
 
-func (d *Decoder) decodeForUnmarshal(src []byte, v interface{}) error {
+// decodeError returns every UnmarshalTypeError as UnmarshalTypeErrors
+// when the Decoder collects errors.
+func (d *Decoder) decodeError(err error) error {
+	if errs, ok := collectTypeError(&d.ctx, nil, err); ok {
+		return errs
+	}
+	return err
+}
+
+func (d *Decoder) decodeForUnmarshal(src []byte, cursor int64, v interface{}) error {
 	header := (*interfaceHeader)(unsafe.Pointer(&v))
 	header.typ.escape()
-	return d.decode(src, header)
+	return d.decode(src, cursor, header)
 }
 
 func (d *Decoder) decodeForUnmarshalNoEscape(src []byte, v interface{}) error {
 	header := (*interfaceHeader)(unsafe.Pointer(&v))
-	return d.decode(src, header)
+	return d.decode(src, 0, header)
 }
 
+// prepareForDecode consumes the separator before the next value
+// according to the state of Token, so that Token and Decode can be mixed.
 func (d *Decoder) prepareForDecode() error {
 	s := d.s
 	for {
@@ -125,9 +186,6 @@ func (d *Decoder) prepareForDecode() error {
 		case ' ', '\t', '\r', '\n':
 			s.cursor++
 			continue
-		case ',', ':':
-			s.cursor++
-			return nil
 		case nul:
 			if s.read() {
 				continue
@@ -136,6 +194,23 @@ func (d *Decoder) prepareForDecode() error {
 		}
 		break
 	}
+	switch d.tokenState {
+	case tokenArrayComma:
+		if s.char() != ',' {
+			return errExpected("comma after array element", s.totalOffset())
+		}
+		s.cursor++
+		d.tokenState = tokenArrayValue
+	case tokenObjectColon:
+		if s.char() != ':' {
+			return errExpected("colon after object key", s.totalOffset())
+		}
+		s.cursor++
+		d.tokenState = tokenObjectValue
+	}
+	if !d.tokenValueAllowed() {
+		return errNotAtBeginningOfValue(s.totalOffset())
+	}
 	return nil
 }
 
@@ -156,26 +231,38 @@ func (d *Decoder) Decode(v interface{}) error {
 		return err
 	}
 
-	dec := cachedDecoder.get(typeptr)
-	if dec == nil {
-		d.structTypeToDecoder = map[uintptr]decoder{}
-		compiledDec, err := d.compileHead(typ)
-		if err != nil {
-			return err
-		}
-		cachedDecoder.set(typeptr, compiledDec)
-		dec = compiledDec
+	dec, err := d.compileToGetDecoder(typeptr, typ)
+	if err != nil {
+		return err
 	}
 	if err := d.prepareForDecode(); err != nil {
 		return err
 	}
 	s := d.s
 	if err := dec.decodeStream(s, header.ptr); err != nil {
+		err = d.decodeError(err)
+		switch err.(type) {
+		case *UnmarshalTypeError, UnmarshalTypeErrors:
+			// the value has been skipped
+			d.tokenValueEnd()
+		}
 		return err
 	}
+	d.tokenValueEnd()
 	return nil
 }
 
+// DecodeWithOption call Decode with DecodeOption.
+// The options stay set on d for the subsequent calls of Decode.
+func (d *Decoder) DecodeWithOption(v interface{}, opts ...DecodeOption) error {
+	for _, opt := range opts {
+		if err := opt(d); err != nil {
+			return err
+		}
+	}
+	return d.Decode(v)
+}
+
 func (d *Decoder) More() bool {
 	s := d.s
 	for {
@@ -196,6 +283,17 @@ func (d *Decoder) More() bool {
 	return true
 }
 
+// Token returns the next JSON token in the input stream.
+// At the end of the input stream, Token returns nil, io.EOF.
+//
+// Token guarantees that the delimiters [ ] { } it returns are
+// properly nested and matched: if Token encounters an unexpected
+// delimiter in the input, it will return an error.
+//
+// The input stream consists of basic JSON values—bool, string,
+// number, and null—along with delimiters [ ] { } of type Delim
+// to mark the start and end of arrays and objects.
+// Commas and colons are elided.
 func (d *Decoder) Token() (Token, error) {
 	s := d.s
 	for {
@@ -203,39 +301,112 @@ func (d *Decoder) Token() (Token, error) {
 		switch c {
 		case ' ', '\n', '\r', '\t':
 			s.cursor++
-		case '{', '[', ']', '}':
+		case '[':
+			if !d.tokenValueAllowed() {
+				return nil, d.tokenError()
+			}
 			s.cursor++
+			d.tokenStack = append(d.tokenStack, d.tokenState)
+			d.tokenState = tokenArrayStart
 			return Delim(c), nil
-		case ',', ':':
+		case ']':
+			if d.tokenState != tokenArrayStart && d.tokenState != tokenArrayComma {
+				return nil, d.tokenError()
+			}
 			s.cursor++
-		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-			bytes := floatBytes(s)
-			s := *(*string)(unsafe.Pointer(&bytes))
-			f64, err := strconv.ParseFloat(s, 64)
-			if err != nil {
-				return nil, err
+			d.popTokenState()
+			return Delim(c), nil
+		case '{':
+			if !d.tokenValueAllowed() {
+				return nil, d.tokenError()
 			}
-			return f64, nil
+			s.cursor++
+			d.tokenStack = append(d.tokenStack, d.tokenState)
+			d.tokenState = tokenObjectStart
+			return Delim(c), nil
+		case '}':
+			if d.tokenState != tokenObjectStart && d.tokenState != tokenObjectComma {
+				return nil, d.tokenError()
+			}
+			s.cursor++
+			d.popTokenState()
+			return Delim(c), nil
+		case ':':
+			if d.tokenState != tokenObjectColon {
+				return nil, d.tokenError()
+			}
+			s.cursor++
+			d.tokenState = tokenObjectValue
+		case ',':
+			switch d.tokenState {
+			case tokenArrayComma:
+				d.tokenState = tokenArrayValue
+			case tokenObjectComma:
+				d.tokenState = tokenObjectKey
+			default:
+				return nil, d.tokenError()
+			}
+			s.cursor++
 		case '"':
+			isKey := d.tokenState == tokenObjectStart || d.tokenState == tokenObjectKey
+			if !isKey && !d.tokenValueAllowed() {
+				return nil, d.tokenError()
+			}
 			bytes, err := stringBytes(s)
 			if err != nil {
 				return nil, err
 			}
+			if isKey {
+				d.tokenState = tokenObjectColon
+			} else {
+				d.tokenValueEnd()
+			}
 			return string(bytes), nil
+		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			if !d.tokenValueAllowed() {
+				return nil, d.tokenError()
+			}
+			bytes := floatBytes(s)
+			if err := validateNumber(bytes, s.totalOffset()); err != nil {
+				return nil, err
+			}
+			if d.ctx.useNumber {
+				d.tokenValueEnd()
+				return Number(string(bytes)), nil
+			}
+			str := *(*string)(unsafe.Pointer(&bytes))
+			f64, err := strconv.ParseFloat(str, 64)
+			if err != nil {
+				return nil, err
+			}
+			d.tokenValueEnd()
+			return f64, nil
 		case 't':
+			if !d.tokenValueAllowed() {
+				return nil, d.tokenError()
+			}
 			if err := trueBytes(s); err != nil {
 				return nil, err
 			}
+			d.tokenValueEnd()
 			return true, nil
 		case 'f':
+			if !d.tokenValueAllowed() {
+				return nil, d.tokenError()
+			}
 			if err := falseBytes(s); err != nil {
 				return nil, err
 			}
+			d.tokenValueEnd()
 			return false, nil
 		case 'n':
+			if !d.tokenValueAllowed() {
+				return nil, d.tokenError()
+			}
 			if err := nullBytes(s); err != nil {
 				return nil, err
 			}
+			d.tokenValueEnd()
 			return nil, nil
 		case nul:
 			if s.read() {
@@ -246,22 +417,86 @@ func (d *Decoder) Token() (Token, error) {
 			return nil, errInvalidCharacter(s.char(), "token", s.totalOffset())
 		}
 	}
-	return nil, io.EOF
+}
+
+// IsObjectKey reports whether the last token returned by Token is an object key.
+func (d *Decoder) IsObjectKey() bool {
+	return d.tokenState == tokenObjectColon
+}
+
+func (d *Decoder) tokenValueAllowed() bool {
+	switch d.tokenState {
+	case tokenTopValue, tokenArrayStart, tokenArrayValue, tokenObjectValue:
+		return true
+	}
+	return false
+}
+
+func (d *Decoder) tokenValueEnd() {
+	switch d.tokenState {
+	case tokenArrayStart, tokenArrayValue:
+		d.tokenState = tokenArrayComma
+	case tokenObjectValue:
+		d.tokenState = tokenObjectComma
+	}
+}
+
+// popTokenState restores the state of the enclosing value after ] or }.
+func (d *Decoder) popTokenState() {
+	last := len(d.tokenStack) - 1
+	d.tokenState = d.tokenStack[last]
+	d.tokenStack = d.tokenStack[:last]
+	d.tokenValueEnd()
+}
+
+func (d *Decoder) tokenError() error {
+	offset := d.s.totalOffset()
+	switch d.tokenState {
+	case tokenArrayComma:
+		return errExpected("comma after array element", offset)
+	case tokenObjectStart, tokenObjectKey:
+		return errExpected("object key", offset)
+	case tokenObjectColon:
+		return errExpected("colon after object key", offset)
+	case tokenObjectComma:
+		return errExpected("comma after object element", offset)
+	}
+	return errNotAtBeginningOfValue(offset)
 }
 
 // DisallowUnknownFields causes the Decoder to return an error when the destination
 // is a struct and the input contains object keys which do not match any
 // non-ignored, exported fields in the destination.
 func (d *Decoder) DisallowUnknownFields() {
-	d.s.disallowUnknownFields = true
+	d.ctx.disallowUnknownFields = true
 }
 
 func (d *Decoder) InputOffset() int64 {
 	return d.s.totalOffset()
 }
 
+// CaseSensitive causes the Decoder to match object keys to struct fields
+// case-sensitively. Only the exact key used by Marshal (the tag name or
+// the field name) is accepted.
+func (d *Decoder) CaseSensitive() {
+	d.ctx.caseSensitive = true
+}
+
+// CollectErrors causes the Decoder to continue decoding after a JSON value
+// cannot be stored in the destination. The value is skipped and Decode returns
+// UnmarshalTypeErrors that lists every mismatch with its field path and offset.
+func (d *Decoder) CollectErrors() {
+	d.ctx.collectErrors = true
+}
+
+// StrictTagOptions causes the Decoder to return UnsupportedTagOptionError
+// when a struct field tag of the destination has an option that is not supported.
+func (d *Decoder) StrictTagOptions() {
+	d.ctx.strictTagOptions = true
+}
+
 // UseNumber causes the Decoder to unmarshal a number into an interface{} as a
 // Number instead of as a float64.
 func (d *Decoder) UseNumber() {
-	d.s.useNumber = true
+	d.ctx.useNumber = true
 }
@@ -0,0 +1,36 @@
+package json
+
+import (
+	"unsafe"
+)
+
+// anonymousFieldDecoder decodes the field promoted from the embedded pointer to struct.
+// The embedded pointer is allocated if it is nil.
+type anonymousFieldDecoder struct {
+	structType *rtype
+	offset     uintptr
+	dec        decoder
+}
+
+func newAnonymousFieldDecoder(structType *rtype, offset uintptr, dec decoder) *anonymousFieldDecoder {
+	return &anonymousFieldDecoder{
+		structType: structType,
+		offset:     offset,
+		dec:        dec,
+	}
+}
+
+func (d *anonymousFieldDecoder) fieldPtr(p unsafe.Pointer) unsafe.Pointer {
+	if *(*unsafe.Pointer)(p) == nil {
+		*(*unsafe.Pointer)(p) = unsafe_New(d.structType)
+	}
+	return unsafe.Pointer(uintptr(*(*unsafe.Pointer)(p)) + d.offset)
+}
+
+func (d *anonymousFieldDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
+	return d.dec.decodeStream(s, d.fieldPtr(p))
+}
+
+func (d *anonymousFieldDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+	return d.dec.decode(ctx, buf, cursor, d.fieldPtr(p))
+}
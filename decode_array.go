@@ -3,14 +3,16 @@ package json
 import "unsafe"
 
 type arrayDecoder struct {
+	typ          *rtype
 	elemType     *rtype
 	size         uintptr
 	valueDecoder decoder
 	alen         int
 }
 
-func newArrayDecoder(dec decoder, elemType *rtype, alen int) *arrayDecoder {
+func newArrayDecoder(typ *rtype, dec decoder, elemType *rtype, alen int) *arrayDecoder {
 	return &arrayDecoder{
+		typ:          typ,
 		valueDecoder: dec,
 		elemType:     elemType,
 		size:         elemType.Size(),
@@ -28,18 +30,23 @@ func (d *arrayDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 			}
 			return nil
 		case '[':
+			var errs UnmarshalTypeErrors
 			idx := 0
 			for {
 				s.cursor++
 				addr := uintptr(p) + uintptr(idx)*d.size
 				if err := d.valueDecoder.decodeStream(s, unsafe.Pointer(addr)); err != nil {
-					return err
+					err = typeErrorWithIndex(err, idx)
+					var ok bool
+					if errs, ok = collectTypeError(s.ctx, errs, err); !ok {
+						return err
+					}
 				}
 				s.skipWhiteSpace()
 				switch s.char() {
 				case ']':
 					s.cursor++
-					return nil
+					return typeErrors(errs)
 				case ',':
 					idx++
 				case nul:
@@ -57,7 +64,7 @@ func (d *arrayDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 			}
 			goto ERROR
 		default:
-			goto ERROR
+			return s.skipTypeMismatch(d.typ)
 		}
 		s.cursor++
 	}
@@ -65,7 +72,7 @@ ERROR:
 	return errUnexpectedEndOfJSON("array", s.totalOffset())
 }
 
-func (d *arrayDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+func (d *arrayDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
 	buflen := int64(len(buf))
 	for ; cursor < buflen; cursor++ {
 		switch buf[cursor] {
@@ -88,20 +95,25 @@ func (d *arrayDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64
 			cursor += 4
 			return cursor, nil
 		case '[':
+			var errs UnmarshalTypeErrors
 			idx := 0
 			for {
 				cursor++
 				addr := uintptr(p) + uintptr(idx)*d.size
-				c, err := d.valueDecoder.decode(buf, cursor, unsafe.Pointer(addr))
+				c, err := d.valueDecoder.decode(ctx, buf, cursor, unsafe.Pointer(addr))
 				if err != nil {
-					return 0, err
+					err = typeErrorWithIndex(err, idx)
+					var ok bool
+					if errs, ok = collectTypeError(ctx, errs, err); !ok {
+						return c, err
+					}
 				}
 				cursor = c
 				cursor = skipWhiteSpace(buf, cursor)
 				switch buf[cursor] {
 				case ']':
 					cursor++
-					return cursor, nil
+					return cursor, typeErrors(errs)
 				case ',':
 					idx++
 					continue
@@ -109,6 +121,8 @@ func (d *arrayDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64
 					return 0, errInvalidCharacter(buf[cursor], "array", cursor)
 				}
 			}
+		default:
+			return skipTypeMismatch(buf, cursor, d.typ)
 		}
 	}
 	return 0, errUnexpectedEndOfJSON("array", cursor)
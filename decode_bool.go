@@ -4,14 +4,16 @@ import (
 	"unsafe"
 )
 
-type boolDecoder struct{}
+type boolDecoder struct {
+	typ *rtype
+}
 
-func newBoolDecoder() *boolDecoder {
-	return &boolDecoder{}
+func newBoolDecoder(typ *rtype) *boolDecoder {
+	return &boolDecoder{typ: typ}
 }
 
 func trueBytes(s *stream) error {
-	if s.cursor+3 >= s.length {
+	for s.cursor+3 >= s.length {
 		if !s.read() {
 			return errInvalidCharacter(s.char(), "bool(true)", s.totalOffset())
 		}
@@ -33,7 +35,7 @@ func trueBytes(s *stream) error {
 }
 
 func falseBytes(s *stream) error {
-	if s.cursor+4 >= s.length {
+	for s.cursor+4 >= s.length {
 		if !s.read() {
 			return errInvalidCharacter(s.char(), "bool(false)", s.totalOffset())
 		}
@@ -74,19 +76,25 @@ func (d *boolDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 			}
 			**(**bool)(unsafe.Pointer(&p)) = false
 			return nil
+		case 'n':
+			if err := nullBytes(s); err != nil {
+				return err
+			}
+			return nil
 		case nul:
 			if s.read() {
 				continue
 			}
 			goto ERROR
+		default:
+			return s.skipTypeMismatch(d.typ)
 		}
-		break
 	}
 ERROR:
 	return errUnexpectedEndOfJSON("bool", s.totalOffset())
 }
 
-func (d *boolDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+func (d *boolDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
 	buflen := int64(len(buf))
 	cursor = skipWhiteSpace(buf, cursor)
 	switch buf[cursor] {
@@ -125,6 +133,10 @@ func (d *boolDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64,
 		cursor += 5
 		**(**bool)(unsafe.Pointer(&p)) = false
 		return cursor, nil
+	case 'n':
+		return skipNull(buf, cursor)
+	case nul:
+		return 0, errUnexpectedEndOfJSON("bool", cursor)
 	}
-	return 0, errUnexpectedEndOfJSON("bool", cursor)
+	return skipTypeMismatch(buf, cursor, d.typ)
 }
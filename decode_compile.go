@@ -7,7 +7,12 @@ import (
 )
 
 func (d *Decoder) compileHead(typ *rtype) (decoder, error) {
+	if d.isFormatType(typ.Elem()) {
+		return d.compileFormat(typ.Elem()), nil
+	}
 	switch {
+	case isGeneratedType(typ) && !d.usesGeneratedCode():
+		// the struct is compiled instead of the generated UnmarshalJSON
 	case typ.Implements(unmarshalJSONType):
 		return newUnmarshalJSONDecoder(typ), nil
 	case rtype_ptrTo(typ).Implements(marshalJSONType):
@@ -21,7 +26,19 @@ func (d *Decoder) compileHead(typ *rtype) (decoder, error) {
 }
 
 func (d *Decoder) compile(typ *rtype) (decoder, error) {
+	if fn := d.customDecoder(typ); fn != nil {
+		return newCustomDecoder(fn), nil
+	}
+	if d.isFormatType(typ) {
+		return d.compileFormat(typ), nil
+	}
+	if typ.Kind() == reflect.Ptr && d.isFormatType(typ.Elem()) {
+		// the pointer type implements Unmarshaler by the methods of the value type
+		return d.compilePtr(typ)
+	}
 	switch {
+	case isGeneratedType(typ) && !d.usesGeneratedCode():
+		// the struct is compiled instead of the generated UnmarshalJSON
 	case typ.Implements(unmarshalJSONType):
 		return newUnmarshalJSONDecoder(typ), nil
 	case rtype_ptrTo(typ).Implements(marshalJSONType):
@@ -46,39 +63,48 @@ func (d *Decoder) compile(typ *rtype) (decoder, error) {
 	case reflect.Interface:
 		return d.compileInterface(typ)
 	case reflect.Uintptr:
-		return d.compileUint()
+		return d.compileUint(typ)
 	case reflect.Int:
-		return d.compileInt()
+		return d.compileInt(typ)
 	case reflect.Int8:
-		return d.compileInt8()
+		return d.compileInt8(typ)
 	case reflect.Int16:
-		return d.compileInt16()
+		return d.compileInt16(typ)
 	case reflect.Int32:
-		return d.compileInt32()
+		return d.compileInt32(typ)
 	case reflect.Int64:
-		return d.compileInt64()
+		return d.compileInt64(typ)
 	case reflect.Uint:
-		return d.compileUint()
+		return d.compileUint(typ)
 	case reflect.Uint8:
-		return d.compileUint8()
+		return d.compileUint8(typ)
 	case reflect.Uint16:
-		return d.compileUint16()
+		return d.compileUint16(typ)
 	case reflect.Uint32:
-		return d.compileUint32()
+		return d.compileUint32(typ)
 	case reflect.Uint64:
-		return d.compileUint64()
+		return d.compileUint64(typ)
 	case reflect.String:
-		return d.compileString()
+		return d.compileString(typ)
 	case reflect.Bool:
-		return d.compileBool()
+		return d.compileBool(typ)
 	case reflect.Float32:
-		return d.compileFloat32()
+		return d.compileFloat32(typ)
 	case reflect.Float64:
-		return d.compileFloat64()
+		return d.compileFloat64(typ)
 	}
 	return nil, &UnsupportedTypeError{Type: rtype2type(typ)}
 }
 
+// usesGeneratedCode reports whether the methods generated by cmd/jsongen decode their types.
+// The generated code decodes like Unmarshal without options,
+// so the types are compiled like the other structs with the options that change the decoding.
+func (d *Decoder) usesGeneratedCode() bool {
+	ctx := &d.ctx
+	return !ctx.useNumber && !ctx.disallowUnknownFields && !ctx.caseSensitive && !ctx.collectErrors &&
+		ctx.timeFormat.isDefault() && ctx.durationFormat == DurationFormatNanoseconds && len(d.customDecoders) == 0
+}
+
 func (d *Decoder) compilePtr(typ *rtype) (decoder, error) {
 	dec, err := d.compile(typ.Elem())
 	if err != nil {
@@ -87,84 +113,84 @@ func (d *Decoder) compilePtr(typ *rtype) (decoder, error) {
 	return newPtrDecoder(dec, typ.Elem()), nil
 }
 
-func (d *Decoder) compileInt() (decoder, error) {
-	return newIntDecoder(func(p unsafe.Pointer, v int64) {
+func (d *Decoder) compileInt(typ *rtype) (decoder, error) {
+	return newIntDecoder(typ, func(p unsafe.Pointer, v int64) {
 		*(*int)(p) = int(v)
 	}), nil
 }
 
-func (d *Decoder) compileInt8() (decoder, error) {
-	return newIntDecoder(func(p unsafe.Pointer, v int64) {
+func (d *Decoder) compileInt8(typ *rtype) (decoder, error) {
+	return newIntDecoder(typ, func(p unsafe.Pointer, v int64) {
 		*(*int8)(p) = int8(v)
 	}), nil
 }
 
-func (d *Decoder) compileInt16() (decoder, error) {
-	return newIntDecoder(func(p unsafe.Pointer, v int64) {
+func (d *Decoder) compileInt16(typ *rtype) (decoder, error) {
+	return newIntDecoder(typ, func(p unsafe.Pointer, v int64) {
 		*(*int16)(p) = int16(v)
 	}), nil
 }
 
-func (d *Decoder) compileInt32() (decoder, error) {
-	return newIntDecoder(func(p unsafe.Pointer, v int64) {
+func (d *Decoder) compileInt32(typ *rtype) (decoder, error) {
+	return newIntDecoder(typ, func(p unsafe.Pointer, v int64) {
 		*(*int32)(p) = int32(v)
 	}), nil
 }
 
-func (d *Decoder) compileInt64() (decoder, error) {
-	return newIntDecoder(func(p unsafe.Pointer, v int64) {
+func (d *Decoder) compileInt64(typ *rtype) (decoder, error) {
+	return newIntDecoder(typ, func(p unsafe.Pointer, v int64) {
 		*(*int64)(p) = v
 	}), nil
 }
 
-func (d *Decoder) compileUint() (decoder, error) {
-	return newUintDecoder(func(p unsafe.Pointer, v uint64) {
+func (d *Decoder) compileUint(typ *rtype) (decoder, error) {
+	return newUintDecoder(typ, func(p unsafe.Pointer, v uint64) {
 		*(*uint)(p) = uint(v)
 	}), nil
 }
 
-func (d *Decoder) compileUint8() (decoder, error) {
-	return newUintDecoder(func(p unsafe.Pointer, v uint64) {
+func (d *Decoder) compileUint8(typ *rtype) (decoder, error) {
+	return newUintDecoder(typ, func(p unsafe.Pointer, v uint64) {
 		*(*uint8)(p) = uint8(v)
 	}), nil
 }
 
-func (d *Decoder) compileUint16() (decoder, error) {
-	return newUintDecoder(func(p unsafe.Pointer, v uint64) {
+func (d *Decoder) compileUint16(typ *rtype) (decoder, error) {
+	return newUintDecoder(typ, func(p unsafe.Pointer, v uint64) {
 		*(*uint16)(p) = uint16(v)
 	}), nil
 }
 
-func (d *Decoder) compileUint32() (decoder, error) {
-	return newUintDecoder(func(p unsafe.Pointer, v uint64) {
+func (d *Decoder) compileUint32(typ *rtype) (decoder, error) {
+	return newUintDecoder(typ, func(p unsafe.Pointer, v uint64) {
 		*(*uint32)(p) = uint32(v)
 	}), nil
 }
 
-func (d *Decoder) compileUint64() (decoder, error) {
-	return newUintDecoder(func(p unsafe.Pointer, v uint64) {
+func (d *Decoder) compileUint64(typ *rtype) (decoder, error) {
+	return newUintDecoder(typ, func(p unsafe.Pointer, v uint64) {
 		*(*uint64)(p) = v
 	}), nil
 }
 
-func (d *Decoder) compileFloat32() (decoder, error) {
-	return newFloatDecoder(func(p unsafe.Pointer, v float64) {
+func (d *Decoder) compileFloat32(typ *rtype) (decoder, error) {
+	return newFloatDecoder(typ, func(p unsafe.Pointer, v float64) {
 		*(*float32)(p) = float32(v)
 	}), nil
 }
 
-func (d *Decoder) compileFloat64() (decoder, error) {
-	return newFloatDecoder(func(p unsafe.Pointer, v float64) {
+func (d *Decoder) compileFloat64(typ *rtype) (decoder, error) {
+	return newFloatDecoder(typ, func(p unsafe.Pointer, v float64) {
 		*(*float64)(p) = v
 	}), nil
 }
 
-func (d *Decoder) compileString() (decoder, error) {
-	return newStringDecoder(), nil
+func (d *Decoder) compileString(typ *rtype) (decoder, error) {
+	return newStringDecoder(typ), nil
 }
 
-func (d *Decoder) compileBool() (decoder, error) {
-	return newBoolDecoder(), nil
+func (d *Decoder) compileBool(typ *rtype) (decoder, error) {
+	return newBoolDecoder(typ), nil
 }
 
 func (d *Decoder) compileSlice(typ *rtype) (decoder, error) {
@@ -173,7 +199,7 @@ func (d *Decoder) compileSlice(typ *rtype) (decoder, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newSliceDecoder(decoder, elem, elem.Size()), nil
+	return newSliceDecoder(typ, decoder, elem, elem.Size()), nil
 }
 
 func (d *Decoder) compileArray(typ *rtype) (decoder, error) {
@@ -182,11 +208,11 @@ func (d *Decoder) compileArray(typ *rtype) (decoder, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newArrayDecoder(decoder, elem, typ.Len()), nil
+	return newArrayDecoder(typ, decoder, elem, typ.Len()), nil
 }
 
 func (d *Decoder) compileMap(typ *rtype) (decoder, error) {
-	keyDec, err := d.compile(typ.Key())
+	keyDec, err := d.compileMapKey(typ.Key())
 	if err != nil {
 		return nil, err
 	}
@@ -197,6 +223,25 @@ func (d *Decoder) compileMap(typ *rtype) (decoder, error) {
 	return newMapDecoder(typ, keyDec, valueDec), nil
 }
 
+// compileMapKey compiles the decoder of map key in the same priority as encoding/json:
+// encoding.TextUnmarshaler, string kinds and then integer kinds.
+func (d *Decoder) compileMapKey(typ *rtype) (decoder, error) {
+	switch {
+	case typ.Implements(unmarshalTextType):
+		return newUnmarshalTextDecoder(typ), nil
+	case rtype_ptrTo(typ).Implements(unmarshalTextType):
+		return newUnmarshalTextDecoder(rtype_ptrTo(typ)), nil
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		return d.compileString(typ)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return newIntegerKeyDecoder(typ), nil
+	}
+	return d.compile(typ)
+}
+
 func (d *Decoder) compileInterface(typ *rtype) (decoder, error) {
 	return newInterfaceDecoder(typ), nil
 }
@@ -208,26 +253,149 @@ func (d *Decoder) compileStruct(typ *rtype) (decoder, error) {
 	if dec, exists := d.structTypeToDecoder[typeptr]; exists {
 		return dec, nil
 	}
-	structDec := newStructDecoder(fieldMap)
+	structDec := newStructDecoder(typ, fieldMap)
 	d.structTypeToDecoder[typeptr] = structDec
+	fields := []*structFieldSet{}
+	tags := structTags{}
+	anonymousFields := map[string][]*structFieldSet{}
 	for i := 0; i < fieldNum; i++ {
 		field := typ.Field(i)
 		if isIgnoredStructField(field) {
 			continue
 		}
 		tag := structTagFromField(field)
+		if field.Anonymous && !tag.isTaggedKey {
+			fieldSets, err := d.anonymousFieldSets(field)
+			if err != nil {
+				return nil, err
+			}
+			if fieldSets != nil {
+				for _, fieldSet := range fieldSets {
+					anonymousFields[fieldSet.key] = append(anonymousFields[fieldSet.key], fieldSet)
+				}
+				fields = append(fields, fieldSets...)
+				continue
+			}
+		}
 		dec, err := d.compile(type2rtype(field.Type))
 		if err != nil {
 			return nil, err
 		}
-		if tag.isString {
+		if tag.isString && !d.isFormatField(type2rtype(field.Type)) {
 			dec = newWrappedStringDecoder(dec)
 		}
-		fieldSet := &structFieldSet{dec: dec, offset: field.Offset}
-		fieldMap[field.Name] = fieldSet
-		fieldMap[tag.key] = fieldSet
-		fieldMap[strings.ToLower(tag.key)] = fieldSet
+		tags = append(tags, tag)
+		fields = append(fields, &structFieldSet{
+			dec:         dec,
+			offset:      field.Offset,
+			key:         tag.key,
+			name:        field.Name,
+			isTaggedKey: tag.isTaggedKey,
+		})
+	}
+	removedFields := removedAnonymousFields(tags, anonymousFields)
+	for _, fieldSet := range fields {
+		if _, removed := removedFields[fieldSet]; removed {
+			continue
+		}
+		structDec.fields = append(structDec.fields, fieldSet)
+		fieldMap[fieldSet.key] = fieldSet
+	}
+	structDec.caseSensitive = d.ctx.caseSensitive
+	if !d.ctx.caseSensitive {
+		// the Go name and the lower case key are aliases,
+		// which never take the exact key of another field and are taken by the first field.
+		for _, fieldSet := range structDec.fields {
+			for _, alias := range []string{fieldSet.name, strings.ToLower(fieldSet.key)} {
+				if _, exists := fieldMap[alias]; !exists {
+					fieldMap[alias] = fieldSet
+				}
+			}
+		}
 	}
 	delete(d.structTypeToDecoder, typeptr)
 	return structDec, nil
 }
+
+// anonymousFieldSets returns the fields of the embedded struct that are promoted to the parent struct,
+// or nil if field is not embedded struct or pointer to struct.
+func (d *Decoder) anonymousFieldSets(field reflect.StructField) ([]*structFieldSet, error) {
+	fieldType := type2rtype(field.Type)
+	isPtr := fieldType.Kind() == reflect.Ptr
+	if isPtr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	if _, exists := d.structTypeToDecoder[uintptr(unsafe.Pointer(fieldType))]; exists {
+		// embedded recursive type does not promote fields
+		return []*structFieldSet{}, nil
+	}
+	dec, err := d.compileStruct(fieldType)
+	if err != nil {
+		return nil, err
+	}
+	fieldSets := []*structFieldSet{}
+	for _, fieldSet := range dec.(*structDecoder).fields {
+		promoted := *fieldSet
+		promoted.depth++
+		if isPtr {
+			promoted.dec = newAnonymousFieldDecoder(fieldType, fieldSet.offset, fieldSet.dec)
+			promoted.offset = field.Offset
+		} else {
+			promoted.offset = field.Offset + fieldSet.offset
+		}
+		fieldSets = append(fieldSets, &promoted)
+	}
+	return fieldSets, nil
+}
+
+// removedAnonymousFields returns the promoted fields hidden by the rules of the encoder:
+// the field of the parent struct has priority, then the shallowest fields have priority,
+// and then the tagged field has priority if multiple embedded structs have the same key.
+func removedAnonymousFields(tags structTags, anonymousFields map[string][]*structFieldSet) map[*structFieldSet]struct{} {
+	removedFields := map[*structFieldSet]struct{}{}
+	for key, fieldSets := range anonymousFields {
+		if tags.existsKey(key) {
+			for _, fieldSet := range fieldSets {
+				removedFields[fieldSet] = struct{}{}
+			}
+			continue
+		}
+		if len(fieldSets) == 1 {
+			continue
+		}
+		depth := fieldSets[0].depth
+		for _, fieldSet := range fieldSets {
+			if fieldSet.depth < depth {
+				depth = fieldSet.depth
+			}
+		}
+		shallowFieldSets := []*structFieldSet{}
+		for _, fieldSet := range fieldSets {
+			if fieldSet.depth > depth {
+				removedFields[fieldSet] = struct{}{}
+			} else {
+				shallowFieldSets = append(shallowFieldSets, fieldSet)
+			}
+		}
+		if len(shallowFieldSets) == 1 {
+			continue
+		}
+		taggedFieldSets := []*structFieldSet{}
+		for _, fieldSet := range shallowFieldSets {
+			if fieldSet.isTaggedKey {
+				taggedFieldSets = append(taggedFieldSets, fieldSet)
+			} else {
+				removedFields[fieldSet] = struct{}{}
+			}
+		}
+		if len(taggedFieldSets) > 1 {
+			for _, fieldSet := range taggedFieldSets {
+				removedFields[fieldSet] = struct{}{}
+			}
+		}
+	}
+	return removedFields
+}
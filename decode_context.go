@@ -2,6 +2,27 @@ package json
 
 var (
 	isWhiteSpace = [256]bool{}
+
+	// valueTypeName is the description of a JSON value reported by UnmarshalTypeError.
+	valueTypeName = [256]string{
+		'"': "string",
+		'{': "object",
+		'[': "array",
+		't': "bool",
+		'f': "bool",
+		'n': "null",
+		'-': "number",
+		'0': "number",
+		'1': "number",
+		'2': "number",
+		'3': "number",
+		'4': "number",
+		'5': "number",
+		'6': "number",
+		'7': "number",
+		'8': "number",
+		'9': "number",
+	}
 )
 
 func init() {
@@ -20,6 +41,41 @@ LOOP:
 	return cursor
 }
 
+func skipNull(buf []byte, cursor int64) (int64, error) {
+	if cursor+3 >= int64(len(buf)) {
+		return 0, errUnexpectedEndOfJSON("null", cursor)
+	}
+	if buf[cursor+1] != 'u' {
+		return 0, errInvalidCharacter(buf[cursor+1], "null", cursor)
+	}
+	if buf[cursor+2] != 'l' {
+		return 0, errInvalidCharacter(buf[cursor+2], "null", cursor)
+	}
+	if buf[cursor+3] != 'l' {
+		return 0, errInvalidCharacter(buf[cursor+3], "null", cursor)
+	}
+	return cursor + 4, nil
+}
+
+// skipTypeMismatch skips the value at cursor that cannot be stored in typ
+// and reports it as UnmarshalTypeError with the offset after the value.
+func skipTypeMismatch(buf []byte, cursor int64, typ *rtype) (int64, error) {
+	cursor = skipWhiteSpace(buf, cursor)
+	value := valueTypeName[buf[cursor]]
+	if value == "" {
+		return 0, errNotAtBeginningOfValue(cursor)
+	}
+	offset := cursor + 1 // the same as encoding/json, report object and array at the beginning of value
+	end, err := skipValue(buf, cursor)
+	if err != nil {
+		return 0, err
+	}
+	if value != "object" && value != "array" {
+		offset = end
+	}
+	return end, errUnmarshalType(value, typ, offset)
+}
+
 func skipValue(buf []byte, cursor int64) (int64, error) {
 	cursor = skipWhiteSpace(buf, cursor)
 	braceCount := 0
@@ -38,32 +94,44 @@ func skipValue(buf []byte, cursor int64) (int64, error) {
 			if braceCount == -1 && bracketCount == 0 {
 				return cursor, nil
 			}
+			if braceCount == 0 && bracketCount == 0 {
+				return cursor + 1, nil
+			}
 		case ']':
 			bracketCount--
+			if bracketCount == -1 && braceCount == 0 {
+				return cursor, nil
+			}
+			if braceCount == 0 && bracketCount == 0 {
+				return cursor + 1, nil
+			}
 		case ',':
 			if bracketCount == 0 && braceCount == 0 {
 				return cursor, nil
 			}
 		case '"':
+		STRING:
 			cursor++
-
-			for ; cursor < buflen; cursor++ {
-				if buf[cursor] != '"' {
-					continue
-				}
-				if buf[cursor-1] == '\\' {
-					continue
+			switch buf[cursor] {
+			case '\\':
+				cursor++
+				if buf[cursor] == nul {
+					return cursor, errUnexpectedEndOfJSON("value of string", cursor)
 				}
+				goto STRING
+			case nul:
+				return cursor, errUnexpectedEndOfJSON("value of string", cursor)
+			case '"':
 				if bracketCount == 0 && braceCount == 0 {
 					return cursor + 1, nil
 				}
-				break
+			default:
+				goto STRING
 			}
 		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			cursor++
 			for ; cursor < buflen; cursor++ {
-				tk := int(buf[cursor])
-				if (int('0') <= tk && tk <= int('9')) || tk == '.' || tk == 'e' || tk == 'E' {
+				if floatTable[buf[cursor]] {
 					continue
 				}
 				break
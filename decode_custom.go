@@ -0,0 +1,89 @@
+package json
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// DecoderFunc decodes the JSON value data and stores the result in the value that p points to.
+// data is valid only until DecoderFunc returns, so it must be copied to retain.
+type DecoderFunc func(data []byte, p unsafe.Pointer) error
+
+var globalCustomDecoders sync.Map // map[uintptr]DecoderFunc
+
+// RegisterDecoder registers fn as the decoder of typ for all unmarshaling.
+// This is useful for the types that cannot have the UnmarshalJSON method such as the types of other packages.
+// The custom decoder takes precedence over Unmarshaler and encoding.TextUnmarshaler,
+// but DecodeCustomDecoder registered for a single call takes precedence over it.
+//
+// The compiled decoders are cached by type, so RegisterDecoder clears the cache.
+// It should be called before unmarshaling starts, e.g. in init.
+func RegisterDecoder(typ reflect.Type, fn DecoderFunc) {
+	typeptr := uintptr(unsafe.Pointer(type2rtype(typ)))
+	if fn == nil {
+		globalCustomDecoders.Delete(typeptr)
+	} else {
+		globalCustomDecoders.Store(typeptr, fn)
+	}
+	cachedDecoder.clear()
+}
+
+func (d *Decoder) setCustomDecoder(typ reflect.Type, fn DecoderFunc) {
+	if d.customDecoders == nil {
+		d.customDecoders = map[uintptr]DecoderFunc{}
+	}
+	d.customDecoders[uintptr(unsafe.Pointer(type2rtype(typ)))] = fn
+	d.customDecodersKey = ""
+}
+
+// customKey returns cacheKey.custom for the decoders registered by DecodeCustomDecoder.
+func (d *Decoder) customKey() string {
+	fns := make(map[uintptr]uintptr, len(d.customDecoders))
+	for typeptr, fn := range d.customDecoders {
+		fns[typeptr] = *(*uintptr)(unsafe.Pointer(&fn))
+	}
+	return newCustomKey(fns)
+}
+
+func (d *Decoder) customDecoder(typ *rtype) DecoderFunc {
+	typeptr := uintptr(unsafe.Pointer(typ))
+	if fn, exists := d.customDecoders[typeptr]; exists {
+		return fn
+	}
+	if fn, exists := globalCustomDecoders.Load(typeptr); exists {
+		return fn.(DecoderFunc)
+	}
+	return nil
+}
+
+type customDecoder struct {
+	fn DecoderFunc
+}
+
+func newCustomDecoder(fn DecoderFunc) *customDecoder {
+	return &customDecoder{fn: fn}
+}
+
+func (d *customDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
+	s.skipWhiteSpace()
+	start := s.cursor
+	if err := s.skipValue(); err != nil {
+		return err
+	}
+	src := s.buf[start:s.cursor]
+	return d.fn(src, p)
+}
+
+func (d *customDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+	cursor = skipWhiteSpace(buf, cursor)
+	start := cursor
+	end, err := skipValue(buf, cursor)
+	if err != nil {
+		return 0, err
+	}
+	if err := d.fn(buf[start:end], p); err != nil {
+		return 0, err
+	}
+	return end, nil
+}
@@ -6,11 +6,17 @@ import (
 )
 
 type floatDecoder struct {
-	op func(unsafe.Pointer, float64)
+	typ     *rtype
+	bitSize int
+	op      func(unsafe.Pointer, float64)
 }
 
-func newFloatDecoder(op func(unsafe.Pointer, float64)) *floatDecoder {
-	return &floatDecoder{op: op}
+func newFloatDecoder(typ *rtype, op func(unsafe.Pointer, float64)) *floatDecoder {
+	return &floatDecoder{
+		typ:     typ,
+		bitSize: int(typ.Size() * 8),
+		op:      op,
+	}
 }
 
 var (
@@ -70,13 +76,18 @@ func (d *floatDecoder) decodeStreamByte(s *stream) ([]byte, error) {
 			continue
 		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			return floatBytes(s), nil
+		case 'n':
+			if err := nullBytes(s); err != nil {
+				return nil, err
+			}
+			return nil, nil
 		case nul:
 			if s.read() {
 				continue
 			}
 			goto ERROR
 		default:
-			goto ERROR
+			return nil, s.skipTypeMismatch(d.typ)
 		}
 	}
 ERROR:
@@ -100,42 +111,72 @@ func (d *floatDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, erro
 			}
 			num := buf[start:cursor]
 			return num, cursor, nil
+		case 'n':
+			c, err := skipNull(buf, cursor)
+			if err != nil {
+				return nil, 0, err
+			}
+			return nil, c, nil
 		default:
-			return nil, 0, errUnexpectedEndOfJSON("float", cursor)
+			c, err := skipTypeMismatch(buf, cursor, d.typ)
+			return nil, c, err
 		}
 	}
 	return nil, 0, errUnexpectedEndOfJSON("float", cursor)
 }
 
+func (d *floatDecoder) parseFloat(b []byte) (float64, error) {
+	s := *(*string)(unsafe.Pointer(&b))
+	f64, err := strconv.ParseFloat(s, d.bitSize)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return 0, &UnmarshalTypeError{Value: "number " + s, Type: rtype2type(d.typ)}
+		}
+		return 0, err
+	}
+	return f64, nil
+}
+
 func (d *floatDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 	bytes, err := d.decodeStreamByte(s)
 	if err != nil {
 		return err
 	}
+	if bytes == nil {
+		return nil
+	}
 	if !validEndNumberChar[s.char()] {
 		return errUnexpectedEndOfJSON("float", s.totalOffset())
 	}
-	str := *(*string)(unsafe.Pointer(&bytes))
-	f64, err := strconv.ParseFloat(str, 64)
+	f64, err := d.parseFloat(bytes)
 	if err != nil {
+		if typeErr, ok := err.(*UnmarshalTypeError); ok {
+			typeErr.Offset = s.totalOffset()
+		}
 		return err
 	}
 	d.op(p, f64)
 	return nil
 }
 
-func (d *floatDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+func (d *floatDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
 	bytes, c, err := d.decodeByte(buf, cursor)
 	if err != nil {
-		return 0, err
+		return c, err
 	}
 	cursor = c
+	if bytes == nil {
+		return cursor, nil
+	}
 	if !validEndNumberChar[buf[cursor]] {
 		return 0, errUnexpectedEndOfJSON("float", cursor)
 	}
-	s := *(*string)(unsafe.Pointer(&bytes))
-	f64, err := strconv.ParseFloat(s, 64)
+	f64, err := d.parseFloat(bytes)
 	if err != nil {
+		if typeErr, ok := err.(*UnmarshalTypeError); ok {
+			typeErr.Offset = cursor
+			return cursor, err
+		}
 		return 0, err
 	}
 	d.op(p, f64)
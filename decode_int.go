@@ -1,13 +1,22 @@
 package json
 
-import "unsafe"
+import (
+	"strconv"
+	"unsafe"
+)
 
 type intDecoder struct {
-	op func(unsafe.Pointer, int64)
+	typ     *rtype
+	bitSize int
+	op      func(unsafe.Pointer, int64)
 }
 
-func newIntDecoder(op func(unsafe.Pointer, int64)) *intDecoder {
-	return &intDecoder{op: op}
+func newIntDecoder(typ *rtype, op func(unsafe.Pointer, int64)) *intDecoder {
+	return &intDecoder{
+		typ:     typ,
+		bitSize: int(typ.Size() * 8),
+		op:      op,
+	}
 }
 
 var (
@@ -17,23 +26,42 @@ var (
 	}
 )
 
-func (d *intDecoder) parseInt(b []byte) int64 {
+func (d *intDecoder) parseInt(b []byte) (int64, error) {
 	isNegative := false
+	num := b
 	if b[0] == '-' {
-		b = b[1:]
+		num = b[1:]
 		isNegative = true
 	}
-	maxDigit := len(b)
+	maxDigit := len(num)
+	if maxDigit > 18 {
+		// may overflow int64, so fallback to strconv
+		v, err := strconv.ParseInt(*(*string)(unsafe.Pointer(&b)), 10, d.bitSize)
+		if err != nil {
+			return 0, d.overflowError(b)
+		}
+		return v, nil
+	}
 	sum := int64(0)
 	for i := 0; i < maxDigit; i++ {
-		c := int64(b[i]) - 48
+		c := int64(num[i]) - 48
 		digitValue := pow10i64[maxDigit-i-1]
 		sum += c * digitValue
 	}
 	if isNegative {
-		return -1 * sum
+		sum = -1 * sum
+	}
+	if d.bitSize < 64 {
+		max := int64(1)<<uint(d.bitSize-1) - 1
+		if sum > max || sum < -max-1 {
+			return 0, d.overflowError(b)
+		}
 	}
-	return sum
+	return sum, nil
+}
+
+func (d *intDecoder) overflowError(b []byte) *UnmarshalTypeError {
+	return &UnmarshalTypeError{Value: "number " + string(b), Type: rtype2type(d.typ)}
 }
 
 var (
@@ -57,7 +85,7 @@ func (d *intDecoder) decodeStreamByte(s *stream) ([]byte, error) {
 		case ' ', '\n', '\t', '\r':
 			s.cursor++
 			continue
-		case '-':
+		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			start := s.cursor
 			for {
 				s.cursor++
@@ -71,36 +99,30 @@ func (d *intDecoder) decodeStreamByte(s *stream) ([]byte, error) {
 				}
 				break
 			}
+			if floatTable[s.char()] {
+				// number that has fraction or exponent part cannot be stored in integer
+				floatBytes(s)
+				num := s.buf[start:s.cursor]
+				return nil, errUnmarshalType("number "+string(num), d.typ, s.totalOffset())
+			}
 			num := s.buf[start:s.cursor]
-			s.reset()
-			if len(num) < 2 {
+			if len(num) < 2 && num[0] == '-' {
 				goto ERROR
 			}
-			return num, nil
-		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-			start := s.cursor
-			for {
-				s.cursor++
-				if numTable[s.char()] {
-					continue
-				} else if s.char() == nul {
-					if s.read() {
-						s.cursor-- // for retry current character
-						continue
-					}
-				}
-				break
-			}
-			num := s.buf[start:s.cursor]
 			s.reset()
 			return num, nil
+		case 'n':
+			if err := nullBytes(s); err != nil {
+				return nil, err
+			}
+			return nil, nil
 		case nul:
 			if s.read() {
 				continue
 			}
 			goto ERROR
 		default:
-			goto ERROR
+			return nil, s.skipTypeMismatch(d.typ)
 		}
 	}
 ERROR:
@@ -121,13 +143,29 @@ func (d *intDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, error)
 				cursor++
 				goto LOOP
 			}
+			if floatTable[buf[cursor]] {
+				// number that has fraction or exponent part cannot be stored in integer
+				for floatTable[buf[cursor]] {
+					cursor++
+				}
+				return nil, cursor, errUnmarshalType("number "+string(buf[start:cursor]), d.typ, cursor)
+			}
 			num := buf[start:cursor]
+			if len(num) < 2 && num[0] == '-' {
+				return nil, 0, errInvalidCharacter(buf[cursor], "number(integer)", cursor)
+			}
 			return num, cursor, nil
+		case 'n':
+			c, err := skipNull(buf, cursor)
+			if err != nil {
+				return nil, 0, err
+			}
+			return nil, c, nil
 		default:
-			return nil, 0, errInvalidCharacter(buf[cursor], "number(integer)", cursor)
+			c, err := skipTypeMismatch(buf, cursor, d.typ)
+			return nil, c, err
 		}
 	}
-	return nil, 0, errUnexpectedEndOfJSON("number(integer)", cursor)
 }
 
 func (d *intDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
@@ -135,16 +173,32 @@ func (d *intDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 	if err != nil {
 		return err
 	}
-	d.op(p, d.parseInt(bytes))
+	if bytes == nil {
+		return nil
+	}
+	v, err := d.parseInt(bytes)
+	if err != nil {
+		err.(*UnmarshalTypeError).Offset = s.totalOffset()
+		return err
+	}
+	d.op(p, v)
 	return nil
 }
 
-func (d *intDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+func (d *intDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
 	bytes, c, err := d.decodeByte(buf, cursor)
 	if err != nil {
-		return 0, err
+		return c, err
 	}
 	cursor = c
-	d.op(p, d.parseInt(bytes))
+	if bytes == nil {
+		return cursor, nil
+	}
+	v, err := d.parseInt(bytes)
+	if err != nil {
+		err.(*UnmarshalTypeError).Offset = cursor
+		return cursor, err
+	}
+	d.op(p, v)
 	return cursor, nil
 }
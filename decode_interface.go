@@ -15,13 +15,13 @@ func newInterfaceDecoder(typ *rtype) *interfaceDecoder {
 	}
 }
 
-func (d *interfaceDecoder) numDecoder(s *stream) decoder {
-	if s.useNumber {
-		return newNumberDecoder(func(p unsafe.Pointer, v Number) {
+func (d *interfaceDecoder) numDecoder(ctx *decodeRuntimeContext) decoder {
+	if ctx.useNumber {
+		return newNumberDecoder(d.typ, func(p unsafe.Pointer, v Number) {
 			*(*interface{})(p) = v
 		})
 	}
-	return newFloatDecoder(func(p unsafe.Pointer, v float64) {
+	return newFloatDecoder(d.typ, func(p unsafe.Pointer, v float64) {
 		*(*interface{})(p) = v
 	})
 }
@@ -30,6 +30,9 @@ var (
 	interfaceMapType = type2rtype(
 		reflect.TypeOf((*map[string]interface{})(nil)).Elem(),
 	)
+	interfaceSliceType = type2rtype(
+		reflect.TypeOf((*[]interface{})(nil)).Elem(),
+	)
 )
 
 func (d *interfaceDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
@@ -41,7 +44,7 @@ func (d *interfaceDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 			ptr := unsafe.Pointer(&v)
 			if err := newMapDecoder(
 				interfaceMapType,
-				newStringDecoder(),
+				newStringDecoder(stringType),
 				newInterfaceDecoder(d.typ),
 			).decodeStream(s, ptr); err != nil {
 				return err
@@ -52,6 +55,7 @@ func (d *interfaceDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 			var v []interface{}
 			ptr := unsafe.Pointer(&v)
 			if err := newSliceDecoder(
+				interfaceSliceType,
 				newInterfaceDecoder(d.typ),
 				d.typ,
 				d.typ.Size(),
@@ -61,7 +65,7 @@ func (d *interfaceDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 			**(**interface{})(unsafe.Pointer(&p)) = v
 			return nil
 		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-			return d.numDecoder(s).decodeStream(s, p)
+			return d.numDecoder(s.ctx).decodeStream(s, p)
 		case '"':
 			s.cursor++
 			start := s.cursor
@@ -113,7 +117,7 @@ func (d *interfaceDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 	return errNotAtBeginningOfValue(s.totalOffset())
 }
 
-func (d *interfaceDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+func (d *interfaceDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
 	cursor = skipWhiteSpace(buf, cursor)
 	switch buf[cursor] {
 	case '{':
@@ -121,12 +125,12 @@ func (d *interfaceDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (i
 		ptr := unsafe.Pointer(&v)
 		dec := newMapDecoder(
 			interfaceMapType,
-			newStringDecoder(),
+			newStringDecoder(stringType),
 			newInterfaceDecoder(d.typ),
 		)
-		cursor, err := dec.decode(buf, cursor, ptr)
+		cursor, err := dec.decode(ctx, buf, cursor, ptr)
 		if err != nil {
-			return 0, err
+			return cursor, err
 		}
 		**(**interface{})(unsafe.Pointer(&p)) = v
 		return cursor, nil
@@ -134,27 +138,31 @@ func (d *interfaceDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (i
 		var v []interface{}
 		ptr := unsafe.Pointer(&v)
 		dec := newSliceDecoder(
+			interfaceSliceType,
 			newInterfaceDecoder(d.typ),
 			d.typ,
 			d.typ.Size(),
 		)
-		cursor, err := dec.decode(buf, cursor, ptr)
+		cursor, err := dec.decode(ctx, buf, cursor, ptr)
 		if err != nil {
-			return 0, err
+			return cursor, err
 		}
 		**(**interface{})(unsafe.Pointer(&p)) = v
 		return cursor, nil
 	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-		return newFloatDecoder(func(p unsafe.Pointer, v float64) {
-			*(*interface{})(p) = v
-		}).decode(buf, cursor, p)
+		return d.numDecoder(ctx).decode(ctx, buf, cursor, p)
 	case '"':
 		cursor++
 		start := cursor
 		for {
 			switch buf[cursor] {
 			case '\\':
-				cursor++
+				var err error
+				buf, cursor, err = decodeEscapeByte(buf, cursor)
+				if err != nil {
+					return 0, err
+				}
+				continue
 			case '"':
 				literal := buf[start:cursor]
 				cursor++
@@ -1,11 +1,15 @@
 package json
 
 import (
+	"fmt"
+	"reflect"
 	"unsafe"
 )
 
 type mapDecoder struct {
 	mapType      *rtype
+	keyType      *rtype
+	valueType    *rtype
 	keyDecoder   decoder
 	valueDecoder decoder
 }
@@ -13,6 +17,8 @@ type mapDecoder struct {
 func newMapDecoder(mapType *rtype, keyDec decoder, valueDec decoder) *mapDecoder {
 	return &mapDecoder{
 		mapType:      mapType,
+		keyType:      mapType.Key(),
+		valueType:    mapType.Elem(),
 		keyDecoder:   keyDec,
 		valueDecoder: valueDec,
 	}
@@ -25,24 +31,12 @@ func makemap(*rtype, int) unsafe.Pointer
 //go:noescape
 func mapassign(t *rtype, m unsafe.Pointer, key, val unsafe.Pointer)
 
-func (d *mapDecoder) setKey(buf []byte, cursor int64, key interface{}) (int64, error) {
-	header := (*interfaceHeader)(unsafe.Pointer(&key))
-	return d.keyDecoder.decode(buf, cursor, header.ptr)
-}
-
-func (d *mapDecoder) setValue(buf []byte, cursor int64, key interface{}) (int64, error) {
-	header := (*interfaceHeader)(unsafe.Pointer(&key))
-	return d.valueDecoder.decode(buf, cursor, header.ptr)
-}
-
-func (d *mapDecoder) setKeyStream(s *stream, key interface{}) error {
-	header := (*interfaceHeader)(unsafe.Pointer(&key))
-	return d.keyDecoder.decodeStream(s, header.ptr)
-}
-
-func (d *mapDecoder) setValueStream(s *stream, key interface{}) error {
-	header := (*interfaceHeader)(unsafe.Pointer(&key))
-	return d.valueDecoder.decodeStream(s, header.ptr)
+// keyString returns the decoded map key at p for the field path of UnmarshalTypeError.
+func (d *mapDecoder) keyString(p unsafe.Pointer) string {
+	if d.keyType.Kind() == reflect.String {
+		return **(**string)(unsafe.Pointer(&p))
+	}
+	return fmt.Sprint(reflect.NewAt(rtype2type(d.keyType), p).Elem().Interface())
 }
 
 func (d *mapDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
@@ -55,20 +49,22 @@ func (d *mapDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 		return nil
 	case '{':
 	default:
-		return errExpected("{ character for map value", s.totalOffset())
+		return s.skipTypeMismatch(d.mapType)
 	}
+	s.cursor++
 	s.skipWhiteSpace()
 	mapValue := makemap(d.mapType, 0)
-	if s.buf[s.cursor+1] == '}' {
+	if s.char() == '}' {
 		**(**unsafe.Pointer)(unsafe.Pointer(&p)) = mapValue
 		s.cursor++
 		return nil
 	}
+	var errs UnmarshalTypeErrors
 	for {
-		s.cursor++
-		var key interface{}
-		if err := d.setKeyStream(s, &key); err != nil {
-			return err
+		key := unsafe_New(d.keyType)
+		keyErr := d.keyDecoder.decodeStream(s, key)
+		if _, ok := keyErr.(*UnmarshalTypeError); keyErr != nil && !ok {
+			return keyErr
 		}
 		s.skipWhiteSpace()
 		if s.char() == nul {
@@ -81,11 +77,26 @@ func (d *mapDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 		if s.end() {
 			return errUnexpectedEndOfJSON("map", s.totalOffset())
 		}
-		var value interface{}
-		if err := d.setValueStream(s, &value); err != nil {
-			return err
+		if keyErr != nil {
+			// the entry of invalid key is skipped like encoding/json
+			if err := s.skipValue(); err != nil {
+				return err
+			}
+			var ok bool
+			if errs, ok = collectTypeError(s.ctx, errs, keyErr); !ok {
+				return keyErr
+			}
+		} else {
+			value := unsafe_New(d.valueType)
+			if err := d.valueDecoder.decodeStream(s, value); err != nil {
+				err = typeErrorWithField(err, "", d.keyString(key))
+				var ok bool
+				if errs, ok = collectTypeError(s.ctx, errs, err); !ok {
+					return err
+				}
+			}
+			mapassign(d.mapType, mapValue, key, value)
 		}
-		mapassign(d.mapType, mapValue, unsafe.Pointer(&key), unsafe.Pointer(&value))
 		s.skipWhiteSpace()
 		if s.char() == nul {
 			s.read()
@@ -93,16 +104,17 @@ func (d *mapDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 		if s.char() == '}' {
 			**(**unsafe.Pointer)(unsafe.Pointer(&p)) = mapValue
 			s.cursor++
-			return nil
+			return typeErrors(errs)
 		}
 		if s.char() != ',' {
 			return errExpected("comma after object value", s.totalOffset())
 		}
+		s.cursor++
 	}
 	return nil
 }
 
-func (d *mapDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+func (d *mapDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
 	cursor = skipWhiteSpace(buf, cursor)
 	buflen := int64(len(buf))
 	if buflen < 2 {
@@ -126,7 +138,7 @@ func (d *mapDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64,
 		return cursor, nil
 	case '{':
 	default:
-		return 0, errExpected("{ character for map value", cursor)
+		return skipTypeMismatch(buf, cursor, d.mapType)
 	}
 	cursor++
 	cursor = skipWhiteSpace(buf, cursor)
@@ -136,11 +148,12 @@ func (d *mapDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64,
 		cursor++
 		return cursor, nil
 	}
+	var errs UnmarshalTypeErrors
 	for ; cursor < buflen; cursor++ {
-		var key interface{}
-		keyCursor, err := d.setKey(buf, cursor, &key)
-		if err != nil {
-			return 0, err
+		key := unsafe_New(d.keyType)
+		keyCursor, keyErr := d.keyDecoder.decode(ctx, buf, cursor, key)
+		if _, ok := keyErr.(*UnmarshalTypeError); keyErr != nil && !ok {
+			return 0, keyErr
 		}
 		cursor = keyCursor
 		cursor = skipWhiteSpace(buf, cursor)
@@ -151,18 +164,35 @@ func (d *mapDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64,
 		if cursor >= buflen {
 			return 0, errUnexpectedEndOfJSON("map", cursor)
 		}
-		var value interface{}
-		valueCursor, err := d.setValue(buf, cursor, &value)
-		if err != nil {
-			return 0, err
+		if keyErr != nil {
+			// the entry of invalid key is skipped like encoding/json
+			valueCursor, err := skipValue(buf, cursor)
+			if err != nil {
+				return 0, err
+			}
+			var ok bool
+			if errs, ok = collectTypeError(ctx, errs, keyErr); !ok {
+				return valueCursor, keyErr
+			}
+			cursor = valueCursor
+		} else {
+			value := unsafe_New(d.valueType)
+			valueCursor, err := d.valueDecoder.decode(ctx, buf, cursor, value)
+			if err != nil {
+				err = typeErrorWithField(err, "", d.keyString(key))
+				var ok bool
+				if errs, ok = collectTypeError(ctx, errs, err); !ok {
+					return valueCursor, err
+				}
+			}
+			cursor = valueCursor
+			mapassign(d.mapType, mapValue, key, value)
 		}
-		cursor = valueCursor
-		mapassign(d.mapType, mapValue, unsafe.Pointer(&key), unsafe.Pointer(&value))
-		cursor = skipWhiteSpace(buf, valueCursor)
+		cursor = skipWhiteSpace(buf, cursor)
 		if buf[cursor] == '}' {
 			**(**unsafe.Pointer)(unsafe.Pointer(&p)) = mapValue
 			cursor++
-			return cursor, nil
+			return cursor, typeErrors(errs)
 		}
 		if buf[cursor] != ',' {
 			return 0, errExpected("comma after object value", cursor)
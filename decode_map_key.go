@@ -0,0 +1,89 @@
+package json
+
+import (
+	"reflect"
+	"strconv"
+	"unsafe"
+)
+
+// integerKeyDecoder decodes the map key of integer kinds.
+// The key is encoded as quoted decimal such as "123".
+type integerKeyDecoder struct {
+	typ           *rtype
+	bitSize       int
+	stringDecoder *stringDecoder
+}
+
+func newIntegerKeyDecoder(typ *rtype) *integerKeyDecoder {
+	return &integerKeyDecoder{
+		typ:           typ,
+		bitSize:       int(typ.Size() * 8),
+		stringDecoder: newStringDecoder(stringType),
+	}
+}
+
+func (d *integerKeyDecoder) typeError(b []byte, offset int64) *UnmarshalTypeError {
+	return &UnmarshalTypeError{
+		Value:  "number " + string(b),
+		Type:   rtype2type(d.typ),
+		Offset: offset + 1,
+	}
+}
+
+func (d *integerKeyDecoder) set(b []byte, offset int64, p unsafe.Pointer) error {
+	switch d.typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(string(b), 10, d.bitSize)
+		if err != nil {
+			return d.typeError(b, offset)
+		}
+		switch d.bitSize {
+		case 8:
+			*(*int8)(p) = int8(v)
+		case 16:
+			*(*int16)(p) = int16(v)
+		case 32:
+			*(*int32)(p) = int32(v)
+		default:
+			*(*int64)(p) = v
+		}
+	default:
+		v, err := strconv.ParseUint(string(b), 10, d.bitSize)
+		if err != nil {
+			return d.typeError(b, offset)
+		}
+		switch d.bitSize {
+		case 8:
+			*(*uint8)(p) = uint8(v)
+		case 16:
+			*(*uint16)(p) = uint16(v)
+		case 32:
+			*(*uint32)(p) = uint32(v)
+		default:
+			*(*uint64)(p) = v
+		}
+	}
+	return nil
+}
+
+func (d *integerKeyDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
+	s.skipWhiteSpace()
+	offset := s.totalOffset()
+	bytes, err := d.stringDecoder.decodeStreamByte(s)
+	if err != nil {
+		return err
+	}
+	return d.set(bytes, offset, p)
+}
+
+func (d *integerKeyDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+	cursor = skipWhiteSpace(buf, cursor)
+	bytes, c, err := d.stringDecoder.decodeByte(buf, cursor)
+	if err != nil {
+		return 0, err
+	}
+	if err := d.set(bytes, cursor, p); err != nil {
+		return c, err
+	}
+	return c, nil
+}
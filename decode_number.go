@@ -9,9 +9,9 @@ type numberDecoder struct {
 	op func(unsafe.Pointer, Number)
 }
 
-func newNumberDecoder(op func(unsafe.Pointer, Number)) *numberDecoder {
+func newNumberDecoder(typ *rtype, op func(unsafe.Pointer, Number)) *numberDecoder {
 	return &numberDecoder{
-		floatDecoder: newFloatDecoder(nil),
+		floatDecoder: newFloatDecoder(typ, nil),
 		op:           op,
 	}
 }
@@ -21,18 +21,80 @@ func (d *numberDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 	if err != nil {
 		return err
 	}
+	if bytes == nil {
+		return nil
+	}
+	if err := validateNumber(bytes, s.totalOffset()); err != nil {
+		return err
+	}
 	str := *(*string)(unsafe.Pointer(&bytes))
 	d.op(p, Number(str))
 	return nil
 }
 
-func (d *numberDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+func (d *numberDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
 	bytes, c, err := d.floatDecoder.decodeByte(buf, cursor)
 	if err != nil {
-		return 0, err
+		return c, err
 	}
 	cursor = c
+	if bytes == nil {
+		return cursor, nil
+	}
+	if err := validateNumber(bytes, cursor); err != nil {
+		return 0, err
+	}
 	s := *(*string)(unsafe.Pointer(&bytes))
 	d.op(p, Number(s))
 	return cursor, nil
 }
+
+// validateNumber returns SyntaxError if the characters of a number scanned into b are not a JSON number literal.
+// end is the offset of the end of b in the input.
+func validateNumber(b []byte, end int64) error {
+	i := 0
+	if i < len(b) && b[i] == '-' {
+		i++
+	}
+	switch {
+	case i < len(b) && b[i] == '0':
+		i++
+	case i < len(b) && '1' <= b[i] && b[i] <= '9':
+		i = skipDigits(b, i+1)
+	default:
+		goto ERROR
+	}
+	if i < len(b) && b[i] == '.' {
+		i++
+		if i == len(b) || b[i] < '0' || '9' < b[i] {
+			goto ERROR
+		}
+		i = skipDigits(b, i)
+	}
+	if i < len(b) && (b[i] == 'e' || b[i] == 'E') {
+		i++
+		if i < len(b) && (b[i] == '+' || b[i] == '-') {
+			i++
+		}
+		if i == len(b) || b[i] < '0' || '9' < b[i] {
+			goto ERROR
+		}
+		i = skipDigits(b, i)
+	}
+	if i == len(b) {
+		return nil
+	}
+ERROR:
+	offset := end - int64(len(b)-i)
+	if i == len(b) {
+		return errUnexpectedEndOfJSON("number", offset)
+	}
+	return errInvalidCharacter(b[i], "number", offset)
+}
+
+func skipDigits(b []byte, i int) int {
+	for i < len(b) && '0' <= b[i] && b[i] <= '9' {
+		i++
+	}
+	return i
+}
@@ -25,12 +25,12 @@ func (d *ptrDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 	return nil
 }
 
-func (d *ptrDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+func (d *ptrDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
 	newptr := unsafe_New(d.typ)
 	*(*unsafe.Pointer)(p) = newptr
-	c, err := d.dec.decode(buf, cursor, newptr)
+	c, err := d.dec.decode(ctx, buf, cursor, newptr)
 	if err != nil {
-		return 0, err
+		return c, err
 	}
 	cursor = c
 	return cursor, nil
@@ -6,6 +6,7 @@ import (
 )
 
 type sliceDecoder struct {
+	typ          *rtype
 	elemType     *rtype
 	valueDecoder decoder
 	size         uintptr
@@ -21,8 +22,9 @@ type sliceHeader struct {
 	cap  int
 }
 
-func newSliceDecoder(dec decoder, elemType *rtype, size uintptr) *sliceDecoder {
+func newSliceDecoder(typ *rtype, dec decoder, elemType *rtype, size uintptr) *sliceDecoder {
 	return &sliceDecoder{
+		typ:          typ,
 		valueDecoder: dec,
 		elemType:     elemType,
 		size:         size,
@@ -78,6 +80,7 @@ func (d *sliceDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 				s.cursor++
 				return nil
 			}
+			var errs UnmarshalTypeErrors
 			idx := 0
 			slice := d.newSlice()
 			cap := slice.cap
@@ -92,7 +95,11 @@ func (d *sliceDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 				}
 				addr := uintptr(data) + uintptr(idx)*d.size
 				if err := d.valueDecoder.decodeStream(s, unsafe.Pointer(addr)); err != nil {
-					return err
+					err = typeErrorWithIndex(err, idx)
+					var ok bool
+					if errs, ok = collectTypeError(s.ctx, errs, err); !ok {
+						return err
+					}
 				}
 				s.skipWhiteSpace()
 			RETRY:
@@ -115,7 +122,7 @@ func (d *sliceDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 					**(**sliceHeader)(unsafe.Pointer(&p)) = dst
 					d.releaseSlice(slice)
 					s.cursor++
-					return nil
+					return typeErrors(errs)
 				case ',':
 					idx++
 				case nul:
@@ -139,13 +146,15 @@ func (d *sliceDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 				continue
 			}
 			goto ERROR
+		default:
+			return s.skipTypeMismatch(d.typ)
 		}
 	}
 ERROR:
 	return errUnexpectedEndOfJSON("slice", s.totalOffset())
 }
 
-func (d *sliceDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+func (d *sliceDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
 	buflen := int64(len(buf))
 	for ; cursor < buflen; cursor++ {
 		switch buf[cursor] {
@@ -179,6 +188,7 @@ func (d *sliceDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64
 				cursor++
 				return cursor, nil
 			}
+			var errs UnmarshalTypeErrors
 			idx := 0
 			slice := d.newSlice()
 			cap := slice.cap
@@ -192,9 +202,13 @@ func (d *sliceDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64
 					copySlice(d.elemType, dst, src)
 				}
 				addr := uintptr(data) + uintptr(idx)*d.size
-				c, err := d.valueDecoder.decode(buf, cursor, unsafe.Pointer(addr))
+				c, err := d.valueDecoder.decode(ctx, buf, cursor, unsafe.Pointer(addr))
 				if err != nil {
-					return 0, err
+					err = typeErrorWithIndex(err, idx)
+					var ok bool
+					if errs, ok = collectTypeError(ctx, errs, err); !ok {
+						return c, err
+					}
 				}
 				cursor = c
 				cursor = skipWhiteSpace(buf, cursor)
@@ -217,7 +231,7 @@ func (d *sliceDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64
 					**(**sliceHeader)(unsafe.Pointer(&p)) = dst
 					d.releaseSlice(slice)
 					cursor++
-					return cursor, nil
+					return cursor, typeErrors(errs)
 				case ',':
 					idx++
 				default:
@@ -228,6 +242,8 @@ func (d *sliceDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64
 				}
 				cursor++
 			}
+		default:
+			return skipTypeMismatch(buf, cursor, d.typ)
 		}
 	}
 	return 0, errUnexpectedEndOfJSON("slice", cursor)
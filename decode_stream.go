@@ -10,14 +10,18 @@ const (
 )
 
 type stream struct {
-	buf                   []byte
-	length                int64
-	r                     io.Reader
-	offset                int64
-	cursor                int64
-	allRead               bool
-	useNumber             bool
-	disallowUnknownFields bool
+	buf       []byte
+	length    int64
+	r         io.Reader
+	offset    int64
+	cursor    int64
+	allRead   bool
+	chunkSize int // size of the buffer read from r at once
+	ctx       *decodeRuntimeContext
+}
+
+func newStream(r io.Reader, ctx *decodeRuntimeContext) *stream {
+	return &stream{r: r, chunkSize: readChunkSize, ctx: ctx}
 }
 
 func (s *stream) buffered() io.Reader {
@@ -50,6 +54,7 @@ func (s *stream) progressN(n int64) bool {
 }
 
 func (s *stream) reset() {
+	s.offset += s.cursor
 	s.buf = s.buf[s.cursor:]
 	s.length -= s.cursor
 	s.cursor = 0
@@ -59,12 +64,19 @@ func (s *stream) read() bool {
 	if s.allRead {
 		return false
 	}
-	buf := make([]byte, readChunkSize)
-	n, err := s.r.Read(buf)
+	buf := make([]byte, s.chunkSize)
+	var (
+		n   int
+		err error
+	)
+	// io.Reader may return less than chunkSize bytes ( or zero bytes ) before the end of input
+	for n == 0 && err == nil {
+		n, err = s.r.Read(buf)
+	}
 	if err != nil && err != io.EOF {
 		return false
 	}
-	if n < readChunkSize || err == io.EOF {
+	if err == io.EOF {
 		s.allRead = true
 	}
 	// extend buffer (2) is protect ( s.cursor++ x2 )
@@ -72,7 +84,7 @@ func (s *stream) read() bool {
 	const extendBufLength = int64(2)
 
 	totalSize := s.length + int64(n) + extendBufLength
-	if totalSize > readChunkSize {
+	if totalSize > int64(s.chunkSize) {
 		newBuf := make([]byte, totalSize)
 		copy(newBuf, s.buf)
 		copy(newBuf[s.length:], buf)
@@ -87,7 +99,6 @@ func (s *stream) read() bool {
 		s.buf = buf
 		s.length = totalSize - extendBufLength
 	}
-	s.offset += s.cursor
 	if n == 0 {
 		return false
 	}
@@ -107,6 +118,24 @@ LOOP:
 	}
 }
 
+// skipTypeMismatch skips the current value that cannot be stored in typ
+// and reports it as UnmarshalTypeError with the offset after the value.
+func (s *stream) skipTypeMismatch(typ *rtype) error {
+	s.skipWhiteSpace()
+	value := valueTypeName[s.char()]
+	if value == "" {
+		return errNotAtBeginningOfValue(s.totalOffset())
+	}
+	offset := s.totalOffset() + 1 // the same as encoding/json, report object and array at the beginning of value
+	if err := s.skipValue(); err != nil {
+		return err
+	}
+	if value != "object" && value != "array" {
+		offset = s.totalOffset()
+	}
+	return errUnmarshalType(value, typ, offset)
+}
+
 func (s *stream) skipValue() error {
 	s.skipWhiteSpace()
 	braceCount := 0
@@ -127,8 +156,19 @@ func (s *stream) skipValue() error {
 			if braceCount == -1 && bracketCount == 0 {
 				return nil
 			}
+			if braceCount == 0 && bracketCount == 0 {
+				s.cursor++
+				return nil
+			}
 		case ']':
 			bracketCount--
+			if bracketCount == -1 && braceCount == 0 {
+				return nil
+			}
+			if braceCount == 0 && bracketCount == 0 {
+				s.cursor++
+				return nil
+			}
 		case ',':
 			if bracketCount == 0 && braceCount == 0 {
 				return nil
@@ -143,10 +183,14 @@ func (s *stream) skipValue() error {
 					}
 					c = s.char()
 				}
-				if c != '"' {
+				if c == '\\' {
+					s.cursor++
+					if s.char() == nul && !s.read() {
+						return errUnexpectedEndOfJSON("value of string", s.totalOffset())
+					}
 					continue
 				}
-				if s.prevChar() == '\\' {
+				if c != '"' {
 					continue
 				}
 				if bracketCount == 0 && braceCount == 0 {
@@ -1,17 +1,30 @@
 package json
 
 import (
+	"reflect"
+	"unicode/utf16"
+	"unicode/utf8"
 	"unsafe"
 )
 
+var (
+	stringType = type2rtype(reflect.TypeOf(""))
+)
+
 type stringDecoder struct {
+	typ *rtype
 }
 
-func newStringDecoder() *stringDecoder {
-	return &stringDecoder{}
+func newStringDecoder(typ *rtype) *stringDecoder {
+	return &stringDecoder{typ: typ}
 }
 
 func (d *stringDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
+	s.skipWhiteSpace()
+	switch s.char() {
+	case '{', '[', 't', 'f', '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return s.skipTypeMismatch(d.typ)
+	}
 	bytes, err := d.decodeStreamByte(s)
 	if err != nil {
 		return err
@@ -20,7 +33,12 @@ func (d *stringDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 	return nil
 }
 
-func (d *stringDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+func (d *stringDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+	cursor = skipWhiteSpace(buf, cursor)
+	switch buf[cursor] {
+	case '{', '[', 't', 'f', '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return skipTypeMismatch(buf, cursor, d.typ)
+	}
 	bytes, c, err := d.decodeByte(buf, cursor)
 	if err != nil {
 		return 0, err
@@ -86,14 +104,27 @@ RETRY:
 	case 't':
 		s.buf[s.cursor] = '\t'
 	case 'u':
-		if s.cursor+5 >= s.length {
+		for s.cursor+5 >= s.length {
 			if !s.read() {
 				return errInvalidCharacter(s.char(), "escaped string", s.totalOffset())
 			}
 		}
 		code := unicodeToRune(s.buf[s.cursor+1 : s.cursor+5])
+		escaped := int64(6) // length of \uXXXX
+		if utf16.IsSurrogate(code) {
+			// combine the surrogate pair such as \ud83d\ude00
+			for s.cursor+11 >= s.length && s.read() {
+			}
+			if s.cursor+11 < s.length && s.buf[s.cursor+5] == '\\' && s.buf[s.cursor+6] == 'u' {
+				if r := utf16.DecodeRune(code, unicodeToRune(s.buf[s.cursor+7:s.cursor+11])); r != utf8.RuneError {
+					code = r
+					escaped = 12
+				}
+			}
+		}
 		unicode := []byte(string(code))
-		s.buf = append(append(s.buf[:s.cursor-1], unicode...), s.buf[s.cursor+5:]...)
+		s.buf = append(append(s.buf[:s.cursor-1], unicode...), s.buf[s.cursor-1+escaped:]...)
+		s.length -= escaped - int64(len(unicode))
 		s.cursor--
 		return nil
 	case nul:
@@ -105,17 +136,60 @@ RETRY:
 		return errUnexpectedEndOfJSON("string", s.totalOffset())
 	}
 	s.buf = append(s.buf[:s.cursor-1], s.buf[s.cursor:]...)
+	s.length--
 	s.cursor--
 	return nil
 }
 
+// decodeEscapeByte unescapes the escape sequence at cursor in buf in place, in the same way as decodeEscapeString.
+// It returns buf shortened by the escape sequence and the cursor after the unescaped character.
+func decodeEscapeByte(buf []byte, cursor int64) ([]byte, int64, error) {
+	cursor++
+	switch buf[cursor] {
+	case '"', '\\', '/':
+	case 'b':
+		buf[cursor] = '\b'
+	case 'f':
+		buf[cursor] = '\f'
+	case 'n':
+		buf[cursor] = '\n'
+	case 'r':
+		buf[cursor] = '\r'
+	case 't':
+		buf[cursor] = '\t'
+	case 'u':
+		buflen := int64(len(buf))
+		if cursor+5 >= buflen {
+			return nil, 0, errUnexpectedEndOfJSON("escaped string", cursor)
+		}
+		code := unicodeToRune(buf[cursor+1 : cursor+5])
+		escaped := int64(6) // length of \uXXXX
+		if utf16.IsSurrogate(code) && cursor+11 < buflen && buf[cursor+5] == '\\' && buf[cursor+6] == 'u' {
+			// combine the surrogate pair such as \ud83d\ude00
+			if r := utf16.DecodeRune(code, unicodeToRune(buf[cursor+7:cursor+11])); r != utf8.RuneError {
+				code = r
+				escaped = 12
+			}
+		}
+		unicode := []byte(string(code))
+		buf = append(append(buf[:cursor-1], unicode...), buf[cursor-1+escaped:]...)
+		return buf, cursor - 1 + int64(len(unicode)), nil
+	default:
+		return nil, 0, errUnexpectedEndOfJSON("escaped string", cursor)
+	}
+	buf = append(buf[:cursor-1], buf[cursor:]...)
+	return buf, cursor, nil
+}
+
 func stringBytes(s *stream) ([]byte, error) {
 	s.cursor++
 	start := s.cursor
 	for {
 		switch s.char() {
 		case '\\':
-			s.cursor++
+			if err := decodeEscapeString(s); err != nil {
+				return nil, err
+			}
 		case '"':
 			literal := s.buf[start:s.cursor]
 			s.cursor++
@@ -134,7 +208,7 @@ ERROR:
 }
 
 func nullBytes(s *stream) error {
-	if s.cursor+3 >= s.length {
+	for s.cursor+3 >= s.length {
 		if !s.read() {
 			return errInvalidCharacter(s.char(), "null", s.totalOffset())
 		}
@@ -189,42 +263,10 @@ func (d *stringDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, err
 			for {
 				switch buf[cursor] {
 				case '\\':
-					cursor++
-					switch buf[cursor] {
-					case '"':
-						buf[cursor] = '"'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case '\\':
-						buf[cursor] = '\\'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case '/':
-						buf[cursor] = '/'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case 'b':
-						buf[cursor] = '\b'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case 'f':
-						buf[cursor] = '\f'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case 'n':
-						buf[cursor] = '\n'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case 'r':
-						buf[cursor] = '\r'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case 't':
-						buf[cursor] = '\t'
-						buf = append(buf[:cursor-1], buf[cursor:]...)
-					case 'u':
-						buflen := int64(len(buf))
-						if cursor+5 >= buflen {
-							return nil, 0, errUnexpectedEndOfJSON("escaped string", cursor)
-						}
-						code := unicodeToRune(buf[cursor+1 : cursor+5])
-						unicode := []byte(string(code))
-						buf = append(append(buf[:cursor-1], unicode...), buf[cursor+5:]...)
-					default:
-						return nil, 0, errUnexpectedEndOfJSON("escaped string", cursor)
+					var err error
+					buf, cursor, err = decodeEscapeByte(buf, cursor)
+					if err != nil {
+						return nil, 0, err
 					}
 					continue
 				case '"':
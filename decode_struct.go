@@ -2,35 +2,80 @@ package json
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 	"unsafe"
 )
 
 type structFieldSet struct {
-	dec    decoder
-	offset uintptr
+	dec         decoder
+	offset      uintptr
+	key         string
+	name        string
+	isTaggedKey bool
+	depth       int // number of embedded structs the field is promoted through
 }
 
 type structDecoder struct {
-	fieldMap   map[string]*structFieldSet
-	keyDecoder *stringDecoder
+	typ           *rtype
+	fields        []*structFieldSet
+	fieldMap      map[string]*structFieldSet
+	keyDecoder    *stringDecoder
+	structName    string
+	caseSensitive bool
 }
 
-func newStructDecoder(fieldMap map[string]*structFieldSet) *structDecoder {
+func newStructDecoder(typ *rtype, fieldMap map[string]*structFieldSet) *structDecoder {
 	return &structDecoder{
+		typ:        typ,
 		fieldMap:   fieldMap,
-		keyDecoder: newStringDecoder(),
+		keyDecoder: newStringDecoder(stringType),
+		structName: typ.Name(),
 	}
 }
 
+// field returns the field that the object key is decoded into.
+// Unless the keys are case-sensitive, a key that matches no field is looked up by its lower case,
+// which is the alias of the field whose key differs from it only in case.
+func (d *structDecoder) field(key []byte) (*structFieldSet, bool) {
+	k := *(*string)(unsafe.Pointer(&key))
+	field, exists := d.fieldMap[k]
+	if exists || d.caseSensitive || !hasUpperCase(key) {
+		return field, exists
+	}
+	field, exists = d.fieldMap[strings.ToLower(k)]
+	return field, exists
+}
+
+// hasUpperCase reports whether key may change by strings.ToLower.
+func hasUpperCase(key []byte) bool {
+	for _, c := range key {
+		if ('A' <= c && c <= 'Z') || c >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
+
 func (d *structDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 	s.skipWhiteSpace()
 	if s.char() == nul {
 		s.read()
 	}
-	if s.char() != '{' {
-		return errNotAtBeginningOfValue(s.totalOffset())
+	switch s.char() {
+	case '{':
+	case 'n':
+		return nullBytes(s)
+	default:
+		return s.skipTypeMismatch(d.typ)
 	}
 	s.cursor++
+	s.skipWhiteSpace()
+	if s.char() == '}' {
+		s.cursor++
+		return nil
+	}
+	var errs UnmarshalTypeErrors
 	for {
 		s.reset()
 		key, err := d.keyDecoder.decodeStreamByte(s)
@@ -51,15 +96,18 @@ func (d *structDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 		if s.end() {
 			return errExpected("object value after colon", s.totalOffset())
 		}
-		k := *(*string)(unsafe.Pointer(&key))
-		field, exists := d.fieldMap[k]
+		field, exists := d.field(key)
 		if exists {
 			addr := uintptr(p) + field.offset
 			if err := field.dec.decodeStream(s, unsafe.Pointer(addr)); err != nil {
-				return err
+				err = typeErrorWithField(err, d.structName, field.key)
+				var ok bool
+				if errs, ok = collectTypeError(s.ctx, errs, err); !ok {
+					return err
+				}
 			}
-		} else if s.disallowUnknownFields {
-			return fmt.Errorf("json: unknown field %q", k)
+		} else if s.ctx.disallowUnknownFields {
+			return fmt.Errorf("json: unknown field %q", key)
 		} else {
 			if err := s.skipValue(); err != nil {
 				return err
@@ -72,7 +120,7 @@ func (d *structDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 		c := s.char()
 		if c == '}' {
 			s.cursor++
-			return nil
+			return typeErrors(errs)
 		}
 		if c != ',' {
 			return errExpected("comma after object element", s.totalOffset())
@@ -82,16 +130,26 @@ func (d *structDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 	return nil
 }
 
-func (d *structDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+func (d *structDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
 	buflen := int64(len(buf))
 	cursor = skipWhiteSpace(buf, cursor)
-	if buf[cursor] != '{' {
-		return 0, errNotAtBeginningOfValue(cursor)
+	switch buf[cursor] {
+	case '{':
+	case 'n':
+		return skipNull(buf, cursor)
+	default:
+		return skipTypeMismatch(buf, cursor, d.typ)
 	}
 	if buflen < 2 {
 		return 0, errUnexpectedEndOfJSON("object", cursor)
 	}
 	cursor++
+	cursor = skipWhiteSpace(buf, cursor)
+	if buf[cursor] == '}' {
+		cursor++
+		return cursor, nil
+	}
+	var errs UnmarshalTypeErrors
 	for ; cursor < buflen; cursor++ {
 		key, c, err := d.keyDecoder.decodeByte(buf, cursor)
 		if err != nil {
@@ -106,15 +164,20 @@ func (d *structDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int6
 		if cursor >= buflen {
 			return 0, errExpected("object value after colon", cursor)
 		}
-		k := *(*string)(unsafe.Pointer(&key))
-		field, exists := d.fieldMap[k]
+		field, exists := d.field(key)
 		if exists {
 			addr := uintptr(p) + field.offset
-			c, err := field.dec.decode(buf, cursor, unsafe.Pointer(addr))
+			c, err := field.dec.decode(ctx, buf, cursor, unsafe.Pointer(addr))
 			if err != nil {
-				return 0, err
+				err = typeErrorWithField(err, d.structName, field.key)
+				var ok bool
+				if errs, ok = collectTypeError(ctx, errs, err); !ok {
+					return c, err
+				}
 			}
 			cursor = c
+		} else if ctx.disallowUnknownFields {
+			return 0, fmt.Errorf("json: unknown field %q", key)
 		} else {
 			c, err := skipValue(buf, cursor)
 			if err != nil {
@@ -125,7 +188,7 @@ func (d *structDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int6
 		cursor = skipWhiteSpace(buf, cursor)
 		if buf[cursor] == '}' {
 			cursor++
-			return cursor, nil
+			return cursor, typeErrors(errs)
 		}
 		if buf[cursor] != ',' {
 			return 0, errExpected("comma after object element", cursor)
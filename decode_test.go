@@ -6,13 +6,16 @@ import (
 	"errors"
 	"fmt"
 	"image"
+	"io"
 	"math"
 	"math/big"
 	"reflect"
 	"strconv"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
+	"unsafe"
 
 	"github.com/goccy/go-json"
 )
@@ -248,6 +251,500 @@ func Test_Decoder_DisallowUnknownFields(t *testing.T) {
 	}
 }
 
+func Test_UnmarshalWithOption(t *testing.T) {
+	t.Run("DecodeUseNumber", func(t *testing.T) {
+		var v map[string]interface{}
+		assertErr(t, json.UnmarshalWithOption([]byte(`{"a": 3.14, "b": [1]}`), &v, json.DecodeUseNumber()))
+		assertEq(t, "json.Number", "json.Number", fmt.Sprintf("%T", v["a"]))
+		assertEq(t, "json.Number", "json.Number", fmt.Sprintf("%T", v["b"].([]interface{})[0]))
+	})
+	t.Run("DecodeDisallowUnknownFields", func(t *testing.T) {
+		var v struct {
+			A int `json:"a"`
+		}
+		err := json.UnmarshalWithOption([]byte(`{"a": 1, "x": 1}`), &v, json.DecodeDisallowUnknownFields())
+		if err == nil {
+			t.Fatal("expected unknown field error")
+		}
+		assertEq(t, "error", `json: unknown field "x"`, err.Error())
+	})
+	t.Run("without option", func(t *testing.T) {
+		var v struct {
+			A int `json:"a"`
+		}
+		assertErr(t, json.UnmarshalWithOption([]byte(`{"a": 1, "x": 1}`), &v))
+		assertEq(t, "a", 1, v.A)
+	})
+}
+
+func Test_Decoder_DecodeWithOption(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`{"a": 3.14} {"x": 1}`))
+	var v map[string]interface{}
+	assertErr(t, dec.DecodeWithOption(&v, json.DecodeUseNumber()))
+	assertEq(t, "json.Number", "json.Number", fmt.Sprintf("%T", v["a"]))
+	var s struct {
+		A int `json:"a"`
+	}
+	if err := dec.DecodeWithOption(&s, json.DecodeDisallowUnknownFields()); err == nil {
+		t.Fatal("expected unknown field error")
+	}
+}
+
+func Test_DecodeCaseSensitive(t *testing.T) {
+	type T struct {
+		ID   int `json:"id"`
+		Name string
+	}
+	t.Run("default", func(t *testing.T) {
+		var v T
+		assertErr(t, json.Unmarshal([]byte(`{"id": 1, "ID": 2, "name": "a"}`), &v))
+		assertEq(t, "id", 2, v.ID)
+		assertEq(t, "name", "a", v.Name)
+	})
+	t.Run("keys differing in case", func(t *testing.T) {
+		var v T
+		assertErr(t, json.Unmarshal([]byte(`{"Id": 1, "NAME": "a"}`), &v))
+		assertEq(t, "id", 1, v.ID)
+		assertEq(t, "name", "a", v.Name)
+		v = T{}
+		assertErr(t, json.NewDecoder(strings.NewReader(`{"iD": 2, "nAmE": "b"}`)).Decode(&v))
+		assertEq(t, "id", 2, v.ID)
+		assertEq(t, "name", "b", v.Name)
+		v = T{}
+		assertErr(t, json.UnmarshalWithOption([]byte(`{"Id": 1, "NAME": "a"}`), &v, json.DecodeCaseSensitive()))
+		assertEq(t, "id", 0, v.ID)
+		assertEq(t, "name", "", v.Name)
+	})
+	t.Run("DecodeCaseSensitive", func(t *testing.T) {
+		var v T
+		assertErr(t, json.UnmarshalWithOption([]byte(`{"id": 1, "ID": 2, "name": "a", "Name": "b"}`), &v, json.DecodeCaseSensitive()))
+		assertEq(t, "id", 1, v.ID)
+		assertEq(t, "name", "b", v.Name)
+	})
+	t.Run("Decoder.CaseSensitive", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(`{"ID": 2, "id": 1, "NAME": "a"}`))
+		dec.CaseSensitive()
+		var v T
+		assertErr(t, dec.Decode(&v))
+		assertEq(t, "id", 1, v.ID)
+		assertEq(t, "name", "", v.Name)
+	})
+}
+
+func Test_UnmarshalTypeError(t *testing.T) {
+	type Owner struct {
+		Name string `json:"name"`
+	}
+	type Item struct {
+		Owner Owner `json:"owner"`
+		Count int8  `json:"count"`
+	}
+	type Root struct {
+		Items []Item         `json:"items"`
+		Tags  map[string]int `json:"tags"`
+		IDs   [2]uint        `json:"ids"`
+		Rate  float32        `json:"rate"`
+		OK    bool           `json:"ok"`
+	}
+	tests := []struct {
+		name string
+		in   string
+		err  *json.UnmarshalTypeError
+	}{
+		{
+			name: "nested field in slice",
+			in:   `{"items":[{},{},{},{"owner":{"name":1}}]}`,
+			err:  &json.UnmarshalTypeError{Value: "number", Type: reflect.TypeOf(""), Offset: 37, Struct: "Owner", Field: "items[3].owner.name"},
+		},
+		{
+			name: "overflow",
+			in:   `{"items":[{"count":300}]}`,
+			err:  &json.UnmarshalTypeError{Value: "number 300", Type: reflect.TypeOf(int8(0)), Offset: 22, Struct: "Item", Field: "items[0].count"},
+		},
+		{
+			name: "fraction into integer",
+			in:   `{"items":[{"count":1.5}]}`,
+			err:  &json.UnmarshalTypeError{Value: "number 1.5", Type: reflect.TypeOf(int8(0)), Offset: 22, Struct: "Item", Field: "items[0].count"},
+		},
+		{
+			name: "map value",
+			in:   `{"tags":{"a":1,"b":"x"}}`,
+			err:  &json.UnmarshalTypeError{Value: "string", Type: reflect.TypeOf(0), Offset: 22, Struct: "Root", Field: "tags.b"},
+		},
+		{
+			name: "negative into unsigned",
+			in:   `{"ids":[1,-1]}`,
+			err:  &json.UnmarshalTypeError{Value: "number -1", Type: reflect.TypeOf(uint(0)), Offset: 12, Struct: "Root", Field: "ids[1]"},
+		},
+		{
+			name: "float overflow",
+			in:   `{"rate":1e40}`,
+			err:  &json.UnmarshalTypeError{Value: "number 1e40", Type: reflect.TypeOf(float32(0)), Offset: 12, Struct: "Root", Field: "rate"},
+		},
+		{
+			name: "string into bool",
+			in:   `{"ok":"true"}`,
+			err:  &json.UnmarshalTypeError{Value: "string", Type: reflect.TypeOf(false), Offset: 12, Struct: "Root", Field: "ok"},
+		},
+		{
+			name: "object into slice",
+			in:   `{"items":{}}`,
+			err:  &json.UnmarshalTypeError{Value: "object", Type: reflect.TypeOf([]Item{}), Offset: 10, Struct: "Root", Field: "items"},
+		},
+		{
+			name: "array into root struct",
+			in:   `[1]`,
+			err:  &json.UnmarshalTypeError{Value: "array", Type: reflect.TypeOf(Root{}), Offset: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var v Root
+			err := json.Unmarshal([]byte(test.in), &v)
+			typeErr, ok := err.(*json.UnmarshalTypeError)
+			if !ok {
+				t.Fatalf("expected UnmarshalTypeError but got %T: %v", err, err)
+			}
+			assertEq(t, "Unmarshal", *test.err, *typeErr)
+			assertEq(t, "Unmarshal message", test.err.Error(), err.Error())
+			var sv Root
+			err = json.NewDecoder(strings.NewReader(test.in)).Decode(&sv)
+			typeErr, ok = err.(*json.UnmarshalTypeError)
+			if !ok {
+				t.Fatalf("expected UnmarshalTypeError but got %T: %v", err, err)
+			}
+			assertEq(t, "Decode", *test.err, *typeErr)
+		})
+	}
+	t.Run("null is ignored", func(t *testing.T) {
+		v := Root{Rate: 1, OK: true}
+		assertErr(t, json.Unmarshal([]byte(`{"items":null,"rate":null,"ok":null}`), &v))
+		assertEq(t, "rate", float32(1), v.Rate)
+		assertEq(t, "ok", true, v.OK)
+	})
+}
+
+func Test_DecodeCollectErrors(t *testing.T) {
+	type Owner struct {
+		Name string `json:"name"`
+	}
+	type Item struct {
+		Owner Owner `json:"owner"`
+		Count int8  `json:"count"`
+	}
+	type Root struct {
+		Items []Item         `json:"items"`
+		Tags  map[string]int `json:"tags"`
+		IDs   [2]uint        `json:"ids"`
+		Rate  float32        `json:"rate"`
+		OK    bool           `json:"ok"`
+	}
+	in := `{"items":[{"owner":{"name":1},"count":2},{"count":300}],"tags":{"a":"x","b":2},"ids":[1,-1],"rate":1.5,"ok":"yes"}`
+	expected := json.UnmarshalTypeErrors{
+		{Value: "number", Type: reflect.TypeOf(""), Offset: 28, Struct: "Owner", Field: "items[0].owner.name"},
+		{Value: "number 300", Type: reflect.TypeOf(int8(0)), Offset: 53, Struct: "Item", Field: "items[1].count"},
+		{Value: "string", Type: reflect.TypeOf(0), Offset: 71, Struct: "Root", Field: "tags.a"},
+		{Value: "number -1", Type: reflect.TypeOf(uint(0)), Offset: 90, Struct: "Root", Field: "ids[1]"},
+		{Value: "string", Type: reflect.TypeOf(false), Offset: 113, Struct: "Root", Field: "ok"},
+	}
+	assertDecoded := func(t *testing.T, err error, v Root) {
+		t.Helper()
+		errs, ok := err.(json.UnmarshalTypeErrors)
+		if !ok {
+			t.Fatalf("expected UnmarshalTypeErrors but got %T: %v", err, err)
+		}
+		assertEq(t, "error count", len(expected), len(errs))
+		for i, e := range expected {
+			assertEq(t, "error", *e, *errs[i])
+		}
+		assertEq(t, "items length", 2, len(v.Items))
+		assertEq(t, "items[0].count", int8(2), v.Items[0].Count)
+		assertEq(t, "tags.b", 2, v.Tags["b"])
+		assertEq(t, "ids[0]", uint(1), v.IDs[0])
+		assertEq(t, "rate", float32(1.5), v.Rate)
+	}
+	t.Run("Unmarshal", func(t *testing.T) {
+		var v Root
+		err := json.UnmarshalWithOption([]byte(in), &v, json.DecodeCollectErrors())
+		assertDecoded(t, err, v)
+	})
+	t.Run("Decoder", func(t *testing.T) {
+		var v Root
+		dec := json.NewDecoder(strings.NewReader(in))
+		dec.CollectErrors()
+		assertDecoded(t, dec.Decode(&v), v)
+	})
+	t.Run("single error at root", func(t *testing.T) {
+		var v int
+		err := json.UnmarshalWithOption([]byte(`"1"`), &v, json.DecodeCollectErrors())
+		errs, ok := err.(json.UnmarshalTypeErrors)
+		if !ok {
+			t.Fatalf("expected UnmarshalTypeErrors but got %T: %v", err, err)
+		}
+		assertEq(t, "error count", 1, len(errs))
+		assertEq(t, "message", "json: cannot unmarshal string into Go value of type int", err.Error())
+	})
+	t.Run("no error", func(t *testing.T) {
+		var v Root
+		assertErr(t, json.UnmarshalWithOption([]byte(`{"rate":2}`), &v, json.DecodeCollectErrors()))
+		assertEq(t, "rate", float32(2), v.Rate)
+	})
+	t.Run("syntax error stops decoding", func(t *testing.T) {
+		var v Root
+		err := json.UnmarshalWithOption([]byte(`{"ok":"x","rate":}`), &v, json.DecodeCollectErrors())
+		if _, ok := err.(*json.SyntaxError); !ok {
+			t.Fatalf("expected SyntaxError but got %T: %v", err, err)
+		}
+	})
+}
+
+func Test_Get(t *testing.T) {
+	data := []byte(` {
+		"items": [
+			{"name": "a", "tags": ["x"]},
+			{"name": "b\"c", "tags": []},
+			{"name": "d", "price": 1.5e2, "ok": true, "note": null}
+		],
+		"a/b": {"m~n": "slash and tilde"},
+		"esc\u0061ped": 1,
+		"": "empty key"
+	} `)
+	tests := []struct {
+		pointer  string
+		expected string
+	}{
+		{pointer: "/items/0/name", expected: `"a"`},
+		{pointer: "/items/1/name", expected: `"b\"c"`},
+		{pointer: "/items/0/tags", expected: `["x"]`},
+		{pointer: "/items/1/tags", expected: `[]`},
+		{pointer: "/items/2/price", expected: `1.5e2`},
+		{pointer: "/items/2/ok", expected: `true`},
+		{pointer: "/items/2/note", expected: `null`},
+		{pointer: "/items/2", expected: `{"name": "d", "price": 1.5e2, "ok": true, "note": null}`},
+		{pointer: "/a~1b/m~0n", expected: `"slash and tilde"`},
+		{pointer: "/escaped", expected: `1`},
+		{pointer: "/", expected: `"empty key"`},
+		{pointer: "", expected: string(bytes.TrimSpace(data))},
+	}
+	for _, test := range tests {
+		t.Run(test.pointer, func(t *testing.T) {
+			v, err := json.Get(data, test.pointer)
+			assertErr(t, err)
+			assertEq(t, "value", test.expected, string(v))
+		})
+	}
+	t.Run("not found", func(t *testing.T) {
+		for _, pointer := range []string{
+			"/items/3",
+			"/items/-",
+			"/items/01",
+			"/items/name",
+			"/items/0/name/x",
+			"/missing",
+		} {
+			_, err := json.Get(data, pointer)
+			pathErr, ok := err.(*json.PathError)
+			if !ok {
+				t.Fatalf("expected PathError for %s but got %T: %v", pointer, err, err)
+			}
+			assertEq(t, "path", pointer, pathErr.Path)
+		}
+	})
+	t.Run("invalid pointer", func(t *testing.T) {
+		for _, pointer := range []string{"items", "/a~2", "/a~"} {
+			if _, err := json.Get(data, pointer); err == nil {
+				t.Fatalf("expected error for %s", pointer)
+			}
+		}
+	})
+	t.Run("syntax error", func(t *testing.T) {
+		if _, err := json.Get([]byte(`{"a" 1}`), "/a"); err == nil {
+			t.Fatal("expected error")
+		}
+		if _, err := json.Get([]byte(`{"a": [1, 2`), "/a/2"); err == nil {
+			t.Fatal("expected error")
+		}
+		if _, err := json.Get([]byte(`{"a": "b`), "/a"); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func Test_UnmarshalPath(t *testing.T) {
+	type Item struct {
+		Name  string `json:"name"`
+		Price int    `json:"price"`
+	}
+	data := []byte(`{"meta": {"total": 2}, "items": [{"name": "a", "price": 10}, {"name": "b", "price": 20}]}`)
+	t.Run("JSON Pointer", func(t *testing.T) {
+		var v Item
+		assertErr(t, json.UnmarshalPath(data, "/items/1", &v))
+		assertEq(t, "item", Item{Name: "b", Price: 20}, v)
+	})
+	t.Run("JSONPath", func(t *testing.T) {
+		for _, path := range []string{"$.items[1].name", "$['items'][1]['name']", `$["items"][1].name`} {
+			var v string
+			assertErr(t, json.UnmarshalPath(data, path, &v))
+			assertEq(t, path, "b", v)
+		}
+	})
+	t.Run("slice", func(t *testing.T) {
+		var v []Item
+		assertErr(t, json.UnmarshalPath(data, "$.items", &v))
+		assertEq(t, "length", 2, len(v))
+		assertEq(t, "items[0]", Item{Name: "a", Price: 10}, v[0])
+	})
+	t.Run("root", func(t *testing.T) {
+		var v struct {
+			Meta struct {
+				Total int `json:"total"`
+			} `json:"meta"`
+		}
+		assertErr(t, json.UnmarshalPath(data, "$", &v))
+		assertEq(t, "total", 2, v.Meta.Total)
+	})
+	t.Run("type error has the offset in data", func(t *testing.T) {
+		var v string
+		err := json.UnmarshalPath(data, "$.items[0].price", &v)
+		typeErr, ok := err.(*json.UnmarshalTypeError)
+		if !ok {
+			t.Fatalf("expected UnmarshalTypeError but got %T: %v", err, err)
+		}
+		assertEq(t, "offset", int64(bytes.Index(data, []byte("10"))+2), typeErr.Offset)
+	})
+	t.Run("with option", func(t *testing.T) {
+		var v struct {
+			Name string `json:"name"`
+		}
+		if err := json.UnmarshalPath(data, "$.items[0]", &v, json.DecodeDisallowUnknownFields()); err == nil {
+			t.Fatal("expected error for unknown field")
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		var v Item
+		err := json.UnmarshalPath(data, "$.items[2]", &v)
+		pathErr, ok := err.(*json.PathError)
+		if !ok {
+			t.Fatalf("expected PathError but got %T: %v", err, err)
+		}
+		assertEq(t, "path", "$.items[2]", pathErr.Path)
+	})
+	t.Run("invalid path", func(t *testing.T) {
+		for _, path := range []string{"items", "$.", "$.items[", "$.items[x]", "$['items'", "$items"} {
+			var v interface{}
+			if _, ok := json.UnmarshalPath(data, path, &v).(*json.PathError); !ok {
+				t.Fatalf("expected PathError for %s", path)
+			}
+		}
+	})
+}
+
+func Test_Path(t *testing.T) {
+	data := []byte(`{ "store": {
+		"book": [
+			{ "category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95 },
+			{ "category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99 },
+			{ "category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99 },
+			{ "category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99 }
+		],
+		"bicycle": { "color": "red", "price": 19.95 }
+	} }`)
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{path: "$.store.book[*].author", expected: []string{`"Nigel Rees"`, `"Evelyn Waugh"`, `"Herman Melville"`, `"J. R. R. Tolkien"`}},
+		{path: "$..author", expected: []string{`"Nigel Rees"`, `"Evelyn Waugh"`, `"Herman Melville"`, `"J. R. R. Tolkien"`}},
+		{path: "$.store..price", expected: []string{`8.95`, `12.99`, `8.99`, `22.99`, `19.95`}},
+		{path: "$['store']['bicycle']['color']", expected: []string{`"red"`}},
+		{path: "$.store.*.color", expected: []string{`"red"`}},
+		{path: "$..book[2].title", expected: []string{`"Moby Dick"`}},
+		{path: "$..book[-1].title", expected: []string{`"The Lord of the Rings"`}},
+		{path: "$..book[0,1].title", expected: []string{`"Sayings of the Century"`, `"Sword of Honour"`}},
+		{path: "$..book[:2].price", expected: []string{`8.95`, `12.99`}},
+		{path: "$..book[1:].price", expected: []string{`12.99`, `8.99`, `22.99`}},
+		{path: "$..book[::2].price", expected: []string{`8.95`, `8.99`}},
+		{path: "$..book[::-1].price", expected: []string{`22.99`, `8.99`, `12.99`, `8.95`}},
+		{path: "$..book[?(@.isbn)].title", expected: []string{`"Moby Dick"`, `"The Lord of the Rings"`}},
+		{path: "$..book[?(@.price < 10)].title", expected: []string{`"Sayings of the Century"`, `"Moby Dick"`}},
+		{path: "$..book[?@.price >= 12.99].price", expected: []string{`12.99`, `22.99`}},
+		{path: "$..book[?(@.category == 'reference')].author", expected: []string{`"Nigel Rees"`}},
+		{path: `$..book[?(@['category'] != "fiction")].author`, expected: []string{`"Nigel Rees"`}},
+		{path: "$..book[10]", expected: []string{}},
+		{path: "$.store.bicycle", expected: []string{`{ "color": "red", "price": 19.95 }`}},
+		{path: "$.store.book[1:4:2].title", expected: []string{`"Sword of Honour"`, `"The Lord of the Rings"`}},
+		{path: "$.store.book[?(@.price < 10)].title", expected: []string{`"Sayings of the Century"`, `"Moby Dick"`}},
+		{path: "$.store.book[3,0].author", expected: []string{`"J. R. R. Tolkien"`, `"Nigel Rees"`}},
+		{path: "$.store.book[0,0].price", expected: []string{`8.95`, `8.95`}},
+		{path: "$.store.book.price", expected: []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			p, err := json.CompilePath(test.path)
+			assertErr(t, err)
+			values, err := p.Extract(data)
+			assertErr(t, err)
+			actual := []string{}
+			for _, v := range values {
+				actual = append(actual, string(v))
+			}
+			assertEq(t, "values", fmt.Sprint(test.expected), fmt.Sprint(actual))
+
+			values, err = p.ExtractReader(iotest.OneByteReader(bytes.NewReader(data)))
+			assertErr(t, err)
+			actual = []string{}
+			for _, v := range values {
+				actual = append(actual, string(v))
+			}
+			assertEq(t, "reader values", fmt.Sprint(test.expected), fmt.Sprint(actual))
+		})
+	}
+	t.Run("reader", func(t *testing.T) {
+		p, err := json.CompilePath("$.ab[1]")
+		assertErr(t, err)
+		values, err := p.ExtractReader(strings.NewReader(`{"x": [[1, {"ab": 2}]], "a\u0062": [true, "v"]}`))
+		assertErr(t, err)
+		assertEq(t, "length", 1, len(values))
+		assertEq(t, "value", `"v"`, string(values[0]))
+
+		if _, err := p.ExtractReader(strings.NewReader(`{"ab": [1 2]}`)); err == nil {
+			t.Fatal("expected error for missing comma")
+		}
+	})
+	t.Run("unmarshal", func(t *testing.T) {
+		type book struct {
+			Title string  `json:"title"`
+			Price float64 `json:"price"`
+		}
+		p, err := json.CompilePath("$.store.book[?(@.category == 'fiction')]")
+		assertErr(t, err)
+		var books []book
+		assertErr(t, p.Unmarshal(data, &books))
+		assertEq(t, "length", 3, len(books))
+		assertEq(t, "books[2]", book{Title: "The Lord of the Rings", Price: 22.99}, books[2])
+
+		var prices []float64
+		p, err = json.CompilePath("$..price")
+		assertErr(t, err)
+		assertErr(t, p.Unmarshal(data, &prices))
+		assertEq(t, "prices", "[8.95 12.99 8.99 22.99 19.95]", fmt.Sprint(prices))
+
+		var titles []int
+		p, err = json.CompilePath("$..title")
+		assertErr(t, err)
+		if _, ok := p.Unmarshal(data, &titles).(*json.UnmarshalTypeError); !ok {
+			t.Fatal("expected UnmarshalTypeError")
+		}
+	})
+	t.Run("invalid path", func(t *testing.T) {
+		for _, path := range []string{"", "store", "$.", "$[", "$[1", "$['a", "$[?(@.a == )]", "$[?(x)]", "$[a]"} {
+			if _, err := json.CompilePath(path); err == nil {
+				t.Fatalf("expected error for %q", path)
+			}
+		}
+	})
+}
+
 type unmarshalJSON struct {
 	v int
 }
@@ -290,6 +787,614 @@ func Test_UnmarshalText(t *testing.T) {
 	})
 }
 
+func Test_UnmarshalMapKey(t *testing.T) {
+	t.Run("integer", func(t *testing.T) {
+		var v map[int16]string
+		assertErr(t, json.Unmarshal([]byte(`{"1":"a", "-2":"b"}`), &v))
+		assertEq(t, "map", fmt.Sprint(map[int16]string{1: "a", -2: "b"}), fmt.Sprint(v))
+	})
+	t.Run("unsigned integer", func(t *testing.T) {
+		var v map[uint64]bool
+		assertErr(t, json.Unmarshal([]byte(`{"18446744073709551615":true}`), &v))
+		assertEq(t, "map", fmt.Sprint(map[uint64]bool{18446744073709551615: true}), fmt.Sprint(v))
+	})
+	t.Run("text unmarshaler", func(t *testing.T) {
+		var v map[unmarshalerText]int
+		assertErr(t, json.Unmarshal([]byte(`{"x:y":1}`), &v))
+		assertEq(t, "map", fmt.Sprint(map[unmarshalerText]int{{"x", "y"}: 1}), fmt.Sprint(v))
+	})
+	t.Run("overflow", func(t *testing.T) {
+		var v map[uint8]int
+		err := json.Unmarshal([]byte(`{"256":1}`), &v)
+		typeErr, ok := err.(*json.UnmarshalTypeError)
+		if !ok {
+			t.Fatalf("expected *json.UnmarshalTypeError, got %T", err)
+		}
+		assertEq(t, "value", "number 256", typeErr.Value)
+		assertEq(t, "offset", int64(2), typeErr.Offset)
+	})
+	t.Run("collect errors", func(t *testing.T) {
+		var v map[int]int
+		err := json.UnmarshalWithOption([]byte(`{"a":1,"2":2,"3.5":[3]}`), &v, json.DecodeCollectErrors())
+		errs, ok := err.(json.UnmarshalTypeErrors)
+		if !ok {
+			t.Fatalf("expected json.UnmarshalTypeErrors, got %T", err)
+		}
+		assertEq(t, "errors", 2, len(errs))
+		assertEq(t, "map", fmt.Sprint(map[int]int{2: 2}), fmt.Sprint(v))
+	})
+}
+
+func Test_DecodeEmbeddedStruct(t *testing.T) {
+	type Base struct {
+		ID   int
+		Name string `json:"name"`
+	}
+	type Extra struct {
+		Note string
+	}
+	type T struct {
+		Base
+		*Extra
+		Age int `json:"age"`
+	}
+	t.Run("promote fields", func(t *testing.T) {
+		var v T
+		assertErr(t, json.Unmarshal([]byte(`{"ID":1,"name":"a","Note":"b","age":2}`), &v))
+		assertEq(t, "id", 1, v.ID)
+		assertEq(t, "name", "a", v.Name)
+		assertEq(t, "age", 2, v.Age)
+		if v.Extra == nil {
+			t.Fatal("expected embedded pointer to be allocated")
+		}
+		assertEq(t, "note", "b", v.Note)
+	})
+	t.Run("nil embedded pointer", func(t *testing.T) {
+		var v T
+		assertErr(t, json.Unmarshal([]byte(`{"ID":1}`), &v))
+		if v.Extra != nil {
+			t.Fatal("expected embedded pointer to be nil")
+		}
+	})
+	t.Run("round trip", func(t *testing.T) {
+		in := T{Base: Base{ID: 1, Name: "a"}, Extra: &Extra{Note: "b"}, Age: 2}
+		data, err := json.Marshal(in)
+		assertErr(t, err)
+		var v T
+		assertErr(t, json.Unmarshal(data, &v))
+		if !reflect.DeepEqual(in, v) {
+			t.Fatalf("failed to round trip. exp=%+v but act=%+v", in, v)
+		}
+
+		var sv T
+		assertErr(t, json.NewDecoder(bytes.NewReader(data)).Decode(&sv))
+		if !reflect.DeepEqual(in, sv) {
+			t.Fatalf("failed to round trip with stream decoder. exp=%+v but act=%+v", in, sv)
+		}
+	})
+	t.Run("conflict", func(t *testing.T) {
+		type A struct {
+			X int
+			Y int
+			Z int
+		}
+		type B struct {
+			X int
+			Y int `json:"Y"`
+			Z int
+		}
+		type C struct {
+			A
+			B
+			X int
+		}
+		var v C
+		assertErr(t, json.Unmarshal([]byte(`{"X":1,"Y":2,"Z":3}`), &v))
+		assertEq(t, "parent field", 1, v.X)
+		assertEq(t, "A.X", 0, v.A.X)
+		assertEq(t, "tagged field", 2, v.B.Y)
+		assertEq(t, "untagged field", 0, v.A.Y)
+		assertEq(t, "ambiguous A.Z", 0, v.A.Z)
+		assertEq(t, "ambiguous B.Z", 0, v.B.Z)
+	})
+	t.Run("depth", func(t *testing.T) {
+		type X1 struct {
+			V string `json:"name"`
+			U string
+		}
+		type T2 struct {
+			W string `json:"name"`
+			U string `json:"U"`
+		}
+		type M2 struct {
+			T2
+		}
+		type S struct {
+			X1
+			M2
+		}
+		in := S{X1: X1{V: "a", U: "b"}}
+		data, err := json.Marshal(in)
+		assertErr(t, err)
+		assertEq(t, "marshal", `{"name":"a","U":"b"}`, string(data))
+		var v S
+		assertErr(t, json.Unmarshal(data, &v))
+		if !reflect.DeepEqual(in, v) {
+			t.Fatalf("failed to round trip. exp=%+v but act=%+v", in, v)
+		}
+		var sv S
+		assertErr(t, json.NewDecoder(bytes.NewReader(data)).Decode(&sv))
+		if !reflect.DeepEqual(in, sv) {
+			t.Fatalf("failed to round trip with stream decoder. exp=%+v but act=%+v", in, sv)
+		}
+	})
+	t.Run("exact key over alias", func(t *testing.T) {
+		type Deep1 struct {
+			Name string
+		}
+		type Deep2 struct {
+			Deep1
+		}
+		type T1 struct {
+			Name string `json:"name"`
+		}
+		type DeepBoth struct {
+			Deep2
+			T1
+		}
+		type Tag2 struct {
+			T1
+			Name string `json:"NAME"`
+		}
+		for _, tc := range []struct {
+			name     string
+			in       interface{}
+			expected string
+			new      func() interface{}
+		}{
+			{"deep both", DeepBoth{Deep2{Deep1{"x"}}, T1{"y"}}, `{"Name":"x","name":"y"}`, func() interface{} { return &DeepBoth{} }},
+			{"tag", Tag2{T1{"x"}, "y"}, `{"name":"x","NAME":"y"}`, func() interface{} { return &Tag2{} }},
+		} {
+			data, err := json.Marshal(tc.in)
+			assertErr(t, err)
+			assertEq(t, tc.name, tc.expected, string(data))
+			v := tc.new()
+			assertErr(t, json.Unmarshal(data, v))
+			if !reflect.DeepEqual(tc.in, reflect.ValueOf(v).Elem().Interface()) {
+				t.Fatalf("failed to round trip %s. exp=%+v but act=%+v", tc.name, tc.in, v)
+			}
+			sv := tc.new()
+			assertErr(t, json.NewDecoder(bytes.NewReader(data)).Decode(sv))
+			if !reflect.DeepEqual(tc.in, reflect.ValueOf(sv).Elem().Interface()) {
+				t.Fatalf("failed to round trip %s with stream decoder. exp=%+v but act=%+v", tc.name, tc.in, sv)
+			}
+		}
+	})
+	t.Run("tagged embedded struct", func(t *testing.T) {
+		type D struct {
+			Base `json:"base"`
+			ID   int
+		}
+		var v D
+		assertErr(t, json.Unmarshal([]byte(`{"base":{"ID":1},"ID":2}`), &v))
+		assertEq(t, "embedded", 1, v.Base.ID)
+		assertEq(t, "field", 2, v.ID)
+	})
+	t.Run("stream", func(t *testing.T) {
+		var v T
+		assertErr(t, json.NewDecoder(strings.NewReader(`{"ID":1,"Note":"b"}`)).Decode(&v))
+		assertEq(t, "id", 1, v.ID)
+		if v.Extra == nil {
+			t.Fatal("expected embedded pointer to be allocated")
+		}
+		assertEq(t, "note", "b", v.Note)
+	})
+}
+
+func Test_DecodeStructTagOptions(t *testing.T) {
+	type T struct {
+		A int  `json:"a,omitempty,string"`
+		B bool `json:"b,string,omitempty"`
+		C int  `json:"c,omitempty,omitnothing"`
+	}
+	t.Run("multiple options", func(t *testing.T) {
+		var v T
+		assertErr(t, json.Unmarshal([]byte(`{"a":"1","b":"true","c":2}`), &v))
+		assertEq(t, "a", 1, v.A)
+		assertEq(t, "b", true, v.B)
+		assertEq(t, "c", 2, v.C)
+	})
+	t.Run("strict", func(t *testing.T) {
+		var v map[string]T
+		assertErr(t, json.Unmarshal([]byte(`{}`), &v))
+		err := json.UnmarshalWithOption([]byte(`{}`), &v, json.DecodeStrictTagOptions())
+		optErr, ok := err.(*json.UnsupportedTagOptionError)
+		if !ok {
+			t.Fatalf("expected *json.UnsupportedTagOptionError, got %T", err)
+		}
+		assertEq(t, "field", "C", optErr.Field)
+		assertEq(t, "option", "omitnothing", optErr.Option)
+
+		dec := json.NewDecoder(strings.NewReader(`{}`))
+		dec.StrictTagOptions()
+		if err := dec.Decode(&v); err == nil {
+			t.Fatal("expected error")
+		}
+		assertErr(t, json.Unmarshal([]byte(`{}`), &v))
+	})
+}
+
+type decodeCustomID [2]byte
+
+func decodeCustomIDFunc(data []byte, p unsafe.Pointer) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	n, err := strconv.ParseUint(s, 16, 16)
+	if err != nil {
+		return err
+	}
+	id := (*decodeCustomID)(p)
+	id[0], id[1] = byte(n>>8), byte(n)
+	return nil
+}
+
+func Test_CustomDecoder(t *testing.T) {
+	json.RegisterDecoder(reflect.TypeOf(decodeCustomID{}), decodeCustomIDFunc)
+	type T struct {
+		A decodeCustomID            `json:"a"`
+		B *decodeCustomID           `json:"b"`
+		C []decodeCustomID          `json:"c"`
+		D map[string]decodeCustomID `json:"d"`
+	}
+	src := `{"a":"0102","b":"0304","c":["0506"],"d":{"k":"0708"}}`
+	expected := T{
+		A: decodeCustomID{1, 2},
+		B: &decodeCustomID{3, 4},
+		C: []decodeCustomID{{5, 6}},
+		D: map[string]decodeCustomID{"k": {7, 8}},
+	}
+	t.Run("global", func(t *testing.T) {
+		var v T
+		assertErr(t, json.Unmarshal([]byte(src), &v))
+		if !reflect.DeepEqual(expected, v) {
+			t.Fatalf("failed to decode. exp=[%+v] but act=[%+v]", expected, v)
+		}
+		var id decodeCustomID
+		assertErr(t, json.Unmarshal([]byte(`"0a0b"`), &id))
+		assertEq(t, "root", decodeCustomID{10, 11}, id)
+	})
+	t.Run("stream", func(t *testing.T) {
+		var v T
+		assertErr(t, json.NewDecoder(strings.NewReader(src)).Decode(&v))
+		if !reflect.DeepEqual(expected, v) {
+			t.Fatalf("failed to decode. exp=[%+v] but act=[%+v]", expected, v)
+		}
+	})
+	t.Run("scoped", func(t *testing.T) {
+		type U struct {
+			A int `json:"a"`
+		}
+		decodeInt := func(data []byte, p unsafe.Pointer) error {
+			*(*int)(p) = len(data)
+			return nil
+		}
+		var v U
+		assertErr(t, json.UnmarshalWithOption([]byte(`{"a":"abc"}`), &v, json.DecodeCustomDecoder(reflect.TypeOf(0), decodeInt)))
+		assertEq(t, "scoped", 5, v.A)
+
+		dec := json.NewDecoder(strings.NewReader(`{"a":[1,2]}`))
+		assertErr(t, dec.DecodeWithOption(&v, json.DecodeCustomDecoder(reflect.TypeOf(0), decodeInt)))
+		assertEq(t, "stream", 5, v.A)
+
+		assertErr(t, json.Unmarshal([]byte(`{"a":7}`), &v))
+		assertEq(t, "not scoped", 7, v.A)
+	})
+	t.Run("cached", func(t *testing.T) {
+		type V struct {
+			A int `json:"a"`
+		}
+		decodeOne := func(data []byte, p unsafe.Pointer) error {
+			*(*int)(p) = 1
+			return nil
+		}
+		decodeTwo := func(data []byte, p unsafe.Pointer) error {
+			*(*int)(p) = 2
+			return nil
+		}
+		before := json.DecodeCacheStats()
+		for i := 0; i < 2; i++ {
+			var v V
+			assertErr(t, json.UnmarshalWithOption([]byte(`{"a":0}`), &v, json.DecodeCustomDecoder(reflect.TypeOf(0), decodeOne)))
+			assertEq(t, "first", 1, v.A)
+		}
+		stats := json.DecodeCacheStats()
+		assertEq(t, "hits", before.Hits+1, stats.Hits)
+		assertEq(t, "misses", before.Misses+1, stats.Misses)
+		var v V
+		assertErr(t, json.UnmarshalWithOption([]byte(`{"a":0}`), &v, json.DecodeCustomDecoder(reflect.TypeOf(0), decodeTwo)))
+		assertEq(t, "other decoder", 2, v.A)
+		assertErr(t, json.Unmarshal([]byte(`{"a":3}`), &v))
+		assertEq(t, "not scoped", 3, v.A)
+	})
+	t.Run("error", func(t *testing.T) {
+		var v T
+		if err := json.Unmarshal([]byte(`{"a":"xyz"}`), &v); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func Test_DecodeTimeFormat(t *testing.T) {
+	type T struct {
+		Time     time.Time      `json:"time"`
+		TimePtr  *time.Time     `json:"timePtr"`
+		Duration time.Duration  `json:"duration"`
+		DurPtr   *time.Duration `json:"durPtr"`
+	}
+	tm := time.Date(2021, 3, 4, 5, 6, 7, 890000000, time.UTC)
+	t.Run("default", func(t *testing.T) {
+		var v T
+		assertErr(t, json.Unmarshal([]byte(`{"time":"2021-03-04T05:06:07.89Z","timePtr":"2021-03-04T05:06:07.89Z","duration":10}`), &v))
+		assertEq(t, "time", true, v.Time.Equal(tm))
+		assertEq(t, "time pointer", true, v.TimePtr.Equal(tm))
+		assertEq(t, "duration", time.Duration(10), v.Duration)
+	})
+	for _, tc := range []struct {
+		name     string
+		format   json.TimeFormat
+		src      string
+		expected time.Time
+	}{
+		{"RFC3339", json.TimeFormatRFC3339, `"2021-03-04T05:06:07Z"`, tm.Truncate(time.Second)},
+		{"layout", json.TimeFormatLayout("2006/01/02"), `"2021/03/04"`, tm.Truncate(24 * time.Hour)},
+		{"unix", json.TimeFormatUnix, `1614834367`, tm.Truncate(time.Second)},
+		{"unix milli", json.TimeFormatUnixMilli, `1614834367890`, tm},
+		{"unix nano", json.TimeFormatUnixNano, `1614834367890000000`, tm},
+		{"negative unix milli", json.TimeFormatUnixMilli, `-1500`, time.Unix(-2, 500000000)},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			src := fmt.Sprintf(`{"time":%[1]s,"timePtr":%[1]s}`, tc.src)
+			var v T
+			assertErr(t, json.UnmarshalWithOption([]byte(src), &v, json.DecodeTimeFormat(tc.format)))
+			assertEq(t, "time", true, v.Time.Equal(tc.expected))
+			assertEq(t, "time pointer", true, v.TimePtr.Equal(tc.expected))
+
+			v = T{}
+			dec := json.NewDecoder(strings.NewReader(src))
+			assertErr(t, dec.DecodeWithOption(&v, json.DecodeTimeFormat(tc.format)))
+			assertEq(t, "stream", true, v.Time.Equal(tc.expected))
+		})
+	}
+	t.Run("duration string", func(t *testing.T) {
+		var v T
+		src := `{"duration":"1h30m","durPtr":"1.5s"}`
+		assertErr(t, json.UnmarshalWithOption([]byte(src), &v, json.DecodeDurationFormat(json.DurationFormatString)))
+		assertEq(t, "duration", 90*time.Minute, v.Duration)
+		assertEq(t, "duration pointer", 1500*time.Millisecond, *v.DurPtr)
+
+		v = T{}
+		dec := json.NewDecoder(strings.NewReader(src))
+		assertErr(t, dec.DecodeWithOption(&v, json.DecodeDurationFormat(json.DurationFormatString)))
+		assertEq(t, "stream", 90*time.Minute, v.Duration)
+	})
+	t.Run("string option round trip", func(t *testing.T) {
+		type S struct {
+			Time     time.Time      `json:"time,string"`
+			Duration time.Duration  `json:"duration,string"`
+			DurPtr   *time.Duration `json:"durPtr,string"`
+		}
+		d := 90 * time.Minute
+		v := S{Time: tm, Duration: d, DurPtr: &d}
+		b, err := json.MarshalWithOption(v, json.EncodeDurationFormat(json.DurationFormatString))
+		assertErr(t, err)
+		assertEq(t, "encoded", `{"time":"2021-03-04T05:06:07.89Z","duration":"1h30m0s","durPtr":"1h30m0s"}`, string(b))
+		var decoded S
+		assertErr(t, json.UnmarshalWithOption(b, &decoded, json.DecodeDurationFormat(json.DurationFormatString)))
+		assertEq(t, "time", true, decoded.Time.Equal(tm))
+		assertEq(t, "duration", d, decoded.Duration)
+		assertEq(t, "duration pointer", d, *decoded.DurPtr)
+
+		decoded = S{}
+		dec := json.NewDecoder(bytes.NewReader(b))
+		assertErr(t, dec.DecodeWithOption(&decoded, json.DecodeDurationFormat(json.DurationFormatString)))
+		assertEq(t, "stream", d, decoded.Duration)
+	})
+	t.Run("null", func(t *testing.T) {
+		v := T{Time: tm, Duration: time.Second}
+		assertErr(t, json.UnmarshalWithOption([]byte(`{"time":null,"duration":null}`), &v,
+			json.DecodeTimeFormat(json.TimeFormatUnix),
+			json.DecodeDurationFormat(json.DurationFormatString),
+		))
+		assertEq(t, "time", true, v.Time.Equal(tm))
+		assertEq(t, "duration", time.Second, v.Duration)
+	})
+	t.Run("type mismatch", func(t *testing.T) {
+		var v T
+		err := json.UnmarshalWithOption([]byte(`{"time":"1614834367"}`), &v, json.DecodeTimeFormat(json.TimeFormatUnix))
+		if _, ok := err.(*json.UnmarshalTypeError); !ok {
+			t.Fatalf("expected *json.UnmarshalTypeError, got %T", err)
+		}
+		err = json.UnmarshalWithOption([]byte(`{"duration":10}`), &v, json.DecodeDurationFormat(json.DurationFormatString))
+		if _, ok := err.(*json.UnmarshalTypeError); !ok {
+			t.Fatalf("expected *json.UnmarshalTypeError, got %T", err)
+		}
+	})
+}
+
+func Test_DecodeStreamEscapedString(t *testing.T) {
+	var v []string
+	src := `["a\"b\\c\n", "\u00e9\ud83d\ude00", "` + strings.Repeat("x", 600) + `\t"]`
+	assertErr(t, json.NewDecoder(strings.NewReader(src)).Decode(&v))
+	assertEq(t, "length", 3, len(v))
+	assertEq(t, "escape", "a\"b\\c\n", v[0])
+	assertEq(t, "unicode", "é😀", v[1])
+	assertEq(t, "long", strings.Repeat("x", 600)+"\t", v[2])
+}
+
+func Test_DecodeEscapedString(t *testing.T) {
+	const src = `{"a":"x\"y","b":["\\\/\b\f\n\r\t","\u00e9\ud83d\ude00\ud83d"]}`
+	expected := map[string]interface{}{
+		"a": `x"y`,
+		"b": []interface{}{"\\/\b\f\n\r\t", "é😀\ufffd"},
+	}
+	type T struct {
+		A string   `json:"a"`
+		B []string `json:"b"`
+	}
+	t.Run("unmarshal", func(t *testing.T) {
+		var v interface{}
+		assertErr(t, json.Unmarshal([]byte(src), &v))
+		if !reflect.DeepEqual(expected, v) {
+			t.Fatalf("expected %q, got %q", expected, v)
+		}
+		var typed T
+		assertErr(t, json.Unmarshal([]byte(src), &typed))
+		assertEq(t, "a", `x"y`, typed.A)
+		assertEq(t, "b", "é😀\ufffd", typed.B[1])
+	})
+	t.Run("decode", func(t *testing.T) {
+		var v interface{}
+		assertErr(t, json.NewDecoder(strings.NewReader(src)).Decode(&v))
+		if !reflect.DeepEqual(expected, v) {
+			t.Fatalf("expected %q, got %q", expected, v)
+		}
+		var typed T
+		assertErr(t, json.NewDecoder(strings.NewReader(src)).Decode(&typed))
+		assertEq(t, "a", `x"y`, typed.A)
+		assertEq(t, "b", "é😀\ufffd", typed.B[1])
+	})
+}
+
+func Test_Iterator(t *testing.T) {
+	const src = `{"id": 12, "name": "a\nb", "score": -1.5, "ok": true, "tags": ["x", "y"],
+		"empty": {}, "none": null, "skip": {"a": [1, {"b": "}"}]}} 1 [null]`
+	t.Run("walk", func(t *testing.T) {
+		it := json.NewIterator(strings.NewReader(src))
+		assertEq(t, "next", json.ObjectValue, it.WhatIsNext())
+		var keys []string
+		for {
+			key, ok, err := it.ReadObjectKey()
+			assertErr(t, err)
+			if !ok {
+				break
+			}
+			keys = append(keys, string(key))
+			switch string(key) {
+			case "id":
+				assertEq(t, "next", json.NumberValue, it.WhatIsNext())
+				v, err := it.ReadInt64()
+				assertErr(t, err)
+				assertEq(t, "id", int64(12), v)
+			case "name":
+				v, err := it.ReadString()
+				assertErr(t, err)
+				assertEq(t, "name", "a\nb", v)
+			case "score":
+				v, err := it.ReadFloat64()
+				assertErr(t, err)
+				assertEq(t, "score", -1.5, v)
+			case "ok":
+				v, err := it.ReadBool()
+				assertErr(t, err)
+				assertEq(t, "ok", true, v)
+			case "tags":
+				var tags []string
+				for {
+					more, err := it.ReadArray()
+					assertErr(t, err)
+					if !more {
+						break
+					}
+					b, err := it.ReadBytes()
+					assertErr(t, err)
+					tags = append(tags, string(b))
+				}
+				assertEq(t, "tags", "x,y", strings.Join(tags, ","))
+			case "empty":
+				_, ok, err := it.ReadObjectKey()
+				assertErr(t, err)
+				assertEq(t, "empty", false, ok)
+			case "none":
+				isNull, err := it.ReadNull()
+				assertErr(t, err)
+				assertEq(t, "null", true, isNull)
+			default:
+				assertErr(t, it.Skip())
+			}
+		}
+		assertEq(t, "keys", "id,name,score,ok,tags,empty,none,skip", strings.Join(keys, ","))
+		isNull, err := it.ReadNull()
+		assertErr(t, err)
+		assertEq(t, "null", false, isNull)
+		v, err := it.ReadInt64()
+		assertErr(t, err)
+		assertEq(t, "top-level", int64(1), v)
+		more, err := it.ReadArray()
+		assertErr(t, err)
+		assertEq(t, "more", true, more)
+		s, err := it.ReadString()
+		assertErr(t, err)
+		assertEq(t, "null string", "", s)
+		more, err = it.ReadArray()
+		assertErr(t, err)
+		assertEq(t, "more", false, more)
+		assertEq(t, "next", json.InvalidValue, it.WhatIsNext())
+	})
+	t.Run("type mismatch", func(t *testing.T) {
+		it := json.NewIterator(strings.NewReader(`["a", 1.5, 2]`))
+		_, err := it.ReadArray()
+		assertErr(t, err)
+		_, err = it.ReadInt64()
+		if _, ok := err.(*json.UnmarshalTypeError); !ok {
+			t.Fatalf("expected *json.UnmarshalTypeError, got %v", err)
+		}
+		_, err = it.ReadArray()
+		assertErr(t, err)
+		_, err = it.ReadInt64()
+		if _, ok := err.(*json.UnmarshalTypeError); !ok {
+			t.Fatalf("expected *json.UnmarshalTypeError, got %v", err)
+		}
+		_, err = it.ReadArray()
+		assertErr(t, err)
+		v, err := it.ReadInt64()
+		assertErr(t, err)
+		assertEq(t, "value after mismatch", int64(2), v)
+	})
+	t.Run("syntax error", func(t *testing.T) {
+		it := json.NewIterator(strings.NewReader(`{"a" 1}`))
+		if _, _, err := it.ReadObjectKey(); err == nil {
+			t.Fatal("expected error")
+		}
+		it = json.NewIterator(strings.NewReader(`]`))
+		if err := it.Skip(); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func Test_DecoderReset(t *testing.T) {
+	dec := json.NewDecoder(strings.NewReader(`[1, 2]`))
+	dec.UseNumber()
+	tk, err := dec.Token()
+	assertErr(t, err)
+	assertEq(t, "[", "[", fmt.Sprint(tk))
+
+	dec.Reset(strings.NewReader(`{"a": 1} {"a": "b"}`))
+	var v map[string]interface{}
+	assertErr(t, dec.Decode(&v))
+	if _, ok := v["a"].(json.Number); !ok {
+		t.Fatalf("expected json.Number, got %T", v["a"])
+	}
+	assertErr(t, dec.Decode(&v))
+	assertEq(t, "a", "b", v["a"])
+	if err := dec.Decode(&v); err != io.EOF {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+}
+
 func Test_InvalidUnmarshalError(t *testing.T) {
 	t.Run("nil", func(t *testing.T) {
 		var v *struct{}
@@ -317,6 +1422,93 @@ func Test_Token(t *testing.T) {
 	}
 }
 
+func Test_TokenState(t *testing.T) {
+	tokens := func(t *testing.T, dec *json.Decoder) ([]json.Token, error) {
+		t.Helper()
+		var tks []json.Token
+		for {
+			tk, err := dec.Token()
+			if err == io.EOF {
+				return tks, nil
+			}
+			if err != nil {
+				return tks, err
+			}
+			tks = append(tks, tk)
+		}
+	}
+	t.Run("number", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(`[1, -2.5e3]`))
+		tks, err := tokens(t, dec)
+		assertErr(t, err)
+		assertEq(t, "tokens", fmt.Sprint([]json.Token{json.Delim('['), float64(1), float64(-2500), json.Delim(']')}), fmt.Sprint(tks))
+		assertEq(t, "type", "float64", fmt.Sprintf("%T", tks[1]))
+	})
+	t.Run("use number", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(`{"a": 12345678901234567890, "b": 1.5}`))
+		dec.UseNumber()
+		tks, err := tokens(t, dec)
+		assertErr(t, err)
+		assertEq(t, "a", json.Number("12345678901234567890"), tks[2])
+		assertEq(t, "b", json.Number("1.5"), tks[4])
+		for _, src := range []string{`-`, `1.2.3`, `[-1e]`} {
+			dec := json.NewDecoder(strings.NewReader(src))
+			dec.UseNumber()
+			if _, err := tokens(t, dec); err == nil {
+				t.Fatalf("expected error for %s", src)
+			} else if _, ok := err.(*json.SyntaxError); !ok {
+				t.Fatalf("expected SyntaxError for %s but got %T: %v", src, err, err)
+			}
+		}
+	})
+	t.Run("object key", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(`{"a": "b", "c": ["d"]}`))
+		var keys []string
+		for {
+			tk, err := dec.Token()
+			if err == io.EOF {
+				break
+			}
+			assertErr(t, err)
+			if dec.IsObjectKey() {
+				keys = append(keys, tk.(string))
+			}
+		}
+		assertEq(t, "keys", "[a c]", fmt.Sprint(keys))
+	})
+	t.Run("invalid sequence", func(t *testing.T) {
+		for _, src := range []string{
+			`{"a" "b"}`,
+			`{"a": "b" "c": 1}`,
+			`{"a": 1,, "b": 2}`,
+			`{1: 2}`,
+			`{"a": 1]`,
+			`[1 2]`,
+			`[1,]`,
+			`[1}`,
+			`[:1]`,
+			`1 ]`,
+			`-`,
+			`[1.2.3]`,
+			`{"a": 1.}`,
+			`[01]`,
+		} {
+			dec := json.NewDecoder(strings.NewReader(src))
+			if _, err := tokens(t, dec); err == nil {
+				t.Fatalf("expected error for %s", src)
+			} else if _, ok := err.(*json.SyntaxError); !ok {
+				t.Fatalf("expected SyntaxError for %s but got %T: %v", src, err, err)
+			}
+		}
+	})
+	t.Run("multiple values", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(`{"a": 1} [true] null`))
+		tks, err := tokens(t, dec)
+		assertErr(t, err)
+		assertEq(t, "tokens", fmt.Sprint([]json.Token{json.Delim('{'), "a", float64(1), json.Delim('}'), json.Delim('['), true, json.Delim(']'), nil}), fmt.Sprint(tks))
+	})
+}
+
 func Test_DecodeStream(t *testing.T) {
 	const stream = `
 	[
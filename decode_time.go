@@ -0,0 +1,208 @@
+package json
+
+import (
+	"reflect"
+	"time"
+	"unsafe"
+)
+
+// isFormatType reports whether typ is decoded in the format of the decoder.
+// time.Duration is decoded as int64 in the default format.
+func (d *Decoder) isFormatType(typ *rtype) bool {
+	return typ == timeType || (typ == durationType && d.ctx.durationFormat == DurationFormatString)
+}
+
+// isFormatField reports whether the field of typ is decoded in the format of the decoder.
+// The encoder ignores the string option of such a field, because the format decides the JSON value,
+// so the decoder ignores it too.
+func (d *Decoder) isFormatField(typ *rtype) bool {
+	return d.isFormatType(typ) || (typ.Kind() == reflect.Ptr && d.isFormatType(typ.Elem()))
+}
+
+func (d *Decoder) compileFormat(typ *rtype) decoder {
+	if typ == timeType {
+		return newTimeDecoder(d.ctx.timeFormat)
+	}
+	return newDurationDecoder()
+}
+
+type timeDecoder struct {
+	format        TimeFormat
+	intDecoder    *intDecoder
+	stringDecoder *stringDecoder
+}
+
+func newTimeDecoder(format TimeFormat) *timeDecoder {
+	return &timeDecoder{
+		format:        format,
+		intDecoder:    &intDecoder{typ: timeType, bitSize: 64},
+		stringDecoder: newStringDecoder(timeType),
+	}
+}
+
+func (d *timeDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
+	if d.format.isDefault() {
+		s.skipWhiteSpace()
+		start := s.cursor
+		if err := s.skipValue(); err != nil {
+			return err
+		}
+		return (*time.Time)(p).UnmarshalJSON(s.buf[start:s.cursor])
+	}
+	if d.format.unit != 0 {
+		bytes, err := d.intDecoder.decodeStreamByte(s)
+		if err != nil {
+			return err
+		}
+		if bytes == nil {
+			return nil
+		}
+		v, err := d.intDecoder.parseInt(bytes)
+		if err != nil {
+			err.(*UnmarshalTypeError).Offset = s.totalOffset()
+			return err
+		}
+		**(**time.Time)(unsafe.Pointer(&p)) = d.format.timeFromUnix(v)
+		return nil
+	}
+	bytes, err := decodeStreamTimeString(s, d.stringDecoder)
+	if err != nil {
+		return err
+	}
+	if bytes == nil {
+		return nil
+	}
+	t, err := time.Parse(d.format.layout, string(bytes))
+	if err != nil {
+		return err
+	}
+	**(**time.Time)(unsafe.Pointer(&p)) = t
+	return nil
+}
+
+func (d *timeDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+	if d.format.isDefault() {
+		cursor = skipWhiteSpace(buf, cursor)
+		end, err := skipValue(buf, cursor)
+		if err != nil {
+			return 0, err
+		}
+		if err := (*time.Time)(p).UnmarshalJSON(buf[cursor:end]); err != nil {
+			return 0, err
+		}
+		return end, nil
+	}
+	if d.format.unit != 0 {
+		bytes, c, err := d.intDecoder.decodeByte(buf, cursor)
+		if err != nil {
+			return c, err
+		}
+		cursor = c
+		if bytes == nil {
+			return cursor, nil
+		}
+		v, err := d.intDecoder.parseInt(bytes)
+		if err != nil {
+			err.(*UnmarshalTypeError).Offset = cursor
+			return cursor, err
+		}
+		**(**time.Time)(unsafe.Pointer(&p)) = d.format.timeFromUnix(v)
+		return cursor, nil
+	}
+	bytes, c, err := decodeTimeString(buf, cursor, d.stringDecoder)
+	if err != nil {
+		return c, err
+	}
+	cursor = c
+	if bytes == nil {
+		return cursor, nil
+	}
+	t, err := time.Parse(d.format.layout, string(bytes))
+	if err != nil {
+		return cursor, err
+	}
+	**(**time.Time)(unsafe.Pointer(&p)) = t
+	return cursor, nil
+}
+
+type durationDecoder struct {
+	stringDecoder *stringDecoder
+}
+
+func newDurationDecoder() *durationDecoder {
+	return &durationDecoder{stringDecoder: newStringDecoder(durationType)}
+}
+
+func (d *durationDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
+	bytes, err := decodeStreamTimeString(s, d.stringDecoder)
+	if err != nil {
+		return err
+	}
+	if bytes == nil {
+		return nil
+	}
+	v, err := time.ParseDuration(string(bytes))
+	if err != nil {
+		return err
+	}
+	**(**time.Duration)(unsafe.Pointer(&p)) = v
+	return nil
+}
+
+func (d *durationDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+	bytes, c, err := decodeTimeString(buf, cursor, d.stringDecoder)
+	if err != nil {
+		return c, err
+	}
+	cursor = c
+	if bytes == nil {
+		return cursor, nil
+	}
+	v, err := time.ParseDuration(string(bytes))
+	if err != nil {
+		return cursor, err
+	}
+	**(**time.Duration)(unsafe.Pointer(&p)) = v
+	return cursor, nil
+}
+
+// decodeStreamTimeString returns the unquoted string of time.Time or time.Duration,
+// or nil for null that leaves the value unchanged.
+func decodeStreamTimeString(s *stream, dec *stringDecoder) ([]byte, error) {
+	s.skipWhiteSpace()
+	switch s.char() {
+	case 'n':
+		return nil, nullBytes(s)
+	case '{', '[', 't', 'f', '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return nil, s.skipTypeMismatch(dec.typ)
+	}
+	bytes, err := dec.decodeStreamByte(s)
+	if err != nil {
+		return nil, err
+	}
+	if bytes == nil {
+		bytes = []byte{}
+	}
+	return bytes, nil
+}
+
+// decodeTimeString is the version of decodeStreamTimeString for the buffer.
+func decodeTimeString(buf []byte, cursor int64, dec *stringDecoder) ([]byte, int64, error) {
+	cursor = skipWhiteSpace(buf, cursor)
+	switch buf[cursor] {
+	case 'n':
+		c, err := skipNull(buf, cursor)
+		return nil, c, err
+	case '{', '[', 't', 'f', '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		c, err := skipTypeMismatch(buf, cursor, dec.typ)
+		return nil, c, err
+	}
+	bytes, c, err := dec.decodeByte(buf, cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+	if bytes == nil {
+		bytes = []byte{}
+	}
+	return bytes, c, nil
+}
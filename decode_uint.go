@@ -1,13 +1,22 @@
 package json
 
-import "unsafe"
+import (
+	"strconv"
+	"unsafe"
+)
 
 type uintDecoder struct {
-	op func(unsafe.Pointer, uint64)
+	typ     *rtype
+	bitSize int
+	op      func(unsafe.Pointer, uint64)
 }
 
-func newUintDecoder(op func(unsafe.Pointer, uint64)) *uintDecoder {
-	return &uintDecoder{op: op}
+func newUintDecoder(typ *rtype, op func(unsafe.Pointer, uint64)) *uintDecoder {
+	return &uintDecoder{
+		typ:     typ,
+		bitSize: int(typ.Size() * 8),
+		op:      op,
+	}
 }
 
 var pow10u64 = [...]uint64{
@@ -15,15 +24,30 @@ var pow10u64 = [...]uint64{
 	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19,
 }
 
-func (d *uintDecoder) parseUint(b []byte) uint64 {
+func (d *uintDecoder) parseUint(b []byte) (uint64, error) {
 	maxDigit := len(b)
+	if maxDigit > 19 {
+		// may overflow uint64, so fallback to strconv
+		v, err := strconv.ParseUint(*(*string)(unsafe.Pointer(&b)), 10, d.bitSize)
+		if err != nil {
+			return 0, d.overflowError(b)
+		}
+		return v, nil
+	}
 	sum := uint64(0)
 	for i := 0; i < maxDigit; i++ {
 		c := uint64(b[i]) - 48
 		digitValue := pow10u64[maxDigit-i-1]
 		sum += c * digitValue
 	}
-	return sum
+	if d.bitSize < 64 && sum > uint64(1)<<uint(d.bitSize)-1 {
+		return 0, d.overflowError(b)
+	}
+	return sum, nil
+}
+
+func (d *uintDecoder) overflowError(b []byte) *UnmarshalTypeError {
+	return &UnmarshalTypeError{Value: "number " + string(b), Type: rtype2type(d.typ)}
 }
 
 func (d *uintDecoder) decodeStreamByte(s *stream) ([]byte, error) {
@@ -32,7 +56,7 @@ func (d *uintDecoder) decodeStreamByte(s *stream) ([]byte, error) {
 		case ' ', '\n', '\t', '\r':
 			s.cursor++
 			continue
-		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			start := s.cursor
 			for {
 				s.cursor++
@@ -46,12 +70,27 @@ func (d *uintDecoder) decodeStreamByte(s *stream) ([]byte, error) {
 				}
 				break
 			}
+			if s.buf[start] == '-' || floatTable[s.char()] {
+				// negative number or number that has fraction or exponent part cannot be stored in unsigned integer
+				if floatTable[s.char()] {
+					floatBytes(s)
+				}
+				num := s.buf[start:s.cursor]
+				return nil, errUnmarshalType("number "+string(num), d.typ, s.totalOffset())
+			}
 			num := s.buf[start:s.cursor]
 			return num, nil
+		case 'n':
+			if err := nullBytes(s); err != nil {
+				return nil, err
+			}
+			return nil, nil
 		case nul:
 			if s.read() {
 				continue
 			}
+		default:
+			return nil, s.skipTypeMismatch(d.typ)
 		}
 		break
 	}
@@ -64,7 +103,7 @@ func (d *uintDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, error
 		switch buf[cursor] {
 		case ' ', '\n', '\t', '\r':
 			continue
-		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
 			start := cursor
 			cursor++
 			for ; cursor < buflen; cursor++ {
@@ -74,10 +113,24 @@ func (d *uintDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, error
 				}
 				break
 			}
+			if buf[start] == '-' || floatTable[buf[cursor]] {
+				// negative number or number that has fraction or exponent part cannot be stored in unsigned integer
+				for floatTable[buf[cursor]] {
+					cursor++
+				}
+				return nil, cursor, errUnmarshalType("number "+string(buf[start:cursor]), d.typ, cursor)
+			}
 			num := buf[start:cursor]
 			return num, cursor, nil
+		case 'n':
+			c, err := skipNull(buf, cursor)
+			if err != nil {
+				return nil, 0, err
+			}
+			return nil, c, nil
 		default:
-			return nil, 0, errInvalidCharacter(buf[cursor], "number(unsigned integer)", cursor)
+			c, err := skipTypeMismatch(buf, cursor, d.typ)
+			return nil, c, err
 		}
 	}
 	return nil, 0, errUnexpectedEndOfJSON("number(unsigned integer)", cursor)
@@ -88,16 +141,32 @@ func (d *uintDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 	if err != nil {
 		return err
 	}
-	d.op(p, d.parseUint(bytes))
+	if bytes == nil {
+		return nil
+	}
+	v, err := d.parseUint(bytes)
+	if err != nil {
+		err.(*UnmarshalTypeError).Offset = s.totalOffset()
+		return err
+	}
+	d.op(p, v)
 	return nil
 }
 
-func (d *uintDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+func (d *uintDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
 	bytes, c, err := d.decodeByte(buf, cursor)
 	if err != nil {
-		return 0, err
+		return c, err
 	}
 	cursor = c
-	d.op(p, d.parseUint(bytes))
+	if bytes == nil {
+		return cursor, nil
+	}
+	v, err := d.parseUint(bytes)
+	if err != nil {
+		err.(*UnmarshalTypeError).Offset = cursor
+		return cursor, err
+	}
+	d.op(p, v)
 	return cursor, nil
 }
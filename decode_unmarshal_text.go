@@ -18,6 +18,10 @@ func newUnmarshalTextDecoder(typ *rtype) *unmarshalTextDecoder {
 
 func (d *unmarshalTextDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 	s.skipWhiteSpace()
+	switch s.char() {
+	case '{', '[':
+		return s.skipTypeMismatch(d.typ)
+	}
 	start := s.cursor
 	if err := s.skipValue(); err != nil {
 		return err
@@ -36,8 +40,12 @@ func (d *unmarshalTextDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 	return nil
 }
 
-func (d *unmarshalTextDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+func (d *unmarshalTextDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
 	cursor = skipWhiteSpace(buf, cursor)
+	switch buf[cursor] {
+	case '{', '[':
+		return skipTypeMismatch(buf, cursor, d.typ)
+	}
 	start := cursor
 	end, err := skipValue(buf, cursor)
 	if err != nil {
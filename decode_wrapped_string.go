@@ -10,7 +10,7 @@ type wrappedStringDecoder struct {
 func newWrappedStringDecoder(dec decoder) *wrappedStringDecoder {
 	return &wrappedStringDecoder{
 		dec:           dec,
-		stringDecoder: newStringDecoder(),
+		stringDecoder: newStringDecoder(stringType),
 	}
 }
 
@@ -41,14 +41,14 @@ func (d *wrappedStringDecoder) decodeStream(s *stream, p unsafe.Pointer) error {
 	return nil
 }
 
-func (d *wrappedStringDecoder) decode(buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
+func (d *wrappedStringDecoder) decode(ctx *decodeRuntimeContext, buf []byte, cursor int64, p unsafe.Pointer) (int64, error) {
 	bytes, c, err := d.stringDecoder.decodeByte(buf, cursor)
 	if err != nil {
 		return 0, err
 	}
 	bytes = append(bytes, nul)
-	if _, err := d.dec.decode(bytes, 0, p); err != nil {
-		return 0, err
+	if _, err := d.dec.decode(ctx, bytes, 0, p); err != nil {
+		return c, err
 	}
 	return c, nil
 }
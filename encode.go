@@ -9,6 +9,7 @@ import (
 	"reflect"
 	"strconv"
 	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -19,6 +20,17 @@ type Encoder struct {
 	enabledIndent                  bool
 	enabledHTMLEscape              bool
 	unorderedMap                   bool
+	strictTagOptions               bool
+	fieldFilter                    fieldFilter
+	trace                          io.Writer
+	flushThreshold                 int
+	sortingMaps                    int
+	customEncoders                 map[uintptr]EncoderFunc
+	customEncodersKey              string
+	timeFormat                     TimeFormat
+	durationFormat                 DurationFormat
+	filterLevels                   []fieldFilterLevel
+	filterMarks                    []fieldFilterMark
 	prefix                         []byte
 	indentStr                      []byte
 	structTypeToCompiledCode       map[uintptr]*compiledCode
@@ -31,35 +43,40 @@ type compiledCode struct {
 
 const (
 	bufSize = 1024
-)
 
-type opcodeMap struct {
-	sync.Map
-}
+	// flushKeepBytes is the number of the last bytes that flush keeps in the buffer,
+	// because the opcodes rewrite the trailing ",\n" or "{\n" of the encoded value.
+	flushKeepBytes = 2
+)
 
 type opcodeSet struct {
 	codeIndent *opcode
 	code       *opcode
 	ctx        sync.Pool
+	opcodes    int // number of opcodes compiled for code
+	tagCheck   structTagCheck
 }
 
-func (m *opcodeMap) get(k uintptr) *opcodeSet {
-	if v, ok := m.Load(k); ok {
-		return v.(*opcodeSet)
-	}
-	return nil
+// isZeroer is implemented by the types such as time.Time
+// that decide their zero value for the omitzero option.
+type isZeroer interface {
+	IsZero() bool
 }
 
-func (m *opcodeMap) set(k uintptr, op *opcodeSet) {
-	m.Store(k, op)
+// appendMarshaler is implemented by the pointers to the types whose methods are generated by cmd/jsongen.
+// AppendJSON appends exactly one valid and compact JSON value to b, so Marshal writes the output as it is
+// instead of calling MarshalJSON, unless the options that change the encoding are set.
+type appendMarshaler interface {
+	AppendJSON(b []byte) ([]byte, error)
 }
 
 var (
-	encPool         sync.Pool
-	codePool        sync.Pool
-	cachedOpcode    opcodeMap
-	marshalJSONType reflect.Type
-	marshalTextType reflect.Type
+	encPool             sync.Pool
+	codePool            sync.Pool
+	marshalJSONType     reflect.Type
+	marshalTextType     reflect.Type
+	appendMarshalerType reflect.Type
+	isZeroerType        reflect.Type
 )
 
 func init() {
@@ -72,9 +89,10 @@ func init() {
 			}
 		},
 	}
-	cachedOpcode = opcodeMap{}
 	marshalJSONType = reflect.TypeOf((*Marshaler)(nil)).Elem()
 	marshalTextType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	appendMarshalerType = reflect.TypeOf((*appendMarshaler)(nil)).Elem()
+	isZeroerType = reflect.TypeOf((*isZeroer)(nil)).Elem()
 }
 
 // NewEncoder returns a new encoder that writes to w.
@@ -123,6 +141,19 @@ func (e *Encoder) SetEscapeHTML(on bool) {
 	e.enabledHTMLEscape = on
 }
 
+// SetFlushThreshold makes the encoder write the encoded bytes to the stream
+// whenever more than n bytes are buffered while encoding a value,
+// so that a large value is encoded with the bounded memory.
+// The bytes of a map are buffered until the map is encoded, because the keys are sorted at the end.
+// If the encoding fails, the bytes already written to the stream are not removed.
+// n less than 1 disables flushing, which is the default, and the value is written at once.
+func (e *Encoder) SetFlushThreshold(n int) {
+	if n < 0 {
+		n = 0
+	}
+	e.flushThreshold = n
+}
+
 // SetIndent instructs the encoder to format each subsequent encoded value as if indented by the package-level function Indent(dst, src, prefix, indent).
 // Calling SetIndent("", "") disables indentation.
 func (e *Encoder) SetIndent(prefix, indent string) {
@@ -135,6 +166,42 @@ func (e *Encoder) SetIndent(prefix, indent string) {
 	e.enabledIndent = true
 }
 
+// Reset discards the buffered output and makes e write to w.
+// The buffer and the settings such as SetIndent and SetEscapeHTML are kept,
+// so that an Encoder can be pooled and reused for many writers.
+func (e *Encoder) Reset(w io.Writer) {
+	e.w = w
+	e.buf = e.buf[:0]
+}
+
+// canFlush reports whether the buffered bytes can be written to the stream.
+// The positions in the buffer recorded for sorting the maps and for the field filter must not change.
+func (e *Encoder) canFlush() bool {
+	return e.w != nil && e.sortingMaps == 0 && len(e.filterMarks) == 0
+}
+
+// flushAtBoundary flushes the buffered bytes if they exceed the flush threshold.
+// The VM calls it at the boundaries of the elements and the struct fields
+// instead of before each opcode, so that the other opcodes pay nothing for flushing.
+func (e *Encoder) flushAtBoundary() error {
+	if e.flushThreshold == 0 || len(e.buf) <= e.flushThreshold || !e.canFlush() {
+		return nil
+	}
+	return e.flush()
+}
+
+func (e *Encoder) flush() error {
+	n := len(e.buf) - flushKeepBytes
+	if n <= 0 {
+		return nil
+	}
+	if _, err := e.w.Write(e.buf[:n]); err != nil {
+		return err
+	}
+	e.buf = e.buf[:copy(e.buf, e.buf[n:])]
+	return nil
+}
+
 func (e *Encoder) release() {
 	e.w = nil
 	encPool.Put(e)
@@ -145,6 +212,14 @@ func (e *Encoder) reset() {
 	e.enabledHTMLEscape = true
 	e.enabledIndent = false
 	e.unorderedMap = false
+	e.strictTagOptions = false
+	e.fieldFilter = nil
+	e.trace = nil
+	e.flushThreshold = 0
+	e.customEncoders = nil
+	e.customEncodersKey = ""
+	e.timeFormat = TimeFormat{}
+	e.durationFormat = DurationFormatNanoseconds
 }
 
 func (e *Encoder) encodeForMarshal(v interface{}) ([]byte, error) {
@@ -161,6 +236,23 @@ func (e *Encoder) encodeForMarshal(v interface{}) ([]byte, error) {
 	return copied, nil
 }
 
+// encodeForAppend encodes v onto dst instead of the buffer of the encoder.
+// The buffer is restored so that the pooled encoder does not keep dst.
+func (e *Encoder) encodeForAppend(dst []byte, v interface{}) ([]byte, error) {
+	buf := e.buf
+	e.buf = dst
+	err := e.encode(v)
+	b := e.buf
+	e.buf = buf[:0]
+	if err != nil {
+		return dst, err
+	}
+	if e.enabledIndent {
+		return b[:len(b)-2], nil
+	}
+	return b[:len(b)-1], nil
+}
+
 func (e *Encoder) encode(v interface{}) error {
 	if v == nil {
 		e.encodeNull()
@@ -175,46 +267,83 @@ func (e *Encoder) encode(v interface{}) error {
 	typ := header.typ
 
 	typeptr := uintptr(unsafe.Pointer(typ))
-	if codeSet := cachedOpcode.get(typeptr); codeSet != nil {
-		var code *opcode
-		if e.enabledIndent {
-			code = codeSet.codeIndent
-		} else {
-			code = codeSet.code
-		}
-		ctx := codeSet.ctx.Get().(*encodeRuntimeContext)
-		p := uintptr(header.ptr)
-		ctx.init(p)
-		err := e.run(ctx, code)
-		codeSet.ctx.Put(ctx)
-		return err
-	}
 
 	// noescape trick for header.typ ( reflect.*rtype )
 	copiedType := *(**rtype)(unsafe.Pointer(&typeptr))
 
+	codeSet, err := e.compileToGetOpcodeSet(typeptr, copiedType)
+	if err != nil {
+		return err
+	}
+	if e.strictTagOptions {
+		if err := codeSet.tagCheck.check(copiedType); err != nil {
+			return err
+		}
+	}
+
+	var code *opcode
+	if e.enabledIndent {
+		code = codeSet.codeIndent
+	} else {
+		code = codeSet.code
+	}
+	ctx := codeSet.ctx.Get().(*encodeRuntimeContext)
+	p := uintptr(header.ptr)
+	ctx.init(p)
+	e.sortingMaps = 0
+	err = e.run(ctx, code)
+	codeSet.ctx.Put(ctx)
+	return err
+}
+
+func (e *Encoder) compileToGetOpcodeSet(typeptr uintptr, typ *rtype) (*opcodeSet, error) {
+	if len(e.customEncoders) > 0 && e.customEncodersKey == "" {
+		e.customEncodersKey = e.customKey()
+	}
+	key := cacheKey{
+		typeptr:        typeptr,
+		timeFormat:     e.timeFormat,
+		durationFormat: e.durationFormat,
+		custom:         e.customEncodersKey,
+		generated:      e.usesGeneratedCode(),
+	}
+	if codeSet := cachedOpcode.get(key); codeSet != nil {
+		return codeSet.(*opcodeSet), nil
+	}
+	start := time.Now()
+	codeSet, err := e.compileOpcodeSet(typ)
+	if err != nil {
+		return nil, err
+	}
+	cachedOpcode.set(key, codeSet, codeSet.opcodes, time.Since(start))
+	return codeSet, nil
+}
+
+func (e *Encoder) compileOpcodeSet(typ *rtype) (*opcodeSet, error) {
 	codeIndent, err := e.compileHead(&encodeCompileContext{
-		typ:        copiedType,
+		typ:        typ,
 		root:       true,
 		withIndent: true,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	code, err := e.compileHead(&encodeCompileContext{
-		typ:        copiedType,
+	ctx := &encodeCompileContext{
+		typ:        typ,
 		root:       true,
 		withIndent: false,
-	})
+	}
+	code, err := e.compileHead(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	codeIndent = copyOpcode(codeIndent)
 	code = copyOpcode(code)
 	codeLength := code.totalLength()
-	codeSet := &opcodeSet{
+	return &opcodeSet{
 		codeIndent: codeIndent,
 		code:       code,
+		opcodes:    code.codeCount(),
 		ctx: sync.Pool{
 			New: func() interface{} {
 				return &encodeRuntimeContext{
@@ -223,25 +352,7 @@ func (e *Encoder) encode(v interface{}) error {
 				}
 			},
 		},
-	}
-	cachedOpcode.set(typeptr, codeSet)
-	p := uintptr(header.ptr)
-	ctx := codeSet.ctx.Get().(*encodeRuntimeContext)
-	ctx.init(p)
-
-	var c *opcode
-	if e.enabledIndent {
-		c = codeIndent
-	} else {
-		c = code
-	}
-
-	if err := e.run(ctx, c); err != nil {
-		codeSet.ctx.Put(ctx)
-		return err
-	}
-	codeSet.ctx.Put(ctx)
-	return nil
+	}, nil
 }
 
 func (e *Encoder) encodeInt(v int) {
@@ -9,7 +9,29 @@ import (
 
 func (e *Encoder) compileHead(ctx *encodeCompileContext) (*opcode, error) {
 	typ := ctx.typ
+	if fn := e.customEncoder(typ); fn != nil {
+		// the root value of the type stored directly in interface{} is not addressed by ptr
+		return e.compileCustom(ctx, fn, isDirectIface(rtype2type(typ)))
+	}
+	if e.isFormatType(typ) {
+		return e.compileFormat(ctx), nil
+	}
+	if typ.Kind() == reflect.Ptr && e.isFormatType(typ.Elem()) {
+		// ptr of the root points to the value
+		return e.compileFormat(ctx.withType(typ.Elem())), nil
+	}
+	generated := isGeneratedType(typ)
+	if generated && e.usesGeneratedCode() {
+		if typ.Kind() == reflect.Ptr {
+			// ptr of the root points to the value
+			return e.compileAppendJSON(ctx.withType(typ.Elem()), false), nil
+		}
+		// the root value of the type stored directly in interface{} is not addressed by ptr
+		return e.compileAppendJSON(ctx, isDirectIface(rtype2type(typ))), nil
+	}
 	switch {
+	case generated:
+		// the struct is compiled instead of the generated MarshalJSON
 	case typ.Implements(marshalJSONType):
 		return e.compileMarshalJSON(ctx)
 	case rtype_ptrTo(typ).Implements(marshalJSONType):
@@ -23,6 +45,9 @@ func (e *Encoder) compileHead(ctx *encodeCompileContext) (*opcode, error) {
 	if typ.Kind() == reflect.Ptr {
 		typ = typ.Elem()
 		isPtr = true
+		if fn := e.customEncoder(typ); fn != nil {
+			return e.compileCustom(ctx.withType(typ), fn, false)
+		}
 	}
 	if typ.Kind() == reflect.Map {
 		return e.compileMap(ctx.withType(typ), isPtr)
@@ -48,7 +73,27 @@ func (e *Encoder) implementsMarshaler(typ *rtype) bool {
 
 func (e *Encoder) compile(ctx *encodeCompileContext) (*opcode, error) {
 	typ := ctx.typ
+	if fn := e.customEncoder(typ); fn != nil {
+		return e.compileCustom(ctx, fn, false)
+	}
+	if e.isFormatType(typ) {
+		return e.compileFormat(ctx), nil
+	}
+	if typ.Kind() == reflect.Ptr && e.isFormatType(typ.Elem()) {
+		// the pointer type implements Marshaler by the methods of the value type
+		return e.compilePtr(ctx)
+	}
+	generated := isGeneratedType(typ)
+	if generated && e.usesGeneratedCode() {
+		if typ.Kind() == reflect.Ptr {
+			// the pointer type implements Marshaler by the methods of the value type
+			return e.compilePtr(ctx)
+		}
+		return e.compileAppendJSON(ctx, false), nil
+	}
 	switch {
+	case generated:
+		// the struct is compiled instead of the generated MarshalJSON
 	case typ.Implements(marshalJSONType):
 		return e.compileMarshalJSON(ctx)
 	case rtype_ptrTo(typ).Implements(marshalJSONType):
@@ -109,31 +154,31 @@ func (e *Encoder) compile(ctx *encodeCompileContext) (*opcode, error) {
 	return nil, &UnsupportedTypeError{Type: rtype2type(typ)}
 }
 
+// compileKey compiles the map key in the same priority as encoding/json:
+// string kinds, encoding.TextMarshaler and then integer kinds.
 func (e *Encoder) compileKey(ctx *encodeCompileContext) (*opcode, error) {
 	typ := ctx.typ
 	switch {
-	case typ.Implements(marshalJSONType):
-		return e.compileMarshalJSON(ctx)
-	case rtype_ptrTo(typ).Implements(marshalJSONType):
-		return e.compileMarshalJSONPtr(ctx)
+	case typ.Kind() == reflect.String:
+		return e.compileString(ctx)
 	case typ.Implements(marshalTextType):
 		return e.compileMarshalText(ctx)
-	case rtype_ptrTo(typ).Implements(marshalTextType):
-		return e.compileMarshalTextPtr(ctx)
 	}
 	switch typ.Kind() {
 	case reflect.Ptr:
 		return e.compilePtr(ctx)
 	case reflect.Interface:
 		return e.compileInterface(ctx)
-	case reflect.String:
-		return e.compileString(ctx)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return e.compileIntString(ctx)
 	}
 	return nil, &UnsupportedTypeError{Type: rtype2type(typ)}
 }
 
 func (e *Encoder) compilePtr(ctx *encodeCompileContext) (*opcode, error) {
 	ptrOpcodeIndex := ctx.opcodeIndex
+	ptrIndex := ctx.ptrIndex
 	ctx.incIndex()
 	code, err := e.compile(ctx.withType(ctx.typ.Elem()))
 	if err != nil {
@@ -148,9 +193,38 @@ func (e *Encoder) compilePtr(ctx *encodeCompileContext) (*opcode, error) {
 	}
 	c := ctx.context()
 	c.opcodeIndex = ptrOpcodeIndex
+	c.ptrIndex = ptrIndex
 	return newOpCodeWithNext(c, opPtr, code), nil
 }
 
+// isAppendMarshalerType reports whether the pointer to the value of typ implements appendMarshaler.
+func isAppendMarshalerType(typ *rtype) bool {
+	return typ.Kind() != reflect.Ptr && rtype_ptrTo(typ).Implements(appendMarshalerType)
+}
+
+// isGeneratedType reports whether typ or the type it points to has the methods generated by cmd/jsongen.
+func isGeneratedType(typ *rtype) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return isAppendMarshalerType(typ)
+}
+
+// usesGeneratedCode reports whether the methods generated by cmd/jsongen encode their types.
+// The generated code encodes like Marshal without options,
+// so the types are compiled like the other structs with the options that change the encoding.
+func (e *Encoder) usesGeneratedCode() bool {
+	return e.enabledHTMLEscape && !e.unorderedMap && e.fieldFilter == nil && len(e.customEncoders) == 0 &&
+		e.timeFormat.isDefault() && e.durationFormat == DurationFormatNanoseconds
+}
+
+func (e *Encoder) compileAppendJSON(ctx *encodeCompileContext, direct bool) *opcode {
+	code := newOpCode(ctx.withType(rtype_ptrTo(ctx.typ)), opAppendJSON)
+	code.root = direct
+	ctx.incIndex()
+	return code
+}
+
 func (e *Encoder) compileMarshalJSON(ctx *encodeCompileContext) (*opcode, error) {
 	code := newOpCode(ctx, opMarshalJSON)
 	ctx.incIndex()
@@ -247,6 +321,36 @@ func (e *Encoder) compileFloat64(ctx *encodeCompileContext) (*opcode, error) {
 	return code, nil
 }
 
+// compileIntString compiles the integer that is encoded as JSON string such as "123".
+func (e *Encoder) compileIntString(ctx *encodeCompileContext) (*opcode, error) {
+	var op opType
+	switch ctx.typ.Kind() {
+	case reflect.Int:
+		op = opIntString
+	case reflect.Int8:
+		op = opInt8String
+	case reflect.Int16:
+		op = opInt16String
+	case reflect.Int32:
+		op = opInt32String
+	case reflect.Int64:
+		op = opInt64String
+	case reflect.Uint, reflect.Uintptr:
+		op = opUintString
+	case reflect.Uint8:
+		op = opUint8String
+	case reflect.Uint16:
+		op = opUint16String
+	case reflect.Uint32:
+		op = opUint32String
+	case reflect.Uint64:
+		op = opUint64String
+	}
+	code := newOpCode(ctx, op)
+	ctx.incIndex()
+	return code, nil
+}
+
 func (e *Encoder) compileString(ctx *encodeCompileContext) (*opcode, error) {
 	code := newOpCode(ctx, opString)
 	ctx.incIndex()
@@ -634,10 +738,186 @@ func (e *Encoder) typeToFieldType(code *opcode) opType {
 	return opStructField
 }
 
+// implementsIsZero reports whether the zero value of typ is decided by IsZero method.
+func implementsIsZero(typ reflect.Type) bool {
+	return typ.Implements(isZeroerType) || reflect.PtrTo(typ).Implements(isZeroerType)
+}
+
+// compileIsZero returns the zero check of the omitzero option for the value of typ at p,
+// so that the type is inspected once when the field is compiled.
+// The nil pointer and interface are zero without calling IsZero method.
+func compileIsZero(typ reflect.Type) func(uintptr) bool {
+	if !implementsIsZero(typ) {
+		return compileIsZeroValue(typ)
+	}
+	switch {
+	case typ.Kind() == reflect.Interface:
+		// the method of the dynamic type is found at runtime
+		return func(p uintptr) bool {
+			v := reflect.NewAt(typ, *(*unsafe.Pointer)(unsafe.Pointer(&p))).Elem()
+			return v.IsNil() || v.Interface().(isZeroer).IsZero()
+		}
+	case typ.Kind() == reflect.Ptr:
+		isZero := isZeroMethod(typ)
+		return func(p uintptr) bool {
+			v := **(**uintptr)(unsafe.Pointer(&p))
+			return v == 0 || isZero(v)
+		}
+	}
+	return isZeroMethod(reflect.PtrTo(typ))
+}
+
+// isZeroMethod returns the function that calls IsZero method of the pointer type ptrType
+// with the receiver p without converting p to the interface by reflect.
+func isZeroMethod(ptrType reflect.Type) func(uintptr) bool {
+	zeroer := reflect.New(ptrType.Elem()).Interface().(isZeroer)
+	return func(p uintptr) bool {
+		z := zeroer
+		(*interfaceHeader)(unsafe.Pointer(&z)).ptr = *(*unsafe.Pointer)(unsafe.Pointer(&p))
+		return z.IsZero()
+	}
+}
+
+// compileIsZeroValue returns the check whether the value of typ at p is the zero value of typ.
+// The value whose zero value is the memory of zero bytes is compared as the memory.
+func compileIsZeroValue(typ reflect.Type) func(uintptr) bool {
+	if isZeroBytesType(typ) {
+		return compileIsZeroBytes(typ)
+	}
+	switch typ.Kind() {
+	case reflect.String:
+		return func(p uintptr) bool {
+			return len(**(**string)(unsafe.Pointer(&p))) == 0
+		}
+	case reflect.Array:
+		isZero := compileIsZeroValue(typ.Elem())
+		size := typ.Elem().Size()
+		length := uintptr(typ.Len())
+		return func(p uintptr) bool {
+			for i := uintptr(0); i < length; i++ {
+				if !isZero(p + i*size) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	type fieldZero struct {
+		offset uintptr
+		isZero func(uintptr) bool
+	}
+	fields := make([]fieldZero, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		fields = append(fields, fieldZero{offset: field.Offset, isZero: compileIsZeroValue(field.Type)})
+	}
+	return func(p uintptr) bool {
+		for _, field := range fields {
+			if !field.isZero(p + field.offset) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// isZeroBytesType reports whether the value of typ is zero if and only if all bytes of it are zero.
+// That is not the case for the string whose data pointer remains after slicing to empty,
+// and for the struct with padding.
+func isZeroBytesType(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.String:
+		return false
+	case reflect.Array:
+		return isZeroBytesType(typ.Elem())
+	case reflect.Struct:
+		size := uintptr(0)
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !isZeroBytesType(field.Type) {
+				return false
+			}
+			size += field.Type.Size()
+		}
+		return size == typ.Size()
+	}
+	return true
+}
+
+func compileIsZeroBytes(typ reflect.Type) func(uintptr) bool {
+	size := typ.Size()
+	if size == 0 {
+		return func(uintptr) bool { return true }
+	}
+	if uintptr(typ.Align()) == size {
+		// the memory is read by one aligned load
+		switch size {
+		case 1:
+			return func(p uintptr) bool { return **(**uint8)(unsafe.Pointer(&p)) == 0 }
+		case 2:
+			return func(p uintptr) bool { return **(**uint16)(unsafe.Pointer(&p)) == 0 }
+		case 4:
+			return func(p uintptr) bool { return **(**uint32)(unsafe.Pointer(&p)) == 0 }
+		case 8:
+			return func(p uintptr) bool { return **(**uint64)(unsafe.Pointer(&p)) == 0 }
+		}
+	}
+	return func(p uintptr) bool {
+		b := *(*[]byte)(unsafe.Pointer(&sliceHeader{
+			data: *(*unsafe.Pointer)(unsafe.Pointer(&p)),
+			len:  int(size),
+			cap:  int(size),
+		}))
+		for _, c := range b {
+			if c != 0 {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// omitZeroAsEmpty reports whether the omitzero option of the field omits the same values as omitempty,
+// so that the field is encoded by the specialized omitempty operations.
+// That is the case for scalar types, and for slices and maps that also have the omitempty option,
+// because omitempty omits the nil slice and map too.
+func omitZeroAsEmpty(tag *structTag) bool {
+	switch tag.field.Type.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Bool, reflect.String:
+		return true
+	case reflect.Slice, reflect.Map:
+		return tag.isOmitEmpty
+	}
+	return false
+}
+
+// omitEmptyKeepsStruct reports whether the omitempty option of the field encoded by op must be ignored.
+// The generic omitempty opcodes test only the first word of the value, and encoding/json never omits a struct
+// for omitempty, so a struct such as time.Time that is encoded by its own opcode is always encoded.
+func omitEmptyKeepsStruct(op opType, tag *structTag) bool {
+	if tag.field.Type.Kind() != reflect.Struct {
+		return false
+	}
+	switch op {
+	case opTime, opTimeIndent, opAppendJSON, opAppendJSONIndent:
+		return true
+	}
+	return false
+}
+
 func (e *Encoder) optimizeStructHeader(op opType, tag *structTag, withIndent bool) opType {
 	headType := e.typeToHeaderType(op)
+	isOmitEmpty := tag.isOmitZero || tag.isOmitEmpty && !omitEmptyKeepsStruct(op, tag)
 	switch {
-	case tag.isOmitEmpty:
+	case tag.isOmitZero && implementsIsZero(tag.field.Type):
+		headType = headType.toOmitZeroMethod()
+	case tag.isOmitZero && !omitZeroAsEmpty(tag):
+		headType = headType.toOmitZero()
+	case isOmitEmpty && tag.isString && headType.toOmitEmptyStringTag() != headType:
+		headType = headType.toOmitEmptyStringTag()
+	case isOmitEmpty:
 		headType = headType.headToOmitEmptyHead()
 	case tag.isString:
 		headType = headType.headToStringTagHead()
@@ -650,8 +930,15 @@ func (e *Encoder) optimizeStructHeader(op opType, tag *structTag, withIndent boo
 
 func (e *Encoder) optimizeStructField(code *opcode, tag *structTag, withIndent bool) opType {
 	fieldType := e.typeToFieldType(code)
+	isOmitEmpty := tag.isOmitZero || tag.isOmitEmpty && !omitEmptyKeepsStruct(code.op, tag)
 	switch {
-	case tag.isOmitEmpty:
+	case tag.isOmitZero && implementsIsZero(tag.field.Type):
+		fieldType = fieldType.toOmitZeroMethod()
+	case tag.isOmitZero && !omitZeroAsEmpty(tag):
+		fieldType = fieldType.toOmitZero()
+	case isOmitEmpty && tag.isString && fieldType.toOmitEmptyStringTag() != fieldType:
+		fieldType = fieldType.toOmitEmptyStringTag()
+	case isOmitEmpty:
 		fieldType = fieldType.fieldToOmitEmptyField()
 	case tag.isString:
 		fieldType = fieldType.fieldToStringTagField()
@@ -715,6 +1002,15 @@ func (e *Encoder) structHeader(ctx *encodeCompileContext, fieldCode *opcode, val
 		opStructFieldHeadOmitEmptyStructIndent,
 		opStructFieldHeadStringTagIndent:
 		return valueCode.beforeLastCode()
+	case opStructFieldHeadOmitZero,
+		opStructFieldHeadOmitZeroMethod,
+		opStructFieldHeadOmitZeroIndent,
+		opStructFieldHeadOmitZeroMethodIndent:
+		// the value code of struct has the type of the first field,
+		// so the zero value is checked with the type of the field
+		fieldCode.typ = type2rtype(tag.field.Type)
+		fieldCode.isZero = compileIsZero(tag.field.Type)
+		return valueCode.beforeLastCode()
 	}
 	ctx.decOpcodeIndex()
 	return (*opcode)(unsafe.Pointer(fieldCode))
@@ -752,6 +1048,15 @@ func (e *Encoder) structField(ctx *encodeCompileContext, fieldCode *opcode, valu
 		opStructFieldOmitEmptyStructIndent,
 		opStructFieldStringTagIndent:
 		return valueCode.beforeLastCode()
+	case opStructFieldOmitZero,
+		opStructFieldOmitZeroMethod,
+		opStructFieldOmitZeroIndent,
+		opStructFieldOmitZeroMethodIndent:
+		// the value code of struct has the type of the first field,
+		// so the zero value is checked with the type of the field
+		fieldCode.typ = type2rtype(tag.field.Type)
+		fieldCode.isZero = compileIsZero(tag.field.Type)
+		return valueCode.beforeLastCode()
 	}
 	ctx.decIndex()
 	return code
@@ -814,6 +1119,7 @@ type structFieldPair struct {
 	curField    *opcode
 	isTaggedKey bool
 	linked      bool
+	depth       int // number of embedded structs the field is promoted through
 }
 
 func (e *Encoder) anonymousStructFieldPairMap(typ *rtype, tags structTags, named string, valueCode *opcode) map[string][]structFieldPair {
@@ -859,6 +1165,9 @@ func (e *Encoder) anonymousStructFieldPairMap(typ *rtype, tags structTags, named
 		})
 		if f.next != nil && f.nextField != f.next && f.next.op.codeType() == codeStructField {
 			for k, v := range e.anonymousStructFieldPairMap(typ, tags, named, f.next) {
+				for i := range v {
+					v[i].depth++
+				}
 				anonymousFields[k] = append(anonymousFields[k], v...)
 			}
 		}
@@ -878,43 +1187,35 @@ func (e *Encoder) optimizeConflictAnonymousFields(anonymousFields map[string][]s
 			continue
 		}
 		// conflict anonymous fields
-		taggedPairs := []structFieldPair{}
+		depth := fieldPairs[0].depth
 		for _, fieldPair := range fieldPairs {
+			if fieldPair.depth < depth {
+				depth = fieldPair.depth
+			}
+		}
+		shallowPairs := []structFieldPair{}
+		for _, fieldPair := range fieldPairs {
+			if fieldPair.depth > depth {
+				// the shallowest fields dominate the deeper ones
+				e.removeConflictAnonymousField(fieldPair, removedFields)
+			} else {
+				shallowPairs = append(shallowPairs, fieldPair)
+			}
+		}
+		if len(shallowPairs) == 1 {
+			continue
+		}
+		taggedPairs := []structFieldPair{}
+		for _, fieldPair := range shallowPairs {
 			if fieldPair.isTaggedKey {
 				taggedPairs = append(taggedPairs, fieldPair)
 			} else {
-				if !fieldPair.linked {
-					if fieldPair.prevField == nil {
-						// head operation
-						fieldPair.curField.op = opStructFieldAnonymousHead
-					} else {
-						diff := fieldPair.curField.nextField.displayIdx - fieldPair.curField.displayIdx
-						for i := 0; i < diff; i++ {
-							fieldPair.curField.nextField.decOpcodeIndex()
-						}
-						removedFields[fieldPair.curField] = struct{}{}
-						linkPrevToNextField(fieldPair.curField, removedFields)
-					}
-					fieldPair.linked = true
-				}
+				e.removeConflictAnonymousField(fieldPair, removedFields)
 			}
 		}
 		if len(taggedPairs) > 1 {
 			for _, fieldPair := range taggedPairs {
-				if !fieldPair.linked {
-					if fieldPair.prevField == nil {
-						// head operation
-						fieldPair.curField.op = opStructFieldAnonymousHead
-					} else {
-						diff := fieldPair.curField.nextField.displayIdx - fieldPair.curField.displayIdx
-						removedFields[fieldPair.curField] = struct{}{}
-						for i := 0; i < diff; i++ {
-							fieldPair.curField.nextField.decOpcodeIndex()
-						}
-						linkPrevToNextField(fieldPair.curField, removedFields)
-					}
-					fieldPair.linked = true
-				}
+				e.removeConflictAnonymousField(fieldPair, removedFields)
 			}
 		} else {
 			for _, fieldPair := range taggedPairs {
@@ -924,6 +1225,23 @@ func (e *Encoder) optimizeConflictAnonymousFields(anonymousFields map[string][]s
 	}
 }
 
+func (e *Encoder) removeConflictAnonymousField(fieldPair structFieldPair, removedFields map[*opcode]struct{}) {
+	if fieldPair.linked {
+		return
+	}
+	if fieldPair.prevField == nil {
+		// head operation
+		fieldPair.curField.op = opStructFieldAnonymousHead
+		return
+	}
+	diff := fieldPair.curField.nextField.displayIdx - fieldPair.curField.displayIdx
+	for i := 0; i < diff; i++ {
+		fieldPair.curField.nextField.decOpcodeIndex()
+	}
+	removedFields[fieldPair.curField] = struct{}{}
+	linkPrevToNextField(fieldPair.curField, removedFields)
+}
+
 func (e *Encoder) compileStruct(ctx *encodeCompileContext, isPtr bool) (*opcode, error) {
 	ctx.root = false
 	if code := e.compiledCode(ctx); code != nil {
@@ -964,7 +1282,8 @@ func (e *Encoder) compileStruct(ctx *encodeCompileContext, isPtr bool) (*opcode,
 			// head field of pointer structure at top level
 			// if field type is pointer and implements MarshalJSON or MarshalText,
 			// it need to operation of dereference of pointer.
-			if field.Type.Kind() == reflect.Ptr &&
+			// time.Time, time.Duration and the types with the generated AppendJSON are encoded by their own opcodes instead.
+			if field.Type.Kind() == reflect.Ptr && !e.isFormatType(fieldType.Elem()) && !isAppendMarshalerType(fieldType.Elem()) &&
 				(field.Type.Implements(marshalJSONType) || field.Type.Implements(marshalTextType)) {
 				fieldType = rtype_ptrTo(fieldType)
 			}
@@ -992,13 +1311,18 @@ func (e *Encoder) compileStruct(ctx *encodeCompileContext, isPtr bool) (*opcode,
 				anonymousFields[k] = append(anonymousFields[k], v...)
 			}
 		}
-		if fieldNum == 1 && valueCode.op == opPtr {
+		if fieldNum == 1 && (valueCode.op == opPtr || valueCode.op == opPtrIndent) {
 			// if field number is one and primitive pointer type,
 			// it should encode as **not** pointer .
 			switch valueCode.next.op {
 			case opInt, opInt8, opInt16, opInt32, opInt64,
 				opUint, opUint8, opUint16, opUint32, opUint64,
-				opFloat32, opFloat64, opBool, opString, opBytes:
+				opFloat32, opFloat64, opBool, opString, opBytes,
+				opTime, opDuration, opAppendJSON,
+				opIntIndent, opInt8Indent, opInt16Indent, opInt32Indent, opInt64Indent,
+				opUintIndent, opUint8Indent, opUint16Indent, opUint32Indent, opUint64Indent,
+				opFloat32Indent, opFloat64Indent, opBoolIndent, opStringIndent, opBytesIndent,
+				opTimeIndent, opDurationIndent, opAppendJSONIndent:
 				valueCode = valueCode.next
 				ctx.decOpcodeIndex()
 			}
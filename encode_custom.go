@@ -0,0 +1,82 @@
+package json
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// EncoderFunc appends the JSON encoding of the value that p points to to b
+// and returns the extended buffer.
+// It must append exactly one valid JSON value. The output is compacted like the output of MarshalJSON,
+// but it is not validated.
+type EncoderFunc func(b []byte, p unsafe.Pointer) ([]byte, error)
+
+var globalCustomEncoders sync.Map // map[uintptr]EncoderFunc
+
+// RegisterEncoder registers fn as the encoder of typ for all marshaling.
+// This is useful for the types that cannot have the MarshalJSON method such as the types of other packages.
+// The custom encoder takes precedence over Marshaler and encoding.TextMarshaler,
+// but CustomEncoder registered for a single call takes precedence over it.
+//
+// The compiled codes are cached by type, so RegisterEncoder clears the cache.
+// It should be called before marshaling starts, e.g. in init.
+func RegisterEncoder(typ reflect.Type, fn EncoderFunc) {
+	typeptr := uintptr(unsafe.Pointer(type2rtype(typ)))
+	if fn == nil {
+		globalCustomEncoders.Delete(typeptr)
+	} else {
+		globalCustomEncoders.Store(typeptr, fn)
+	}
+	cachedOpcode.clear()
+}
+
+func (e *Encoder) setCustomEncoder(typ reflect.Type, fn EncoderFunc) {
+	if e.customEncoders == nil {
+		e.customEncoders = map[uintptr]EncoderFunc{}
+	}
+	e.customEncoders[uintptr(unsafe.Pointer(type2rtype(typ)))] = fn
+	e.customEncodersKey = ""
+}
+
+// customKey returns cacheKey.custom for the encoders registered by CustomEncoder.
+func (e *Encoder) customKey() string {
+	fns := make(map[uintptr]uintptr, len(e.customEncoders))
+	for typeptr, fn := range e.customEncoders {
+		fns[typeptr] = *(*uintptr)(unsafe.Pointer(&fn))
+	}
+	return newCustomKey(fns)
+}
+
+func (e *Encoder) customEncoder(typ *rtype) EncoderFunc {
+	typeptr := uintptr(unsafe.Pointer(typ))
+	if fn, exists := e.customEncoders[typeptr]; exists {
+		return fn
+	}
+	if fn, exists := globalCustomEncoders.Load(typeptr); exists {
+		return fn.(EncoderFunc)
+	}
+	return nil
+}
+
+func (e *Encoder) compileCustom(ctx *encodeCompileContext, fn EncoderFunc, direct bool) (*opcode, error) {
+	code := newOpCode(ctx, opCustom)
+	code.customEncoder = fn
+	code.root = direct
+	ctx.incIndex()
+	return code, nil
+}
+
+// isDirectIface reports whether the value of typ is stored directly in the data word of interface{}
+// instead of the pointer to the value.
+func isDirectIface(typ reflect.Type) bool {
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return true
+	case reflect.Struct:
+		return typ.NumField() == 1 && isDirectIface(typ.Field(0).Type)
+	case reflect.Array:
+		return typ.Len() == 1 && isDirectIface(typ.Elem())
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package json
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// DumpEncodeProgram returns the opcode sequence compiled for the type of v with opts, one opcode per line.
+// Each line has the opcode index, the opcode name indented by the nesting level and the slots of the opcode,
+// such as the index of the pointer slot ( idx ) and the JSON key of the struct field.
+// It is intended for debugging the encoder together with EncodeTrace.
+func DumpEncodeProgram(v interface{}, opts ...EncodeOption) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	enc := NewEncoder(nil)
+	defer enc.release()
+	for _, opt := range opts {
+		if err := opt(enc); err != nil {
+			return "", err
+		}
+	}
+	typ := (*interfaceHeader)(unsafe.Pointer(&v)).typ
+	codeSet, err := enc.compileToGetOpcodeSet(uintptr(unsafe.Pointer(typ)), typ)
+	if err != nil {
+		return "", err
+	}
+	return codeSet.code.dump(), nil
+}
+
+// traceOpcode writes the opcode about to be executed and the pointer stored in its slot for EncodeTrace.
+func (e *Encoder) traceOpcode(ctxptr uintptr, code *opcode) {
+	key := ""
+	if code.op.codeType() == codeStructField {
+		key = fmt.Sprintf("[key:%s]", code.displayKey)
+	}
+	fmt.Fprintf(
+		e.trace,
+		"[%d]%s%s ([idx:%d]%s[ptr:%#x])\n",
+		code.displayIdx,
+		strings.Repeat("-", code.indent),
+		code.op,
+		code.idx/uintptrSize,
+		key,
+		load(ctxptr, code.idx),
+	)
+}
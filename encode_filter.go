@@ -0,0 +1,181 @@
+package json
+
+import (
+	"fmt"
+	"strings"
+)
+
+// fieldFilter is the tree of field paths selected by FilterFields.
+// The value of a key is the filter for the fields of the key's value,
+// and nil selects all of them.
+type fieldFilter map[string]fieldFilter
+
+func newFieldFilter(paths []string) (fieldFilter, error) {
+	filter := fieldFilter{}
+	for _, path := range paths {
+		keys := strings.Split(path, ".")
+		for _, key := range keys {
+			if key == "" {
+				return nil, fmt.Errorf("json: invalid field path %q", path)
+			}
+		}
+		f := filter
+		for i, key := range keys {
+			child, exists := f[key]
+			if exists && child == nil {
+				// all fields of key are already selected
+				break
+			}
+			if i == len(keys)-1 {
+				f[key] = nil
+				break
+			}
+			if !exists {
+				child = fieldFilter{}
+				f[key] = child
+			}
+			f = child
+		}
+	}
+	return filter, nil
+}
+
+// fieldFilterLevel is the filter state of the struct being encoded.
+type fieldFilterLevel struct {
+	filter fieldFilter // filter for the fields of the struct
+	value  fieldFilter // filter for the value of the current field
+	skip   *opcode     // code to run next if the struct is encoded as null
+}
+
+// fieldFilterMark removes a struct head field that is not selected.
+// The head operation encodes '{' together with the first field,
+// so the field is removed from buf after it has been encoded.
+type fieldFilterMark struct {
+	pos   int     // length of buf before the head operation
+	head  bool    // whether the head operation encodes '{'
+	until *opcode // next field of the head
+	skip  *opcode // code to run next if the head does not encode the field
+}
+
+type fieldFilterOpKind uint8
+
+const (
+	fieldFilterOpNone fieldFilterOpKind = iota
+	fieldFilterOpHead
+	fieldFilterOpAnonymousHead
+	fieldFilterOpField
+	fieldFilterOpEnd
+)
+
+// fieldFilterOpKinds classifies struct operations by opType,
+// so that filtering does not depend on the specialized operations.
+var fieldFilterOpKinds = func() []fieldFilterOpKind {
+	kinds := []fieldFilterOpKind{}
+	for op := opType(0); op.String() != ""; op++ {
+		kind := fieldFilterOpNone
+		switch {
+		case op.codeType() != codeStructField:
+		case op == opStructEnd || op == opStructEndIndent:
+			kind = fieldFilterOpEnd
+		case op == opStructAnonymousEnd || op == opStructAnonymousEndIndent:
+		case op == opStructFieldRecursive || op == opStructFieldRecursiveIndent:
+			// the value of a recursive field, which is not a field itself
+		case op.headToAnonymousHead() != op:
+			kind = fieldFilterOpHead
+		case op.headToPtrHead() != op || op.ptrHeadToHead() != op:
+			kind = fieldFilterOpAnonymousHead
+		default:
+			kind = fieldFilterOpField
+		}
+		kinds = append(kinds, kind)
+	}
+	return kinds
+}()
+
+func (e *Encoder) initFieldFilter() {
+	e.filterLevels = append(e.filterLevels[:0], fieldFilterLevel{value: e.fieldFilter})
+	e.filterMarks = e.filterMarks[:0]
+}
+
+// filterField applies the field filter before code runs.
+// It returns the code to run, which follows code if code encodes a field that is not selected.
+func (e *Encoder) filterField(code *opcode) *opcode {
+	for {
+		e.resolveFieldFilter(code)
+		switch fieldFilterOpKinds[code.op] {
+		case fieldFilterOpHead:
+			level := fieldFilterLevel{filter: e.filterLevels[len(e.filterLevels)-1].value}
+			if code.end != nil {
+				level.skip = code.end.next
+			}
+			e.filterLevels = append(e.filterLevels, level)
+			if !code.anonymousKey && !e.selectField(code) {
+				e.markField(code, true)
+			}
+		case fieldFilterOpAnonymousHead:
+			if !code.anonymousKey && !e.selectField(code) {
+				e.markField(code, false)
+			}
+		case fieldFilterOpField:
+			if code.anonymousKey || e.selectField(code) || code.nextField == nil {
+				return code
+			}
+			code = code.nextField
+			continue
+		case fieldFilterOpEnd:
+			if len(e.filterLevels) > 1 {
+				e.filterLevels = e.filterLevels[:len(e.filterLevels)-1]
+			}
+		}
+		return code
+	}
+}
+
+// resolveFieldFilter removes the encoded head fields that are not selected
+// and leaves the structs encoded as null.
+func (e *Encoder) resolveFieldFilter(code *opcode) {
+	for len(e.filterMarks) > 0 {
+		mark := e.filterMarks[len(e.filterMarks)-1]
+		if code == mark.until {
+			e.buf = e.buf[:mark.pos]
+			if mark.head {
+				e.encodeByte('{')
+				if e.enabledIndent {
+					e.encodeByte('\n')
+				}
+			}
+		} else if code != mark.skip {
+			break
+		}
+		e.filterMarks = e.filterMarks[:len(e.filterMarks)-1]
+	}
+	for len(e.filterLevels) > 1 && e.filterLevels[len(e.filterLevels)-1].skip == code {
+		e.filterLevels = e.filterLevels[:len(e.filterLevels)-1]
+	}
+}
+
+func (e *Encoder) selectField(code *opcode) bool {
+	level := &e.filterLevels[len(e.filterLevels)-1]
+	if level.filter == nil {
+		level.value = nil
+		return true
+	}
+	value, exists := level.filter[code.displayKey]
+	level.value = value
+	return exists
+}
+
+func (e *Encoder) markField(code *opcode, head bool) {
+	if code.nextField == nil {
+		return
+	}
+	mark := fieldFilterMark{
+		pos:   len(e.buf),
+		head:  head,
+		until: code.nextField,
+	}
+	if code.end != nil {
+		mark.skip = code.end.next
+	}
+	e.filterMarks = append(e.filterMarks, mark)
+}
@@ -37,6 +37,9 @@ type opcode struct {
 	nextField *opcode       // next struct field
 	next      *opcode       // next opcode
 	jmp       *compiledCode // for recursive call
+
+	customEncoder EncoderFunc          // encoder registered for the type
+	isZero        func(p uintptr) bool // zero check of the field with omitzero option
 }
 
 func newOpCode(ctx *encodeCompileContext, op opType) *opcode {
@@ -107,6 +110,8 @@ func (c *opcode) copy(codeMap map[uintptr]*opcode) *opcode {
 	copied.nextField = c.nextField.copy(codeMap)
 	copied.next = c.next.copy(codeMap)
 	copied.jmp = c.jmp
+	copied.customEncoder = c.customEncoder
+	copied.isZero = c.isZero
 	return copied
 }
 
@@ -145,6 +150,21 @@ func (c *opcode) totalLength() int {
 	return idx + 2 // opEnd + 1
 }
 
+// codeCount returns the number of the codes, which are dumped one per line.
+func (c *opcode) codeCount() int {
+	var n int
+	for code := c; code.op != opEnd; {
+		n++
+		switch code.op.codeType() {
+		case codeArrayElem, codeSliceElem, codeMapKey:
+			code = code.end
+		default:
+			code = code.next
+		}
+	}
+	return n
+}
+
 func (c *opcode) decOpcodeIndex() {
 	for code := c; code.op != opEnd; {
 		code.displayIdx--
@@ -24,854 +24,912 @@ const (
 	opInterface                                             opType = 1
 	opInterfaceEnd                                          opType = 2
 	opPtr                                                   opType = 3
-	opSliceHead                                             opType = 4
-	opRootSliceHead                                         opType = 5
-	opSliceElem                                             opType = 6
-	opRootSliceElem                                         opType = 7
-	opSliceEnd                                              opType = 8
-	opArrayHead                                             opType = 9
-	opArrayElem                                             opType = 10
-	opArrayEnd                                              opType = 11
-	opMapHead                                               opType = 12
-	opMapHeadLoad                                           opType = 13
-	opMapKey                                                opType = 14
-	opMapValue                                              opType = 15
-	opMapEnd                                                opType = 16
-	opStructFieldHead                                       opType = 17
-	opStructFieldHeadOmitEmpty                              opType = 18
-	opStructFieldHeadStringTag                              opType = 19
-	opStructFieldAnonymousHead                              opType = 20
-	opStructFieldAnonymousHeadOmitEmpty                     opType = 21
-	opStructFieldPtrAnonymousHeadOmitEmpty                  opType = 22
-	opStructFieldAnonymousHeadStringTag                     opType = 23
-	opStructFieldPtrAnonymousHeadStringTag                  opType = 24
-	opStructFieldPtrHead                                    opType = 25
-	opStructFieldPtrHeadOmitEmpty                           opType = 26
-	opStructFieldPtrHeadStringTag                           opType = 27
-	opStructFieldPtrAnonymousHead                           opType = 28
-	opStructField                                           opType = 29
-	opStructFieldOmitEmpty                                  opType = 30
-	opStructFieldStringTag                                  opType = 31
-	opStructFieldRecursiveEnd                               opType = 32
-	opStructEnd                                             opType = 33
-	opStructAnonymousEnd                                    opType = 34
-	opInt                                                   opType = 35
-	opInt8                                                  opType = 36
-	opInt16                                                 opType = 37
-	opInt32                                                 opType = 38
-	opInt64                                                 opType = 39
-	opUint                                                  opType = 40
-	opUint8                                                 opType = 41
-	opUint16                                                opType = 42
-	opUint32                                                opType = 43
-	opUint64                                                opType = 44
-	opFloat32                                               opType = 45
-	opFloat64                                               opType = 46
-	opBool                                                  opType = 47
-	opString                                                opType = 48
-	opBytes                                                 opType = 49
-	opArray                                                 opType = 50
-	opMap                                                   opType = 51
-	opMapLoad                                               opType = 52
-	opSlice                                                 opType = 53
-	opStruct                                                opType = 54
-	opMarshalJSON                                           opType = 55
-	opMarshalText                                           opType = 56
-	opRecursive                                             opType = 57
-	opStructFieldHeadInt                                    opType = 58
-	opStructFieldHeadInt8                                   opType = 59
-	opStructFieldHeadInt16                                  opType = 60
-	opStructFieldHeadInt32                                  opType = 61
-	opStructFieldHeadInt64                                  opType = 62
-	opStructFieldHeadUint                                   opType = 63
-	opStructFieldHeadUint8                                  opType = 64
-	opStructFieldHeadUint16                                 opType = 65
-	opStructFieldHeadUint32                                 opType = 66
-	opStructFieldHeadUint64                                 opType = 67
-	opStructFieldHeadFloat32                                opType = 68
-	opStructFieldHeadFloat64                                opType = 69
-	opStructFieldHeadBool                                   opType = 70
-	opStructFieldHeadString                                 opType = 71
-	opStructFieldHeadBytes                                  opType = 72
-	opStructFieldHeadArray                                  opType = 73
-	opStructFieldHeadMap                                    opType = 74
-	opStructFieldHeadMapLoad                                opType = 75
-	opStructFieldHeadSlice                                  opType = 76
-	opStructFieldHeadStruct                                 opType = 77
-	opStructFieldHeadMarshalJSON                            opType = 78
-	opStructFieldHeadMarshalText                            opType = 79
-	opStructFieldHeadRecursive                              opType = 80
-	opStructFieldHeadOmitEmptyInt                           opType = 81
-	opStructFieldHeadOmitEmptyInt8                          opType = 82
-	opStructFieldHeadOmitEmptyInt16                         opType = 83
-	opStructFieldHeadOmitEmptyInt32                         opType = 84
-	opStructFieldHeadOmitEmptyInt64                         opType = 85
-	opStructFieldHeadOmitEmptyUint                          opType = 86
-	opStructFieldHeadOmitEmptyUint8                         opType = 87
-	opStructFieldHeadOmitEmptyUint16                        opType = 88
-	opStructFieldHeadOmitEmptyUint32                        opType = 89
-	opStructFieldHeadOmitEmptyUint64                        opType = 90
-	opStructFieldHeadOmitEmptyFloat32                       opType = 91
-	opStructFieldHeadOmitEmptyFloat64                       opType = 92
-	opStructFieldHeadOmitEmptyBool                          opType = 93
-	opStructFieldHeadOmitEmptyString                        opType = 94
-	opStructFieldHeadOmitEmptyBytes                         opType = 95
-	opStructFieldHeadOmitEmptyArray                         opType = 96
-	opStructFieldHeadOmitEmptyMap                           opType = 97
-	opStructFieldHeadOmitEmptyMapLoad                       opType = 98
-	opStructFieldHeadOmitEmptySlice                         opType = 99
-	opStructFieldHeadOmitEmptyStruct                        opType = 100
-	opStructFieldHeadOmitEmptyMarshalJSON                   opType = 101
-	opStructFieldHeadOmitEmptyMarshalText                   opType = 102
-	opStructFieldHeadOmitEmptyRecursive                     opType = 103
-	opStructFieldHeadStringTagInt                           opType = 104
-	opStructFieldHeadStringTagInt8                          opType = 105
-	opStructFieldHeadStringTagInt16                         opType = 106
-	opStructFieldHeadStringTagInt32                         opType = 107
-	opStructFieldHeadStringTagInt64                         opType = 108
-	opStructFieldHeadStringTagUint                          opType = 109
-	opStructFieldHeadStringTagUint8                         opType = 110
-	opStructFieldHeadStringTagUint16                        opType = 111
-	opStructFieldHeadStringTagUint32                        opType = 112
-	opStructFieldHeadStringTagUint64                        opType = 113
-	opStructFieldHeadStringTagFloat32                       opType = 114
-	opStructFieldHeadStringTagFloat64                       opType = 115
-	opStructFieldHeadStringTagBool                          opType = 116
-	opStructFieldHeadStringTagString                        opType = 117
-	opStructFieldHeadStringTagBytes                         opType = 118
-	opStructFieldHeadStringTagArray                         opType = 119
-	opStructFieldHeadStringTagMap                           opType = 120
-	opStructFieldHeadStringTagMapLoad                       opType = 121
-	opStructFieldHeadStringTagSlice                         opType = 122
-	opStructFieldHeadStringTagStruct                        opType = 123
-	opStructFieldHeadStringTagMarshalJSON                   opType = 124
-	opStructFieldHeadStringTagMarshalText                   opType = 125
-	opStructFieldHeadStringTagRecursive                     opType = 126
-	opStructFieldAnonymousHeadInt                           opType = 127
-	opStructFieldAnonymousHeadInt8                          opType = 128
-	opStructFieldAnonymousHeadInt16                         opType = 129
-	opStructFieldAnonymousHeadInt32                         opType = 130
-	opStructFieldAnonymousHeadInt64                         opType = 131
-	opStructFieldAnonymousHeadUint                          opType = 132
-	opStructFieldAnonymousHeadUint8                         opType = 133
-	opStructFieldAnonymousHeadUint16                        opType = 134
-	opStructFieldAnonymousHeadUint32                        opType = 135
-	opStructFieldAnonymousHeadUint64                        opType = 136
-	opStructFieldAnonymousHeadFloat32                       opType = 137
-	opStructFieldAnonymousHeadFloat64                       opType = 138
-	opStructFieldAnonymousHeadBool                          opType = 139
-	opStructFieldAnonymousHeadString                        opType = 140
-	opStructFieldAnonymousHeadBytes                         opType = 141
-	opStructFieldAnonymousHeadArray                         opType = 142
-	opStructFieldAnonymousHeadMap                           opType = 143
-	opStructFieldAnonymousHeadMapLoad                       opType = 144
-	opStructFieldAnonymousHeadSlice                         opType = 145
-	opStructFieldAnonymousHeadStruct                        opType = 146
-	opStructFieldAnonymousHeadMarshalJSON                   opType = 147
-	opStructFieldAnonymousHeadMarshalText                   opType = 148
-	opStructFieldAnonymousHeadRecursive                     opType = 149
-	opStructFieldAnonymousHeadOmitEmptyInt                  opType = 150
-	opStructFieldAnonymousHeadOmitEmptyInt8                 opType = 151
-	opStructFieldAnonymousHeadOmitEmptyInt16                opType = 152
-	opStructFieldAnonymousHeadOmitEmptyInt32                opType = 153
-	opStructFieldAnonymousHeadOmitEmptyInt64                opType = 154
-	opStructFieldAnonymousHeadOmitEmptyUint                 opType = 155
-	opStructFieldAnonymousHeadOmitEmptyUint8                opType = 156
-	opStructFieldAnonymousHeadOmitEmptyUint16               opType = 157
-	opStructFieldAnonymousHeadOmitEmptyUint32               opType = 158
-	opStructFieldAnonymousHeadOmitEmptyUint64               opType = 159
-	opStructFieldAnonymousHeadOmitEmptyFloat32              opType = 160
-	opStructFieldAnonymousHeadOmitEmptyFloat64              opType = 161
-	opStructFieldAnonymousHeadOmitEmptyBool                 opType = 162
-	opStructFieldAnonymousHeadOmitEmptyString               opType = 163
-	opStructFieldAnonymousHeadOmitEmptyBytes                opType = 164
-	opStructFieldAnonymousHeadOmitEmptyArray                opType = 165
-	opStructFieldAnonymousHeadOmitEmptyMap                  opType = 166
-	opStructFieldAnonymousHeadOmitEmptyMapLoad              opType = 167
-	opStructFieldAnonymousHeadOmitEmptySlice                opType = 168
-	opStructFieldAnonymousHeadOmitEmptyStruct               opType = 169
-	opStructFieldAnonymousHeadOmitEmptyMarshalJSON          opType = 170
-	opStructFieldAnonymousHeadOmitEmptyMarshalText          opType = 171
-	opStructFieldAnonymousHeadOmitEmptyRecursive            opType = 172
-	opStructFieldAnonymousHeadStringTagInt                  opType = 173
-	opStructFieldAnonymousHeadStringTagInt8                 opType = 174
-	opStructFieldAnonymousHeadStringTagInt16                opType = 175
-	opStructFieldAnonymousHeadStringTagInt32                opType = 176
-	opStructFieldAnonymousHeadStringTagInt64                opType = 177
-	opStructFieldAnonymousHeadStringTagUint                 opType = 178
-	opStructFieldAnonymousHeadStringTagUint8                opType = 179
-	opStructFieldAnonymousHeadStringTagUint16               opType = 180
-	opStructFieldAnonymousHeadStringTagUint32               opType = 181
-	opStructFieldAnonymousHeadStringTagUint64               opType = 182
-	opStructFieldAnonymousHeadStringTagFloat32              opType = 183
-	opStructFieldAnonymousHeadStringTagFloat64              opType = 184
-	opStructFieldAnonymousHeadStringTagBool                 opType = 185
-	opStructFieldAnonymousHeadStringTagString               opType = 186
-	opStructFieldAnonymousHeadStringTagBytes                opType = 187
-	opStructFieldAnonymousHeadStringTagArray                opType = 188
-	opStructFieldAnonymousHeadStringTagMap                  opType = 189
-	opStructFieldAnonymousHeadStringTagMapLoad              opType = 190
-	opStructFieldAnonymousHeadStringTagSlice                opType = 191
-	opStructFieldAnonymousHeadStringTagStruct               opType = 192
-	opStructFieldAnonymousHeadStringTagMarshalJSON          opType = 193
-	opStructFieldAnonymousHeadStringTagMarshalText          opType = 194
-	opStructFieldAnonymousHeadStringTagRecursive            opType = 195
-	opStructFieldPtrHeadInt                                 opType = 196
-	opStructFieldPtrHeadInt8                                opType = 197
-	opStructFieldPtrHeadInt16                               opType = 198
-	opStructFieldPtrHeadInt32                               opType = 199
-	opStructFieldPtrHeadInt64                               opType = 200
-	opStructFieldPtrHeadUint                                opType = 201
-	opStructFieldPtrHeadUint8                               opType = 202
-	opStructFieldPtrHeadUint16                              opType = 203
-	opStructFieldPtrHeadUint32                              opType = 204
-	opStructFieldPtrHeadUint64                              opType = 205
-	opStructFieldPtrHeadFloat32                             opType = 206
-	opStructFieldPtrHeadFloat64                             opType = 207
-	opStructFieldPtrHeadBool                                opType = 208
-	opStructFieldPtrHeadString                              opType = 209
-	opStructFieldPtrHeadBytes                               opType = 210
-	opStructFieldPtrHeadArray                               opType = 211
-	opStructFieldPtrHeadMap                                 opType = 212
-	opStructFieldPtrHeadMapLoad                             opType = 213
-	opStructFieldPtrHeadSlice                               opType = 214
-	opStructFieldPtrHeadStruct                              opType = 215
-	opStructFieldPtrHeadMarshalJSON                         opType = 216
-	opStructFieldPtrHeadMarshalText                         opType = 217
-	opStructFieldPtrHeadRecursive                           opType = 218
-	opStructFieldPtrHeadOmitEmptyInt                        opType = 219
-	opStructFieldPtrHeadOmitEmptyInt8                       opType = 220
-	opStructFieldPtrHeadOmitEmptyInt16                      opType = 221
-	opStructFieldPtrHeadOmitEmptyInt32                      opType = 222
-	opStructFieldPtrHeadOmitEmptyInt64                      opType = 223
-	opStructFieldPtrHeadOmitEmptyUint                       opType = 224
-	opStructFieldPtrHeadOmitEmptyUint8                      opType = 225
-	opStructFieldPtrHeadOmitEmptyUint16                     opType = 226
-	opStructFieldPtrHeadOmitEmptyUint32                     opType = 227
-	opStructFieldPtrHeadOmitEmptyUint64                     opType = 228
-	opStructFieldPtrHeadOmitEmptyFloat32                    opType = 229
-	opStructFieldPtrHeadOmitEmptyFloat64                    opType = 230
-	opStructFieldPtrHeadOmitEmptyBool                       opType = 231
-	opStructFieldPtrHeadOmitEmptyString                     opType = 232
-	opStructFieldPtrHeadOmitEmptyBytes                      opType = 233
-	opStructFieldPtrHeadOmitEmptyArray                      opType = 234
-	opStructFieldPtrHeadOmitEmptyMap                        opType = 235
-	opStructFieldPtrHeadOmitEmptyMapLoad                    opType = 236
-	opStructFieldPtrHeadOmitEmptySlice                      opType = 237
-	opStructFieldPtrHeadOmitEmptyStruct                     opType = 238
-	opStructFieldPtrHeadOmitEmptyMarshalJSON                opType = 239
-	opStructFieldPtrHeadOmitEmptyMarshalText                opType = 240
-	opStructFieldPtrHeadOmitEmptyRecursive                  opType = 241
-	opStructFieldPtrHeadStringTagInt                        opType = 242
-	opStructFieldPtrHeadStringTagInt8                       opType = 243
-	opStructFieldPtrHeadStringTagInt16                      opType = 244
-	opStructFieldPtrHeadStringTagInt32                      opType = 245
-	opStructFieldPtrHeadStringTagInt64                      opType = 246
-	opStructFieldPtrHeadStringTagUint                       opType = 247
-	opStructFieldPtrHeadStringTagUint8                      opType = 248
-	opStructFieldPtrHeadStringTagUint16                     opType = 249
-	opStructFieldPtrHeadStringTagUint32                     opType = 250
-	opStructFieldPtrHeadStringTagUint64                     opType = 251
-	opStructFieldPtrHeadStringTagFloat32                    opType = 252
-	opStructFieldPtrHeadStringTagFloat64                    opType = 253
-	opStructFieldPtrHeadStringTagBool                       opType = 254
-	opStructFieldPtrHeadStringTagString                     opType = 255
-	opStructFieldPtrHeadStringTagBytes                      opType = 256
-	opStructFieldPtrHeadStringTagArray                      opType = 257
-	opStructFieldPtrHeadStringTagMap                        opType = 258
-	opStructFieldPtrHeadStringTagMapLoad                    opType = 259
-	opStructFieldPtrHeadStringTagSlice                      opType = 260
-	opStructFieldPtrHeadStringTagStruct                     opType = 261
-	opStructFieldPtrHeadStringTagMarshalJSON                opType = 262
-	opStructFieldPtrHeadStringTagMarshalText                opType = 263
-	opStructFieldPtrHeadStringTagRecursive                  opType = 264
-	opStructFieldPtrAnonymousHeadInt                        opType = 265
-	opStructFieldPtrAnonymousHeadInt8                       opType = 266
-	opStructFieldPtrAnonymousHeadInt16                      opType = 267
-	opStructFieldPtrAnonymousHeadInt32                      opType = 268
-	opStructFieldPtrAnonymousHeadInt64                      opType = 269
-	opStructFieldPtrAnonymousHeadUint                       opType = 270
-	opStructFieldPtrAnonymousHeadUint8                      opType = 271
-	opStructFieldPtrAnonymousHeadUint16                     opType = 272
-	opStructFieldPtrAnonymousHeadUint32                     opType = 273
-	opStructFieldPtrAnonymousHeadUint64                     opType = 274
-	opStructFieldPtrAnonymousHeadFloat32                    opType = 275
-	opStructFieldPtrAnonymousHeadFloat64                    opType = 276
-	opStructFieldPtrAnonymousHeadBool                       opType = 277
-	opStructFieldPtrAnonymousHeadString                     opType = 278
-	opStructFieldPtrAnonymousHeadBytes                      opType = 279
-	opStructFieldPtrAnonymousHeadArray                      opType = 280
-	opStructFieldPtrAnonymousHeadMap                        opType = 281
-	opStructFieldPtrAnonymousHeadMapLoad                    opType = 282
-	opStructFieldPtrAnonymousHeadSlice                      opType = 283
-	opStructFieldPtrAnonymousHeadStruct                     opType = 284
-	opStructFieldPtrAnonymousHeadMarshalJSON                opType = 285
-	opStructFieldPtrAnonymousHeadMarshalText                opType = 286
-	opStructFieldPtrAnonymousHeadRecursive                  opType = 287
-	opStructFieldPtrAnonymousHeadOmitEmptyInt               opType = 288
-	opStructFieldPtrAnonymousHeadOmitEmptyInt8              opType = 289
-	opStructFieldPtrAnonymousHeadOmitEmptyInt16             opType = 290
-	opStructFieldPtrAnonymousHeadOmitEmptyInt32             opType = 291
-	opStructFieldPtrAnonymousHeadOmitEmptyInt64             opType = 292
-	opStructFieldPtrAnonymousHeadOmitEmptyUint              opType = 293
-	opStructFieldPtrAnonymousHeadOmitEmptyUint8             opType = 294
-	opStructFieldPtrAnonymousHeadOmitEmptyUint16            opType = 295
-	opStructFieldPtrAnonymousHeadOmitEmptyUint32            opType = 296
-	opStructFieldPtrAnonymousHeadOmitEmptyUint64            opType = 297
-	opStructFieldPtrAnonymousHeadOmitEmptyFloat32           opType = 298
-	opStructFieldPtrAnonymousHeadOmitEmptyFloat64           opType = 299
-	opStructFieldPtrAnonymousHeadOmitEmptyBool              opType = 300
-	opStructFieldPtrAnonymousHeadOmitEmptyString            opType = 301
-	opStructFieldPtrAnonymousHeadOmitEmptyBytes             opType = 302
-	opStructFieldPtrAnonymousHeadOmitEmptyArray             opType = 303
-	opStructFieldPtrAnonymousHeadOmitEmptyMap               opType = 304
-	opStructFieldPtrAnonymousHeadOmitEmptyMapLoad           opType = 305
-	opStructFieldPtrAnonymousHeadOmitEmptySlice             opType = 306
-	opStructFieldPtrAnonymousHeadOmitEmptyStruct            opType = 307
-	opStructFieldPtrAnonymousHeadOmitEmptyMarshalJSON       opType = 308
-	opStructFieldPtrAnonymousHeadOmitEmptyMarshalText       opType = 309
-	opStructFieldPtrAnonymousHeadOmitEmptyRecursive         opType = 310
-	opStructFieldPtrAnonymousHeadStringTagInt               opType = 311
-	opStructFieldPtrAnonymousHeadStringTagInt8              opType = 312
-	opStructFieldPtrAnonymousHeadStringTagInt16             opType = 313
-	opStructFieldPtrAnonymousHeadStringTagInt32             opType = 314
-	opStructFieldPtrAnonymousHeadStringTagInt64             opType = 315
-	opStructFieldPtrAnonymousHeadStringTagUint              opType = 316
-	opStructFieldPtrAnonymousHeadStringTagUint8             opType = 317
-	opStructFieldPtrAnonymousHeadStringTagUint16            opType = 318
-	opStructFieldPtrAnonymousHeadStringTagUint32            opType = 319
-	opStructFieldPtrAnonymousHeadStringTagUint64            opType = 320
-	opStructFieldPtrAnonymousHeadStringTagFloat32           opType = 321
-	opStructFieldPtrAnonymousHeadStringTagFloat64           opType = 322
-	opStructFieldPtrAnonymousHeadStringTagBool              opType = 323
-	opStructFieldPtrAnonymousHeadStringTagString            opType = 324
-	opStructFieldPtrAnonymousHeadStringTagBytes             opType = 325
-	opStructFieldPtrAnonymousHeadStringTagArray             opType = 326
-	opStructFieldPtrAnonymousHeadStringTagMap               opType = 327
-	opStructFieldPtrAnonymousHeadStringTagMapLoad           opType = 328
-	opStructFieldPtrAnonymousHeadStringTagSlice             opType = 329
-	opStructFieldPtrAnonymousHeadStringTagStruct            opType = 330
-	opStructFieldPtrAnonymousHeadStringTagMarshalJSON       opType = 331
-	opStructFieldPtrAnonymousHeadStringTagMarshalText       opType = 332
-	opStructFieldPtrAnonymousHeadStringTagRecursive         opType = 333
-	opStructFieldInt                                        opType = 334
-	opStructFieldInt8                                       opType = 335
-	opStructFieldInt16                                      opType = 336
-	opStructFieldInt32                                      opType = 337
-	opStructFieldInt64                                      opType = 338
-	opStructFieldUint                                       opType = 339
-	opStructFieldUint8                                      opType = 340
-	opStructFieldUint16                                     opType = 341
-	opStructFieldUint32                                     opType = 342
-	opStructFieldUint64                                     opType = 343
-	opStructFieldFloat32                                    opType = 344
-	opStructFieldFloat64                                    opType = 345
-	opStructFieldBool                                       opType = 346
-	opStructFieldString                                     opType = 347
-	opStructFieldBytes                                      opType = 348
-	opStructFieldArray                                      opType = 349
-	opStructFieldMap                                        opType = 350
-	opStructFieldMapLoad                                    opType = 351
-	opStructFieldSlice                                      opType = 352
-	opStructFieldStruct                                     opType = 353
-	opStructFieldMarshalJSON                                opType = 354
-	opStructFieldMarshalText                                opType = 355
-	opStructFieldRecursive                                  opType = 356
-	opStructFieldPtrInt                                     opType = 357
-	opStructFieldPtrInt8                                    opType = 358
-	opStructFieldPtrInt16                                   opType = 359
-	opStructFieldPtrInt32                                   opType = 360
-	opStructFieldPtrInt64                                   opType = 361
-	opStructFieldPtrUint                                    opType = 362
-	opStructFieldPtrUint8                                   opType = 363
-	opStructFieldPtrUint16                                  opType = 364
-	opStructFieldPtrUint32                                  opType = 365
-	opStructFieldPtrUint64                                  opType = 366
-	opStructFieldPtrFloat32                                 opType = 367
-	opStructFieldPtrFloat64                                 opType = 368
-	opStructFieldPtrBool                                    opType = 369
-	opStructFieldPtrString                                  opType = 370
-	opStructFieldPtrBytes                                   opType = 371
-	opStructFieldPtrArray                                   opType = 372
-	opStructFieldPtrMap                                     opType = 373
-	opStructFieldPtrMapLoad                                 opType = 374
-	opStructFieldPtrSlice                                   opType = 375
-	opStructFieldPtrStruct                                  opType = 376
-	opStructFieldPtrMarshalJSON                             opType = 377
-	opStructFieldPtrMarshalText                             opType = 378
-	opStructFieldPtrRecursive                               opType = 379
-	opStructFieldOmitEmptyInt                               opType = 380
-	opStructFieldOmitEmptyInt8                              opType = 381
-	opStructFieldOmitEmptyInt16                             opType = 382
-	opStructFieldOmitEmptyInt32                             opType = 383
-	opStructFieldOmitEmptyInt64                             opType = 384
-	opStructFieldOmitEmptyUint                              opType = 385
-	opStructFieldOmitEmptyUint8                             opType = 386
-	opStructFieldOmitEmptyUint16                            opType = 387
-	opStructFieldOmitEmptyUint32                            opType = 388
-	opStructFieldOmitEmptyUint64                            opType = 389
-	opStructFieldOmitEmptyFloat32                           opType = 390
-	opStructFieldOmitEmptyFloat64                           opType = 391
-	opStructFieldOmitEmptyBool                              opType = 392
-	opStructFieldOmitEmptyString                            opType = 393
-	opStructFieldOmitEmptyBytes                             opType = 394
-	opStructFieldOmitEmptyArray                             opType = 395
-	opStructFieldOmitEmptyMap                               opType = 396
-	opStructFieldOmitEmptyMapLoad                           opType = 397
-	opStructFieldOmitEmptySlice                             opType = 398
-	opStructFieldOmitEmptyStruct                            opType = 399
-	opStructFieldOmitEmptyMarshalJSON                       opType = 400
-	opStructFieldOmitEmptyMarshalText                       opType = 401
-	opStructFieldOmitEmptyRecursive                         opType = 402
-	opStructFieldStringTagInt                               opType = 403
-	opStructFieldStringTagInt8                              opType = 404
-	opStructFieldStringTagInt16                             opType = 405
-	opStructFieldStringTagInt32                             opType = 406
-	opStructFieldStringTagInt64                             opType = 407
-	opStructFieldStringTagUint                              opType = 408
-	opStructFieldStringTagUint8                             opType = 409
-	opStructFieldStringTagUint16                            opType = 410
-	opStructFieldStringTagUint32                            opType = 411
-	opStructFieldStringTagUint64                            opType = 412
-	opStructFieldStringTagFloat32                           opType = 413
-	opStructFieldStringTagFloat64                           opType = 414
-	opStructFieldStringTagBool                              opType = 415
-	opStructFieldStringTagString                            opType = 416
-	opStructFieldStringTagBytes                             opType = 417
-	opStructFieldStringTagArray                             opType = 418
-	opStructFieldStringTagMap                               opType = 419
-	opStructFieldStringTagMapLoad                           opType = 420
-	opStructFieldStringTagSlice                             opType = 421
-	opStructFieldStringTagStruct                            opType = 422
-	opStructFieldStringTagMarshalJSON                       opType = 423
-	opStructFieldStringTagMarshalText                       opType = 424
-	opStructFieldStringTagRecursive                         opType = 425
-	opEndIndent                                             opType = 426
-	opInterfaceIndent                                       opType = 427
-	opInterfaceEndIndent                                    opType = 428
-	opPtrIndent                                             opType = 429
-	opSliceHeadIndent                                       opType = 430
-	opRootSliceHeadIndent                                   opType = 431
-	opSliceElemIndent                                       opType = 432
-	opRootSliceElemIndent                                   opType = 433
-	opSliceEndIndent                                        opType = 434
-	opArrayHeadIndent                                       opType = 435
-	opArrayElemIndent                                       opType = 436
-	opArrayEndIndent                                        opType = 437
-	opMapHeadIndent                                         opType = 438
-	opMapHeadLoadIndent                                     opType = 439
-	opMapKeyIndent                                          opType = 440
-	opMapValueIndent                                        opType = 441
-	opMapEndIndent                                          opType = 442
-	opStructFieldHeadIndent                                 opType = 443
-	opStructFieldHeadOmitEmptyIndent                        opType = 444
-	opStructFieldHeadStringTagIndent                        opType = 445
-	opStructFieldAnonymousHeadIndent                        opType = 446
-	opStructFieldAnonymousHeadOmitEmptyIndent               opType = 447
-	opStructFieldPtrAnonymousHeadOmitEmptyIndent            opType = 448
-	opStructFieldAnonymousHeadStringTagIndent               opType = 449
-	opStructFieldPtrAnonymousHeadStringTagIndent            opType = 450
-	opStructFieldPtrHeadIndent                              opType = 451
-	opStructFieldPtrHeadOmitEmptyIndent                     opType = 452
-	opStructFieldPtrHeadStringTagIndent                     opType = 453
-	opStructFieldPtrAnonymousHeadIndent                     opType = 454
-	opStructFieldIndent                                     opType = 455
-	opStructFieldOmitEmptyIndent                            opType = 456
-	opStructFieldStringTagIndent                            opType = 457
-	opStructFieldRecursiveEndIndent                         opType = 458
-	opStructEndIndent                                       opType = 459
-	opStructAnonymousEndIndent                              opType = 460
-	opIntIndent                                             opType = 461
-	opInt8Indent                                            opType = 462
-	opInt16Indent                                           opType = 463
-	opInt32Indent                                           opType = 464
-	opInt64Indent                                           opType = 465
-	opUintIndent                                            opType = 466
-	opUint8Indent                                           opType = 467
-	opUint16Indent                                          opType = 468
-	opUint32Indent                                          opType = 469
-	opUint64Indent                                          opType = 470
-	opFloat32Indent                                         opType = 471
-	opFloat64Indent                                         opType = 472
-	opBoolIndent                                            opType = 473
-	opStringIndent                                          opType = 474
-	opBytesIndent                                           opType = 475
-	opArrayIndent                                           opType = 476
-	opMapIndent                                             opType = 477
-	opMapLoadIndent                                         opType = 478
-	opSliceIndent                                           opType = 479
-	opStructIndent                                          opType = 480
-	opMarshalJSONIndent                                     opType = 481
-	opMarshalTextIndent                                     opType = 482
-	opRecursiveIndent                                       opType = 483
-	opStructFieldHeadIntIndent                              opType = 484
-	opStructFieldHeadInt8Indent                             opType = 485
-	opStructFieldHeadInt16Indent                            opType = 486
-	opStructFieldHeadInt32Indent                            opType = 487
-	opStructFieldHeadInt64Indent                            opType = 488
-	opStructFieldHeadUintIndent                             opType = 489
-	opStructFieldHeadUint8Indent                            opType = 490
-	opStructFieldHeadUint16Indent                           opType = 491
-	opStructFieldHeadUint32Indent                           opType = 492
-	opStructFieldHeadUint64Indent                           opType = 493
-	opStructFieldHeadFloat32Indent                          opType = 494
-	opStructFieldHeadFloat64Indent                          opType = 495
-	opStructFieldHeadBoolIndent                             opType = 496
-	opStructFieldHeadStringIndent                           opType = 497
-	opStructFieldHeadBytesIndent                            opType = 498
-	opStructFieldHeadArrayIndent                            opType = 499
-	opStructFieldHeadMapIndent                              opType = 500
-	opStructFieldHeadMapLoadIndent                          opType = 501
-	opStructFieldHeadSliceIndent                            opType = 502
-	opStructFieldHeadStructIndent                           opType = 503
-	opStructFieldHeadMarshalJSONIndent                      opType = 504
-	opStructFieldHeadMarshalTextIndent                      opType = 505
-	opStructFieldHeadRecursiveIndent                        opType = 506
-	opStructFieldHeadOmitEmptyIntIndent                     opType = 507
-	opStructFieldHeadOmitEmptyInt8Indent                    opType = 508
-	opStructFieldHeadOmitEmptyInt16Indent                   opType = 509
-	opStructFieldHeadOmitEmptyInt32Indent                   opType = 510
-	opStructFieldHeadOmitEmptyInt64Indent                   opType = 511
-	opStructFieldHeadOmitEmptyUintIndent                    opType = 512
-	opStructFieldHeadOmitEmptyUint8Indent                   opType = 513
-	opStructFieldHeadOmitEmptyUint16Indent                  opType = 514
-	opStructFieldHeadOmitEmptyUint32Indent                  opType = 515
-	opStructFieldHeadOmitEmptyUint64Indent                  opType = 516
-	opStructFieldHeadOmitEmptyFloat32Indent                 opType = 517
-	opStructFieldHeadOmitEmptyFloat64Indent                 opType = 518
-	opStructFieldHeadOmitEmptyBoolIndent                    opType = 519
-	opStructFieldHeadOmitEmptyStringIndent                  opType = 520
-	opStructFieldHeadOmitEmptyBytesIndent                   opType = 521
-	opStructFieldHeadOmitEmptyArrayIndent                   opType = 522
-	opStructFieldHeadOmitEmptyMapIndent                     opType = 523
-	opStructFieldHeadOmitEmptyMapLoadIndent                 opType = 524
-	opStructFieldHeadOmitEmptySliceIndent                   opType = 525
-	opStructFieldHeadOmitEmptyStructIndent                  opType = 526
-	opStructFieldHeadOmitEmptyMarshalJSONIndent             opType = 527
-	opStructFieldHeadOmitEmptyMarshalTextIndent             opType = 528
-	opStructFieldHeadOmitEmptyRecursiveIndent               opType = 529
-	opStructFieldHeadStringTagIntIndent                     opType = 530
-	opStructFieldHeadStringTagInt8Indent                    opType = 531
-	opStructFieldHeadStringTagInt16Indent                   opType = 532
-	opStructFieldHeadStringTagInt32Indent                   opType = 533
-	opStructFieldHeadStringTagInt64Indent                   opType = 534
-	opStructFieldHeadStringTagUintIndent                    opType = 535
-	opStructFieldHeadStringTagUint8Indent                   opType = 536
-	opStructFieldHeadStringTagUint16Indent                  opType = 537
-	opStructFieldHeadStringTagUint32Indent                  opType = 538
-	opStructFieldHeadStringTagUint64Indent                  opType = 539
-	opStructFieldHeadStringTagFloat32Indent                 opType = 540
-	opStructFieldHeadStringTagFloat64Indent                 opType = 541
-	opStructFieldHeadStringTagBoolIndent                    opType = 542
-	opStructFieldHeadStringTagStringIndent                  opType = 543
-	opStructFieldHeadStringTagBytesIndent                   opType = 544
-	opStructFieldHeadStringTagArrayIndent                   opType = 545
-	opStructFieldHeadStringTagMapIndent                     opType = 546
-	opStructFieldHeadStringTagMapLoadIndent                 opType = 547
-	opStructFieldHeadStringTagSliceIndent                   opType = 548
-	opStructFieldHeadStringTagStructIndent                  opType = 549
-	opStructFieldHeadStringTagMarshalJSONIndent             opType = 550
-	opStructFieldHeadStringTagMarshalTextIndent             opType = 551
-	opStructFieldHeadStringTagRecursiveIndent               opType = 552
-	opStructFieldAnonymousHeadIntIndent                     opType = 553
-	opStructFieldAnonymousHeadInt8Indent                    opType = 554
-	opStructFieldAnonymousHeadInt16Indent                   opType = 555
-	opStructFieldAnonymousHeadInt32Indent                   opType = 556
-	opStructFieldAnonymousHeadInt64Indent                   opType = 557
-	opStructFieldAnonymousHeadUintIndent                    opType = 558
-	opStructFieldAnonymousHeadUint8Indent                   opType = 559
-	opStructFieldAnonymousHeadUint16Indent                  opType = 560
-	opStructFieldAnonymousHeadUint32Indent                  opType = 561
-	opStructFieldAnonymousHeadUint64Indent                  opType = 562
-	opStructFieldAnonymousHeadFloat32Indent                 opType = 563
-	opStructFieldAnonymousHeadFloat64Indent                 opType = 564
-	opStructFieldAnonymousHeadBoolIndent                    opType = 565
-	opStructFieldAnonymousHeadStringIndent                  opType = 566
-	opStructFieldAnonymousHeadBytesIndent                   opType = 567
-	opStructFieldAnonymousHeadArrayIndent                   opType = 568
-	opStructFieldAnonymousHeadMapIndent                     opType = 569
-	opStructFieldAnonymousHeadMapLoadIndent                 opType = 570
-	opStructFieldAnonymousHeadSliceIndent                   opType = 571
-	opStructFieldAnonymousHeadStructIndent                  opType = 572
-	opStructFieldAnonymousHeadMarshalJSONIndent             opType = 573
-	opStructFieldAnonymousHeadMarshalTextIndent             opType = 574
-	opStructFieldAnonymousHeadRecursiveIndent               opType = 575
-	opStructFieldAnonymousHeadOmitEmptyIntIndent            opType = 576
-	opStructFieldAnonymousHeadOmitEmptyInt8Indent           opType = 577
-	opStructFieldAnonymousHeadOmitEmptyInt16Indent          opType = 578
-	opStructFieldAnonymousHeadOmitEmptyInt32Indent          opType = 579
-	opStructFieldAnonymousHeadOmitEmptyInt64Indent          opType = 580
-	opStructFieldAnonymousHeadOmitEmptyUintIndent           opType = 581
-	opStructFieldAnonymousHeadOmitEmptyUint8Indent          opType = 582
-	opStructFieldAnonymousHeadOmitEmptyUint16Indent         opType = 583
-	opStructFieldAnonymousHeadOmitEmptyUint32Indent         opType = 584
-	opStructFieldAnonymousHeadOmitEmptyUint64Indent         opType = 585
-	opStructFieldAnonymousHeadOmitEmptyFloat32Indent        opType = 586
-	opStructFieldAnonymousHeadOmitEmptyFloat64Indent        opType = 587
-	opStructFieldAnonymousHeadOmitEmptyBoolIndent           opType = 588
-	opStructFieldAnonymousHeadOmitEmptyStringIndent         opType = 589
-	opStructFieldAnonymousHeadOmitEmptyBytesIndent          opType = 590
-	opStructFieldAnonymousHeadOmitEmptyArrayIndent          opType = 591
-	opStructFieldAnonymousHeadOmitEmptyMapIndent            opType = 592
-	opStructFieldAnonymousHeadOmitEmptyMapLoadIndent        opType = 593
-	opStructFieldAnonymousHeadOmitEmptySliceIndent          opType = 594
-	opStructFieldAnonymousHeadOmitEmptyStructIndent         opType = 595
-	opStructFieldAnonymousHeadOmitEmptyMarshalJSONIndent    opType = 596
-	opStructFieldAnonymousHeadOmitEmptyMarshalTextIndent    opType = 597
-	opStructFieldAnonymousHeadOmitEmptyRecursiveIndent      opType = 598
-	opStructFieldAnonymousHeadStringTagIntIndent            opType = 599
-	opStructFieldAnonymousHeadStringTagInt8Indent           opType = 600
-	opStructFieldAnonymousHeadStringTagInt16Indent          opType = 601
-	opStructFieldAnonymousHeadStringTagInt32Indent          opType = 602
-	opStructFieldAnonymousHeadStringTagInt64Indent          opType = 603
-	opStructFieldAnonymousHeadStringTagUintIndent           opType = 604
-	opStructFieldAnonymousHeadStringTagUint8Indent          opType = 605
-	opStructFieldAnonymousHeadStringTagUint16Indent         opType = 606
-	opStructFieldAnonymousHeadStringTagUint32Indent         opType = 607
-	opStructFieldAnonymousHeadStringTagUint64Indent         opType = 608
-	opStructFieldAnonymousHeadStringTagFloat32Indent        opType = 609
-	opStructFieldAnonymousHeadStringTagFloat64Indent        opType = 610
-	opStructFieldAnonymousHeadStringTagBoolIndent           opType = 611
-	opStructFieldAnonymousHeadStringTagStringIndent         opType = 612
-	opStructFieldAnonymousHeadStringTagBytesIndent          opType = 613
-	opStructFieldAnonymousHeadStringTagArrayIndent          opType = 614
-	opStructFieldAnonymousHeadStringTagMapIndent            opType = 615
-	opStructFieldAnonymousHeadStringTagMapLoadIndent        opType = 616
-	opStructFieldAnonymousHeadStringTagSliceIndent          opType = 617
-	opStructFieldAnonymousHeadStringTagStructIndent         opType = 618
-	opStructFieldAnonymousHeadStringTagMarshalJSONIndent    opType = 619
-	opStructFieldAnonymousHeadStringTagMarshalTextIndent    opType = 620
-	opStructFieldAnonymousHeadStringTagRecursiveIndent      opType = 621
-	opStructFieldPtrHeadIntIndent                           opType = 622
-	opStructFieldPtrHeadInt8Indent                          opType = 623
-	opStructFieldPtrHeadInt16Indent                         opType = 624
-	opStructFieldPtrHeadInt32Indent                         opType = 625
-	opStructFieldPtrHeadInt64Indent                         opType = 626
-	opStructFieldPtrHeadUintIndent                          opType = 627
-	opStructFieldPtrHeadUint8Indent                         opType = 628
-	opStructFieldPtrHeadUint16Indent                        opType = 629
-	opStructFieldPtrHeadUint32Indent                        opType = 630
-	opStructFieldPtrHeadUint64Indent                        opType = 631
-	opStructFieldPtrHeadFloat32Indent                       opType = 632
-	opStructFieldPtrHeadFloat64Indent                       opType = 633
-	opStructFieldPtrHeadBoolIndent                          opType = 634
-	opStructFieldPtrHeadStringIndent                        opType = 635
-	opStructFieldPtrHeadBytesIndent                         opType = 636
-	opStructFieldPtrHeadArrayIndent                         opType = 637
-	opStructFieldPtrHeadMapIndent                           opType = 638
-	opStructFieldPtrHeadMapLoadIndent                       opType = 639
-	opStructFieldPtrHeadSliceIndent                         opType = 640
-	opStructFieldPtrHeadStructIndent                        opType = 641
-	opStructFieldPtrHeadMarshalJSONIndent                   opType = 642
-	opStructFieldPtrHeadMarshalTextIndent                   opType = 643
-	opStructFieldPtrHeadRecursiveIndent                     opType = 644
-	opStructFieldPtrHeadOmitEmptyIntIndent                  opType = 645
-	opStructFieldPtrHeadOmitEmptyInt8Indent                 opType = 646
-	opStructFieldPtrHeadOmitEmptyInt16Indent                opType = 647
-	opStructFieldPtrHeadOmitEmptyInt32Indent                opType = 648
-	opStructFieldPtrHeadOmitEmptyInt64Indent                opType = 649
-	opStructFieldPtrHeadOmitEmptyUintIndent                 opType = 650
-	opStructFieldPtrHeadOmitEmptyUint8Indent                opType = 651
-	opStructFieldPtrHeadOmitEmptyUint16Indent               opType = 652
-	opStructFieldPtrHeadOmitEmptyUint32Indent               opType = 653
-	opStructFieldPtrHeadOmitEmptyUint64Indent               opType = 654
-	opStructFieldPtrHeadOmitEmptyFloat32Indent              opType = 655
-	opStructFieldPtrHeadOmitEmptyFloat64Indent              opType = 656
-	opStructFieldPtrHeadOmitEmptyBoolIndent                 opType = 657
-	opStructFieldPtrHeadOmitEmptyStringIndent               opType = 658
-	opStructFieldPtrHeadOmitEmptyBytesIndent                opType = 659
-	opStructFieldPtrHeadOmitEmptyArrayIndent                opType = 660
-	opStructFieldPtrHeadOmitEmptyMapIndent                  opType = 661
-	opStructFieldPtrHeadOmitEmptyMapLoadIndent              opType = 662
-	opStructFieldPtrHeadOmitEmptySliceIndent                opType = 663
-	opStructFieldPtrHeadOmitEmptyStructIndent               opType = 664
-	opStructFieldPtrHeadOmitEmptyMarshalJSONIndent          opType = 665
-	opStructFieldPtrHeadOmitEmptyMarshalTextIndent          opType = 666
-	opStructFieldPtrHeadOmitEmptyRecursiveIndent            opType = 667
-	opStructFieldPtrHeadStringTagIntIndent                  opType = 668
-	opStructFieldPtrHeadStringTagInt8Indent                 opType = 669
-	opStructFieldPtrHeadStringTagInt16Indent                opType = 670
-	opStructFieldPtrHeadStringTagInt32Indent                opType = 671
-	opStructFieldPtrHeadStringTagInt64Indent                opType = 672
-	opStructFieldPtrHeadStringTagUintIndent                 opType = 673
-	opStructFieldPtrHeadStringTagUint8Indent                opType = 674
-	opStructFieldPtrHeadStringTagUint16Indent               opType = 675
-	opStructFieldPtrHeadStringTagUint32Indent               opType = 676
-	opStructFieldPtrHeadStringTagUint64Indent               opType = 677
-	opStructFieldPtrHeadStringTagFloat32Indent              opType = 678
-	opStructFieldPtrHeadStringTagFloat64Indent              opType = 679
-	opStructFieldPtrHeadStringTagBoolIndent                 opType = 680
-	opStructFieldPtrHeadStringTagStringIndent               opType = 681
-	opStructFieldPtrHeadStringTagBytesIndent                opType = 682
-	opStructFieldPtrHeadStringTagArrayIndent                opType = 683
-	opStructFieldPtrHeadStringTagMapIndent                  opType = 684
-	opStructFieldPtrHeadStringTagMapLoadIndent              opType = 685
-	opStructFieldPtrHeadStringTagSliceIndent                opType = 686
-	opStructFieldPtrHeadStringTagStructIndent               opType = 687
-	opStructFieldPtrHeadStringTagMarshalJSONIndent          opType = 688
-	opStructFieldPtrHeadStringTagMarshalTextIndent          opType = 689
-	opStructFieldPtrHeadStringTagRecursiveIndent            opType = 690
-	opStructFieldPtrAnonymousHeadIntIndent                  opType = 691
-	opStructFieldPtrAnonymousHeadInt8Indent                 opType = 692
-	opStructFieldPtrAnonymousHeadInt16Indent                opType = 693
-	opStructFieldPtrAnonymousHeadInt32Indent                opType = 694
-	opStructFieldPtrAnonymousHeadInt64Indent                opType = 695
-	opStructFieldPtrAnonymousHeadUintIndent                 opType = 696
-	opStructFieldPtrAnonymousHeadUint8Indent                opType = 697
-	opStructFieldPtrAnonymousHeadUint16Indent               opType = 698
-	opStructFieldPtrAnonymousHeadUint32Indent               opType = 699
-	opStructFieldPtrAnonymousHeadUint64Indent               opType = 700
-	opStructFieldPtrAnonymousHeadFloat32Indent              opType = 701
-	opStructFieldPtrAnonymousHeadFloat64Indent              opType = 702
-	opStructFieldPtrAnonymousHeadBoolIndent                 opType = 703
-	opStructFieldPtrAnonymousHeadStringIndent               opType = 704
-	opStructFieldPtrAnonymousHeadBytesIndent                opType = 705
-	opStructFieldPtrAnonymousHeadArrayIndent                opType = 706
-	opStructFieldPtrAnonymousHeadMapIndent                  opType = 707
-	opStructFieldPtrAnonymousHeadMapLoadIndent              opType = 708
-	opStructFieldPtrAnonymousHeadSliceIndent                opType = 709
-	opStructFieldPtrAnonymousHeadStructIndent               opType = 710
-	opStructFieldPtrAnonymousHeadMarshalJSONIndent          opType = 711
-	opStructFieldPtrAnonymousHeadMarshalTextIndent          opType = 712
-	opStructFieldPtrAnonymousHeadRecursiveIndent            opType = 713
-	opStructFieldPtrAnonymousHeadOmitEmptyIntIndent         opType = 714
-	opStructFieldPtrAnonymousHeadOmitEmptyInt8Indent        opType = 715
-	opStructFieldPtrAnonymousHeadOmitEmptyInt16Indent       opType = 716
-	opStructFieldPtrAnonymousHeadOmitEmptyInt32Indent       opType = 717
-	opStructFieldPtrAnonymousHeadOmitEmptyInt64Indent       opType = 718
-	opStructFieldPtrAnonymousHeadOmitEmptyUintIndent        opType = 719
-	opStructFieldPtrAnonymousHeadOmitEmptyUint8Indent       opType = 720
-	opStructFieldPtrAnonymousHeadOmitEmptyUint16Indent      opType = 721
-	opStructFieldPtrAnonymousHeadOmitEmptyUint32Indent      opType = 722
-	opStructFieldPtrAnonymousHeadOmitEmptyUint64Indent      opType = 723
-	opStructFieldPtrAnonymousHeadOmitEmptyFloat32Indent     opType = 724
-	opStructFieldPtrAnonymousHeadOmitEmptyFloat64Indent     opType = 725
-	opStructFieldPtrAnonymousHeadOmitEmptyBoolIndent        opType = 726
-	opStructFieldPtrAnonymousHeadOmitEmptyStringIndent      opType = 727
-	opStructFieldPtrAnonymousHeadOmitEmptyBytesIndent       opType = 728
-	opStructFieldPtrAnonymousHeadOmitEmptyArrayIndent       opType = 729
-	opStructFieldPtrAnonymousHeadOmitEmptyMapIndent         opType = 730
-	opStructFieldPtrAnonymousHeadOmitEmptyMapLoadIndent     opType = 731
-	opStructFieldPtrAnonymousHeadOmitEmptySliceIndent       opType = 732
-	opStructFieldPtrAnonymousHeadOmitEmptyStructIndent      opType = 733
-	opStructFieldPtrAnonymousHeadOmitEmptyMarshalJSONIndent opType = 734
-	opStructFieldPtrAnonymousHeadOmitEmptyMarshalTextIndent opType = 735
-	opStructFieldPtrAnonymousHeadOmitEmptyRecursiveIndent   opType = 736
-	opStructFieldPtrAnonymousHeadStringTagIntIndent         opType = 737
-	opStructFieldPtrAnonymousHeadStringTagInt8Indent        opType = 738
-	opStructFieldPtrAnonymousHeadStringTagInt16Indent       opType = 739
-	opStructFieldPtrAnonymousHeadStringTagInt32Indent       opType = 740
-	opStructFieldPtrAnonymousHeadStringTagInt64Indent       opType = 741
-	opStructFieldPtrAnonymousHeadStringTagUintIndent        opType = 742
-	opStructFieldPtrAnonymousHeadStringTagUint8Indent       opType = 743
-	opStructFieldPtrAnonymousHeadStringTagUint16Indent      opType = 744
-	opStructFieldPtrAnonymousHeadStringTagUint32Indent      opType = 745
-	opStructFieldPtrAnonymousHeadStringTagUint64Indent      opType = 746
-	opStructFieldPtrAnonymousHeadStringTagFloat32Indent     opType = 747
-	opStructFieldPtrAnonymousHeadStringTagFloat64Indent     opType = 748
-	opStructFieldPtrAnonymousHeadStringTagBoolIndent        opType = 749
-	opStructFieldPtrAnonymousHeadStringTagStringIndent      opType = 750
-	opStructFieldPtrAnonymousHeadStringTagBytesIndent       opType = 751
-	opStructFieldPtrAnonymousHeadStringTagArrayIndent       opType = 752
-	opStructFieldPtrAnonymousHeadStringTagMapIndent         opType = 753
-	opStructFieldPtrAnonymousHeadStringTagMapLoadIndent     opType = 754
-	opStructFieldPtrAnonymousHeadStringTagSliceIndent       opType = 755
-	opStructFieldPtrAnonymousHeadStringTagStructIndent      opType = 756
-	opStructFieldPtrAnonymousHeadStringTagMarshalJSONIndent opType = 757
-	opStructFieldPtrAnonymousHeadStringTagMarshalTextIndent opType = 758
-	opStructFieldPtrAnonymousHeadStringTagRecursiveIndent   opType = 759
-	opStructFieldIntIndent                                  opType = 760
-	opStructFieldInt8Indent                                 opType = 761
-	opStructFieldInt16Indent                                opType = 762
-	opStructFieldInt32Indent                                opType = 763
-	opStructFieldInt64Indent                                opType = 764
-	opStructFieldUintIndent                                 opType = 765
-	opStructFieldUint8Indent                                opType = 766
-	opStructFieldUint16Indent                               opType = 767
-	opStructFieldUint32Indent                               opType = 768
-	opStructFieldUint64Indent                               opType = 769
-	opStructFieldFloat32Indent                              opType = 770
-	opStructFieldFloat64Indent                              opType = 771
-	opStructFieldBoolIndent                                 opType = 772
-	opStructFieldStringIndent                               opType = 773
-	opStructFieldBytesIndent                                opType = 774
-	opStructFieldArrayIndent                                opType = 775
-	opStructFieldMapIndent                                  opType = 776
-	opStructFieldMapLoadIndent                              opType = 777
-	opStructFieldSliceIndent                                opType = 778
-	opStructFieldStructIndent                               opType = 779
-	opStructFieldMarshalJSONIndent                          opType = 780
-	opStructFieldMarshalTextIndent                          opType = 781
-	opStructFieldRecursiveIndent                            opType = 782
-	opStructFieldPtrIntIndent                               opType = 783
-	opStructFieldPtrInt8Indent                              opType = 784
-	opStructFieldPtrInt16Indent                             opType = 785
-	opStructFieldPtrInt32Indent                             opType = 786
-	opStructFieldPtrInt64Indent                             opType = 787
-	opStructFieldPtrUintIndent                              opType = 788
-	opStructFieldPtrUint8Indent                             opType = 789
-	opStructFieldPtrUint16Indent                            opType = 790
-	opStructFieldPtrUint32Indent                            opType = 791
-	opStructFieldPtrUint64Indent                            opType = 792
-	opStructFieldPtrFloat32Indent                           opType = 793
-	opStructFieldPtrFloat64Indent                           opType = 794
-	opStructFieldPtrBoolIndent                              opType = 795
-	opStructFieldPtrStringIndent                            opType = 796
-	opStructFieldPtrBytesIndent                             opType = 797
-	opStructFieldPtrArrayIndent                             opType = 798
-	opStructFieldPtrMapIndent                               opType = 799
-	opStructFieldPtrMapLoadIndent                           opType = 800
-	opStructFieldPtrSliceIndent                             opType = 801
-	opStructFieldPtrStructIndent                            opType = 802
-	opStructFieldPtrMarshalJSONIndent                       opType = 803
-	opStructFieldPtrMarshalTextIndent                       opType = 804
-	opStructFieldPtrRecursiveIndent                         opType = 805
-	opStructFieldOmitEmptyIntIndent                         opType = 806
-	opStructFieldOmitEmptyInt8Indent                        opType = 807
-	opStructFieldOmitEmptyInt16Indent                       opType = 808
-	opStructFieldOmitEmptyInt32Indent                       opType = 809
-	opStructFieldOmitEmptyInt64Indent                       opType = 810
-	opStructFieldOmitEmptyUintIndent                        opType = 811
-	opStructFieldOmitEmptyUint8Indent                       opType = 812
-	opStructFieldOmitEmptyUint16Indent                      opType = 813
-	opStructFieldOmitEmptyUint32Indent                      opType = 814
-	opStructFieldOmitEmptyUint64Indent                      opType = 815
-	opStructFieldOmitEmptyFloat32Indent                     opType = 816
-	opStructFieldOmitEmptyFloat64Indent                     opType = 817
-	opStructFieldOmitEmptyBoolIndent                        opType = 818
-	opStructFieldOmitEmptyStringIndent                      opType = 819
-	opStructFieldOmitEmptyBytesIndent                       opType = 820
-	opStructFieldOmitEmptyArrayIndent                       opType = 821
-	opStructFieldOmitEmptyMapIndent                         opType = 822
-	opStructFieldOmitEmptyMapLoadIndent                     opType = 823
-	opStructFieldOmitEmptySliceIndent                       opType = 824
-	opStructFieldOmitEmptyStructIndent                      opType = 825
-	opStructFieldOmitEmptyMarshalJSONIndent                 opType = 826
-	opStructFieldOmitEmptyMarshalTextIndent                 opType = 827
-	opStructFieldOmitEmptyRecursiveIndent                   opType = 828
-	opStructFieldStringTagIntIndent                         opType = 829
-	opStructFieldStringTagInt8Indent                        opType = 830
-	opStructFieldStringTagInt16Indent                       opType = 831
-	opStructFieldStringTagInt32Indent                       opType = 832
-	opStructFieldStringTagInt64Indent                       opType = 833
-	opStructFieldStringTagUintIndent                        opType = 834
-	opStructFieldStringTagUint8Indent                       opType = 835
-	opStructFieldStringTagUint16Indent                      opType = 836
-	opStructFieldStringTagUint32Indent                      opType = 837
-	opStructFieldStringTagUint64Indent                      opType = 838
-	opStructFieldStringTagFloat32Indent                     opType = 839
-	opStructFieldStringTagFloat64Indent                     opType = 840
-	opStructFieldStringTagBoolIndent                        opType = 841
-	opStructFieldStringTagStringIndent                      opType = 842
-	opStructFieldStringTagBytesIndent                       opType = 843
-	opStructFieldStringTagArrayIndent                       opType = 844
-	opStructFieldStringTagMapIndent                         opType = 845
-	opStructFieldStringTagMapLoadIndent                     opType = 846
-	opStructFieldStringTagSliceIndent                       opType = 847
-	opStructFieldStringTagStructIndent                      opType = 848
-	opStructFieldStringTagMarshalJSONIndent                 opType = 849
-	opStructFieldStringTagMarshalTextIndent                 opType = 850
-	opStructFieldStringTagRecursiveIndent                   opType = 851
+	opCustom                                                opType = 4
+	opAppendJSON                                            opType = 5
+	opTime                                                  opType = 6
+	opDuration                                              opType = 7
+	opSliceHead                                             opType = 8
+	opRootSliceHead                                         opType = 9
+	opSliceElem                                             opType = 10
+	opRootSliceElem                                         opType = 11
+	opSliceEnd                                              opType = 12
+	opArrayHead                                             opType = 13
+	opArrayElem                                             opType = 14
+	opArrayEnd                                              opType = 15
+	opMapHead                                               opType = 16
+	opMapHeadLoad                                           opType = 17
+	opMapKey                                                opType = 18
+	opMapValue                                              opType = 19
+	opMapEnd                                                opType = 20
+	opStructFieldHead                                       opType = 21
+	opStructFieldHeadOmitEmpty                              opType = 22
+	opStructFieldHeadStringTag                              opType = 23
+	opStructFieldAnonymousHead                              opType = 24
+	opStructFieldAnonymousHeadOmitEmpty                     opType = 25
+	opStructFieldPtrAnonymousHeadOmitEmpty                  opType = 26
+	opStructFieldAnonymousHeadStringTag                     opType = 27
+	opStructFieldPtrAnonymousHeadStringTag                  opType = 28
+	opStructFieldPtrHead                                    opType = 29
+	opStructFieldPtrHeadOmitEmpty                           opType = 30
+	opStructFieldPtrHeadStringTag                           opType = 31
+	opStructFieldPtrAnonymousHead                           opType = 32
+	opStructField                                           opType = 33
+	opStructFieldOmitEmpty                                  opType = 34
+	opStructFieldStringTag                                  opType = 35
+	opStructFieldHeadOmitEmptyStringTag                     opType = 36
+	opStructFieldPtrHeadOmitEmptyStringTag                  opType = 37
+	opStructFieldAnonymousHeadOmitEmptyStringTag            opType = 38
+	opStructFieldPtrAnonymousHeadOmitEmptyStringTag         opType = 39
+	opStructFieldOmitEmptyStringTag                         opType = 40
+	opStructFieldHeadOmitZero                               opType = 41
+	opStructFieldPtrHeadOmitZero                            opType = 42
+	opStructFieldAnonymousHeadOmitZero                      opType = 43
+	opStructFieldPtrAnonymousHeadOmitZero                   opType = 44
+	opStructFieldOmitZero                                   opType = 45
+	opStructFieldHeadOmitZeroMethod                         opType = 46
+	opStructFieldPtrHeadOmitZeroMethod                      opType = 47
+	opStructFieldAnonymousHeadOmitZeroMethod                opType = 48
+	opStructFieldPtrAnonymousHeadOmitZeroMethod             opType = 49
+	opStructFieldOmitZeroMethod                             opType = 50
+	opStructFieldRecursiveEnd                               opType = 51
+	opStructEnd                                             opType = 52
+	opStructAnonymousEnd                                    opType = 53
+	opInt                                                   opType = 54
+	opInt8                                                  opType = 55
+	opInt16                                                 opType = 56
+	opInt32                                                 opType = 57
+	opInt64                                                 opType = 58
+	opUint                                                  opType = 59
+	opUint8                                                 opType = 60
+	opUint16                                                opType = 61
+	opUint32                                                opType = 62
+	opUint64                                                opType = 63
+	opFloat32                                               opType = 64
+	opFloat64                                               opType = 65
+	opBool                                                  opType = 66
+	opString                                                opType = 67
+	opBytes                                                 opType = 68
+	opArray                                                 opType = 69
+	opMap                                                   opType = 70
+	opMapLoad                                               opType = 71
+	opSlice                                                 opType = 72
+	opStruct                                                opType = 73
+	opMarshalJSON                                           opType = 74
+	opMarshalText                                           opType = 75
+	opRecursive                                             opType = 76
+	opIntString                                             opType = 77
+	opInt8String                                            opType = 78
+	opInt16String                                           opType = 79
+	opInt32String                                           opType = 80
+	opInt64String                                           opType = 81
+	opUintString                                            opType = 82
+	opUint8String                                           opType = 83
+	opUint16String                                          opType = 84
+	opUint32String                                          opType = 85
+	opUint64String                                          opType = 86
+	opStructFieldHeadInt                                    opType = 87
+	opStructFieldHeadInt8                                   opType = 88
+	opStructFieldHeadInt16                                  opType = 89
+	opStructFieldHeadInt32                                  opType = 90
+	opStructFieldHeadInt64                                  opType = 91
+	opStructFieldHeadUint                                   opType = 92
+	opStructFieldHeadUint8                                  opType = 93
+	opStructFieldHeadUint16                                 opType = 94
+	opStructFieldHeadUint32                                 opType = 95
+	opStructFieldHeadUint64                                 opType = 96
+	opStructFieldHeadFloat32                                opType = 97
+	opStructFieldHeadFloat64                                opType = 98
+	opStructFieldHeadBool                                   opType = 99
+	opStructFieldHeadString                                 opType = 100
+	opStructFieldHeadBytes                                  opType = 101
+	opStructFieldHeadArray                                  opType = 102
+	opStructFieldHeadMap                                    opType = 103
+	opStructFieldHeadMapLoad                                opType = 104
+	opStructFieldHeadSlice                                  opType = 105
+	opStructFieldHeadStruct                                 opType = 106
+	opStructFieldHeadMarshalJSON                            opType = 107
+	opStructFieldHeadMarshalText                            opType = 108
+	opStructFieldHeadRecursive                              opType = 109
+	opStructFieldHeadOmitEmptyInt                           opType = 110
+	opStructFieldHeadOmitEmptyInt8                          opType = 111
+	opStructFieldHeadOmitEmptyInt16                         opType = 112
+	opStructFieldHeadOmitEmptyInt32                         opType = 113
+	opStructFieldHeadOmitEmptyInt64                         opType = 114
+	opStructFieldHeadOmitEmptyUint                          opType = 115
+	opStructFieldHeadOmitEmptyUint8                         opType = 116
+	opStructFieldHeadOmitEmptyUint16                        opType = 117
+	opStructFieldHeadOmitEmptyUint32                        opType = 118
+	opStructFieldHeadOmitEmptyUint64                        opType = 119
+	opStructFieldHeadOmitEmptyFloat32                       opType = 120
+	opStructFieldHeadOmitEmptyFloat64                       opType = 121
+	opStructFieldHeadOmitEmptyBool                          opType = 122
+	opStructFieldHeadOmitEmptyString                        opType = 123
+	opStructFieldHeadOmitEmptyBytes                         opType = 124
+	opStructFieldHeadOmitEmptyArray                         opType = 125
+	opStructFieldHeadOmitEmptyMap                           opType = 126
+	opStructFieldHeadOmitEmptyMapLoad                       opType = 127
+	opStructFieldHeadOmitEmptySlice                         opType = 128
+	opStructFieldHeadOmitEmptyStruct                        opType = 129
+	opStructFieldHeadOmitEmptyMarshalJSON                   opType = 130
+	opStructFieldHeadOmitEmptyMarshalText                   opType = 131
+	opStructFieldHeadOmitEmptyRecursive                     opType = 132
+	opStructFieldHeadStringTagInt                           opType = 133
+	opStructFieldHeadStringTagInt8                          opType = 134
+	opStructFieldHeadStringTagInt16                         opType = 135
+	opStructFieldHeadStringTagInt32                         opType = 136
+	opStructFieldHeadStringTagInt64                         opType = 137
+	opStructFieldHeadStringTagUint                          opType = 138
+	opStructFieldHeadStringTagUint8                         opType = 139
+	opStructFieldHeadStringTagUint16                        opType = 140
+	opStructFieldHeadStringTagUint32                        opType = 141
+	opStructFieldHeadStringTagUint64                        opType = 142
+	opStructFieldHeadStringTagFloat32                       opType = 143
+	opStructFieldHeadStringTagFloat64                       opType = 144
+	opStructFieldHeadStringTagBool                          opType = 145
+	opStructFieldHeadStringTagString                        opType = 146
+	opStructFieldHeadStringTagBytes                         opType = 147
+	opStructFieldHeadStringTagArray                         opType = 148
+	opStructFieldHeadStringTagMap                           opType = 149
+	opStructFieldHeadStringTagMapLoad                       opType = 150
+	opStructFieldHeadStringTagSlice                         opType = 151
+	opStructFieldHeadStringTagStruct                        opType = 152
+	opStructFieldHeadStringTagMarshalJSON                   opType = 153
+	opStructFieldHeadStringTagMarshalText                   opType = 154
+	opStructFieldHeadStringTagRecursive                     opType = 155
+	opStructFieldAnonymousHeadInt                           opType = 156
+	opStructFieldAnonymousHeadInt8                          opType = 157
+	opStructFieldAnonymousHeadInt16                         opType = 158
+	opStructFieldAnonymousHeadInt32                         opType = 159
+	opStructFieldAnonymousHeadInt64                         opType = 160
+	opStructFieldAnonymousHeadUint                          opType = 161
+	opStructFieldAnonymousHeadUint8                         opType = 162
+	opStructFieldAnonymousHeadUint16                        opType = 163
+	opStructFieldAnonymousHeadUint32                        opType = 164
+	opStructFieldAnonymousHeadUint64                        opType = 165
+	opStructFieldAnonymousHeadFloat32                       opType = 166
+	opStructFieldAnonymousHeadFloat64                       opType = 167
+	opStructFieldAnonymousHeadBool                          opType = 168
+	opStructFieldAnonymousHeadString                        opType = 169
+	opStructFieldAnonymousHeadBytes                         opType = 170
+	opStructFieldAnonymousHeadArray                         opType = 171
+	opStructFieldAnonymousHeadMap                           opType = 172
+	opStructFieldAnonymousHeadMapLoad                       opType = 173
+	opStructFieldAnonymousHeadSlice                         opType = 174
+	opStructFieldAnonymousHeadStruct                        opType = 175
+	opStructFieldAnonymousHeadMarshalJSON                   opType = 176
+	opStructFieldAnonymousHeadMarshalText                   opType = 177
+	opStructFieldAnonymousHeadRecursive                     opType = 178
+	opStructFieldAnonymousHeadOmitEmptyInt                  opType = 179
+	opStructFieldAnonymousHeadOmitEmptyInt8                 opType = 180
+	opStructFieldAnonymousHeadOmitEmptyInt16                opType = 181
+	opStructFieldAnonymousHeadOmitEmptyInt32                opType = 182
+	opStructFieldAnonymousHeadOmitEmptyInt64                opType = 183
+	opStructFieldAnonymousHeadOmitEmptyUint                 opType = 184
+	opStructFieldAnonymousHeadOmitEmptyUint8                opType = 185
+	opStructFieldAnonymousHeadOmitEmptyUint16               opType = 186
+	opStructFieldAnonymousHeadOmitEmptyUint32               opType = 187
+	opStructFieldAnonymousHeadOmitEmptyUint64               opType = 188
+	opStructFieldAnonymousHeadOmitEmptyFloat32              opType = 189
+	opStructFieldAnonymousHeadOmitEmptyFloat64              opType = 190
+	opStructFieldAnonymousHeadOmitEmptyBool                 opType = 191
+	opStructFieldAnonymousHeadOmitEmptyString               opType = 192
+	opStructFieldAnonymousHeadOmitEmptyBytes                opType = 193
+	opStructFieldAnonymousHeadOmitEmptyArray                opType = 194
+	opStructFieldAnonymousHeadOmitEmptyMap                  opType = 195
+	opStructFieldAnonymousHeadOmitEmptyMapLoad              opType = 196
+	opStructFieldAnonymousHeadOmitEmptySlice                opType = 197
+	opStructFieldAnonymousHeadOmitEmptyStruct               opType = 198
+	opStructFieldAnonymousHeadOmitEmptyMarshalJSON          opType = 199
+	opStructFieldAnonymousHeadOmitEmptyMarshalText          opType = 200
+	opStructFieldAnonymousHeadOmitEmptyRecursive            opType = 201
+	opStructFieldAnonymousHeadStringTagInt                  opType = 202
+	opStructFieldAnonymousHeadStringTagInt8                 opType = 203
+	opStructFieldAnonymousHeadStringTagInt16                opType = 204
+	opStructFieldAnonymousHeadStringTagInt32                opType = 205
+	opStructFieldAnonymousHeadStringTagInt64                opType = 206
+	opStructFieldAnonymousHeadStringTagUint                 opType = 207
+	opStructFieldAnonymousHeadStringTagUint8                opType = 208
+	opStructFieldAnonymousHeadStringTagUint16               opType = 209
+	opStructFieldAnonymousHeadStringTagUint32               opType = 210
+	opStructFieldAnonymousHeadStringTagUint64               opType = 211
+	opStructFieldAnonymousHeadStringTagFloat32              opType = 212
+	opStructFieldAnonymousHeadStringTagFloat64              opType = 213
+	opStructFieldAnonymousHeadStringTagBool                 opType = 214
+	opStructFieldAnonymousHeadStringTagString               opType = 215
+	opStructFieldAnonymousHeadStringTagBytes                opType = 216
+	opStructFieldAnonymousHeadStringTagArray                opType = 217
+	opStructFieldAnonymousHeadStringTagMap                  opType = 218
+	opStructFieldAnonymousHeadStringTagMapLoad              opType = 219
+	opStructFieldAnonymousHeadStringTagSlice                opType = 220
+	opStructFieldAnonymousHeadStringTagStruct               opType = 221
+	opStructFieldAnonymousHeadStringTagMarshalJSON          opType = 222
+	opStructFieldAnonymousHeadStringTagMarshalText          opType = 223
+	opStructFieldAnonymousHeadStringTagRecursive            opType = 224
+	opStructFieldPtrHeadInt                                 opType = 225
+	opStructFieldPtrHeadInt8                                opType = 226
+	opStructFieldPtrHeadInt16                               opType = 227
+	opStructFieldPtrHeadInt32                               opType = 228
+	opStructFieldPtrHeadInt64                               opType = 229
+	opStructFieldPtrHeadUint                                opType = 230
+	opStructFieldPtrHeadUint8                               opType = 231
+	opStructFieldPtrHeadUint16                              opType = 232
+	opStructFieldPtrHeadUint32                              opType = 233
+	opStructFieldPtrHeadUint64                              opType = 234
+	opStructFieldPtrHeadFloat32                             opType = 235
+	opStructFieldPtrHeadFloat64                             opType = 236
+	opStructFieldPtrHeadBool                                opType = 237
+	opStructFieldPtrHeadString                              opType = 238
+	opStructFieldPtrHeadBytes                               opType = 239
+	opStructFieldPtrHeadArray                               opType = 240
+	opStructFieldPtrHeadMap                                 opType = 241
+	opStructFieldPtrHeadMapLoad                             opType = 242
+	opStructFieldPtrHeadSlice                               opType = 243
+	opStructFieldPtrHeadStruct                              opType = 244
+	opStructFieldPtrHeadMarshalJSON                         opType = 245
+	opStructFieldPtrHeadMarshalText                         opType = 246
+	opStructFieldPtrHeadRecursive                           opType = 247
+	opStructFieldPtrHeadOmitEmptyInt                        opType = 248
+	opStructFieldPtrHeadOmitEmptyInt8                       opType = 249
+	opStructFieldPtrHeadOmitEmptyInt16                      opType = 250
+	opStructFieldPtrHeadOmitEmptyInt32                      opType = 251
+	opStructFieldPtrHeadOmitEmptyInt64                      opType = 252
+	opStructFieldPtrHeadOmitEmptyUint                       opType = 253
+	opStructFieldPtrHeadOmitEmptyUint8                      opType = 254
+	opStructFieldPtrHeadOmitEmptyUint16                     opType = 255
+	opStructFieldPtrHeadOmitEmptyUint32                     opType = 256
+	opStructFieldPtrHeadOmitEmptyUint64                     opType = 257
+	opStructFieldPtrHeadOmitEmptyFloat32                    opType = 258
+	opStructFieldPtrHeadOmitEmptyFloat64                    opType = 259
+	opStructFieldPtrHeadOmitEmptyBool                       opType = 260
+	opStructFieldPtrHeadOmitEmptyString                     opType = 261
+	opStructFieldPtrHeadOmitEmptyBytes                      opType = 262
+	opStructFieldPtrHeadOmitEmptyArray                      opType = 263
+	opStructFieldPtrHeadOmitEmptyMap                        opType = 264
+	opStructFieldPtrHeadOmitEmptyMapLoad                    opType = 265
+	opStructFieldPtrHeadOmitEmptySlice                      opType = 266
+	opStructFieldPtrHeadOmitEmptyStruct                     opType = 267
+	opStructFieldPtrHeadOmitEmptyMarshalJSON                opType = 268
+	opStructFieldPtrHeadOmitEmptyMarshalText                opType = 269
+	opStructFieldPtrHeadOmitEmptyRecursive                  opType = 270
+	opStructFieldPtrHeadStringTagInt                        opType = 271
+	opStructFieldPtrHeadStringTagInt8                       opType = 272
+	opStructFieldPtrHeadStringTagInt16                      opType = 273
+	opStructFieldPtrHeadStringTagInt32                      opType = 274
+	opStructFieldPtrHeadStringTagInt64                      opType = 275
+	opStructFieldPtrHeadStringTagUint                       opType = 276
+	opStructFieldPtrHeadStringTagUint8                      opType = 277
+	opStructFieldPtrHeadStringTagUint16                     opType = 278
+	opStructFieldPtrHeadStringTagUint32                     opType = 279
+	opStructFieldPtrHeadStringTagUint64                     opType = 280
+	opStructFieldPtrHeadStringTagFloat32                    opType = 281
+	opStructFieldPtrHeadStringTagFloat64                    opType = 282
+	opStructFieldPtrHeadStringTagBool                       opType = 283
+	opStructFieldPtrHeadStringTagString                     opType = 284
+	opStructFieldPtrHeadStringTagBytes                      opType = 285
+	opStructFieldPtrHeadStringTagArray                      opType = 286
+	opStructFieldPtrHeadStringTagMap                        opType = 287
+	opStructFieldPtrHeadStringTagMapLoad                    opType = 288
+	opStructFieldPtrHeadStringTagSlice                      opType = 289
+	opStructFieldPtrHeadStringTagStruct                     opType = 290
+	opStructFieldPtrHeadStringTagMarshalJSON                opType = 291
+	opStructFieldPtrHeadStringTagMarshalText                opType = 292
+	opStructFieldPtrHeadStringTagRecursive                  opType = 293
+	opStructFieldPtrAnonymousHeadInt                        opType = 294
+	opStructFieldPtrAnonymousHeadInt8                       opType = 295
+	opStructFieldPtrAnonymousHeadInt16                      opType = 296
+	opStructFieldPtrAnonymousHeadInt32                      opType = 297
+	opStructFieldPtrAnonymousHeadInt64                      opType = 298
+	opStructFieldPtrAnonymousHeadUint                       opType = 299
+	opStructFieldPtrAnonymousHeadUint8                      opType = 300
+	opStructFieldPtrAnonymousHeadUint16                     opType = 301
+	opStructFieldPtrAnonymousHeadUint32                     opType = 302
+	opStructFieldPtrAnonymousHeadUint64                     opType = 303
+	opStructFieldPtrAnonymousHeadFloat32                    opType = 304
+	opStructFieldPtrAnonymousHeadFloat64                    opType = 305
+	opStructFieldPtrAnonymousHeadBool                       opType = 306
+	opStructFieldPtrAnonymousHeadString                     opType = 307
+	opStructFieldPtrAnonymousHeadBytes                      opType = 308
+	opStructFieldPtrAnonymousHeadArray                      opType = 309
+	opStructFieldPtrAnonymousHeadMap                        opType = 310
+	opStructFieldPtrAnonymousHeadMapLoad                    opType = 311
+	opStructFieldPtrAnonymousHeadSlice                      opType = 312
+	opStructFieldPtrAnonymousHeadStruct                     opType = 313
+	opStructFieldPtrAnonymousHeadMarshalJSON                opType = 314
+	opStructFieldPtrAnonymousHeadMarshalText                opType = 315
+	opStructFieldPtrAnonymousHeadRecursive                  opType = 316
+	opStructFieldPtrAnonymousHeadOmitEmptyInt               opType = 317
+	opStructFieldPtrAnonymousHeadOmitEmptyInt8              opType = 318
+	opStructFieldPtrAnonymousHeadOmitEmptyInt16             opType = 319
+	opStructFieldPtrAnonymousHeadOmitEmptyInt32             opType = 320
+	opStructFieldPtrAnonymousHeadOmitEmptyInt64             opType = 321
+	opStructFieldPtrAnonymousHeadOmitEmptyUint              opType = 322
+	opStructFieldPtrAnonymousHeadOmitEmptyUint8             opType = 323
+	opStructFieldPtrAnonymousHeadOmitEmptyUint16            opType = 324
+	opStructFieldPtrAnonymousHeadOmitEmptyUint32            opType = 325
+	opStructFieldPtrAnonymousHeadOmitEmptyUint64            opType = 326
+	opStructFieldPtrAnonymousHeadOmitEmptyFloat32           opType = 327
+	opStructFieldPtrAnonymousHeadOmitEmptyFloat64           opType = 328
+	opStructFieldPtrAnonymousHeadOmitEmptyBool              opType = 329
+	opStructFieldPtrAnonymousHeadOmitEmptyString            opType = 330
+	opStructFieldPtrAnonymousHeadOmitEmptyBytes             opType = 331
+	opStructFieldPtrAnonymousHeadOmitEmptyArray             opType = 332
+	opStructFieldPtrAnonymousHeadOmitEmptyMap               opType = 333
+	opStructFieldPtrAnonymousHeadOmitEmptyMapLoad           opType = 334
+	opStructFieldPtrAnonymousHeadOmitEmptySlice             opType = 335
+	opStructFieldPtrAnonymousHeadOmitEmptyStruct            opType = 336
+	opStructFieldPtrAnonymousHeadOmitEmptyMarshalJSON       opType = 337
+	opStructFieldPtrAnonymousHeadOmitEmptyMarshalText       opType = 338
+	opStructFieldPtrAnonymousHeadOmitEmptyRecursive         opType = 339
+	opStructFieldPtrAnonymousHeadStringTagInt               opType = 340
+	opStructFieldPtrAnonymousHeadStringTagInt8              opType = 341
+	opStructFieldPtrAnonymousHeadStringTagInt16             opType = 342
+	opStructFieldPtrAnonymousHeadStringTagInt32             opType = 343
+	opStructFieldPtrAnonymousHeadStringTagInt64             opType = 344
+	opStructFieldPtrAnonymousHeadStringTagUint              opType = 345
+	opStructFieldPtrAnonymousHeadStringTagUint8             opType = 346
+	opStructFieldPtrAnonymousHeadStringTagUint16            opType = 347
+	opStructFieldPtrAnonymousHeadStringTagUint32            opType = 348
+	opStructFieldPtrAnonymousHeadStringTagUint64            opType = 349
+	opStructFieldPtrAnonymousHeadStringTagFloat32           opType = 350
+	opStructFieldPtrAnonymousHeadStringTagFloat64           opType = 351
+	opStructFieldPtrAnonymousHeadStringTagBool              opType = 352
+	opStructFieldPtrAnonymousHeadStringTagString            opType = 353
+	opStructFieldPtrAnonymousHeadStringTagBytes             opType = 354
+	opStructFieldPtrAnonymousHeadStringTagArray             opType = 355
+	opStructFieldPtrAnonymousHeadStringTagMap               opType = 356
+	opStructFieldPtrAnonymousHeadStringTagMapLoad           opType = 357
+	opStructFieldPtrAnonymousHeadStringTagSlice             opType = 358
+	opStructFieldPtrAnonymousHeadStringTagStruct            opType = 359
+	opStructFieldPtrAnonymousHeadStringTagMarshalJSON       opType = 360
+	opStructFieldPtrAnonymousHeadStringTagMarshalText       opType = 361
+	opStructFieldPtrAnonymousHeadStringTagRecursive         opType = 362
+	opStructFieldInt                                        opType = 363
+	opStructFieldInt8                                       opType = 364
+	opStructFieldInt16                                      opType = 365
+	opStructFieldInt32                                      opType = 366
+	opStructFieldInt64                                      opType = 367
+	opStructFieldUint                                       opType = 368
+	opStructFieldUint8                                      opType = 369
+	opStructFieldUint16                                     opType = 370
+	opStructFieldUint32                                     opType = 371
+	opStructFieldUint64                                     opType = 372
+	opStructFieldFloat32                                    opType = 373
+	opStructFieldFloat64                                    opType = 374
+	opStructFieldBool                                       opType = 375
+	opStructFieldString                                     opType = 376
+	opStructFieldBytes                                      opType = 377
+	opStructFieldArray                                      opType = 378
+	opStructFieldMap                                        opType = 379
+	opStructFieldMapLoad                                    opType = 380
+	opStructFieldSlice                                      opType = 381
+	opStructFieldStruct                                     opType = 382
+	opStructFieldMarshalJSON                                opType = 383
+	opStructFieldMarshalText                                opType = 384
+	opStructFieldRecursive                                  opType = 385
+	opStructFieldPtrInt                                     opType = 386
+	opStructFieldPtrInt8                                    opType = 387
+	opStructFieldPtrInt16                                   opType = 388
+	opStructFieldPtrInt32                                   opType = 389
+	opStructFieldPtrInt64                                   opType = 390
+	opStructFieldPtrUint                                    opType = 391
+	opStructFieldPtrUint8                                   opType = 392
+	opStructFieldPtrUint16                                  opType = 393
+	opStructFieldPtrUint32                                  opType = 394
+	opStructFieldPtrUint64                                  opType = 395
+	opStructFieldPtrFloat32                                 opType = 396
+	opStructFieldPtrFloat64                                 opType = 397
+	opStructFieldPtrBool                                    opType = 398
+	opStructFieldPtrString                                  opType = 399
+	opStructFieldPtrBytes                                   opType = 400
+	opStructFieldPtrArray                                   opType = 401
+	opStructFieldPtrMap                                     opType = 402
+	opStructFieldPtrMapLoad                                 opType = 403
+	opStructFieldPtrSlice                                   opType = 404
+	opStructFieldPtrStruct                                  opType = 405
+	opStructFieldPtrMarshalJSON                             opType = 406
+	opStructFieldPtrMarshalText                             opType = 407
+	opStructFieldPtrRecursive                               opType = 408
+	opStructFieldOmitEmptyInt                               opType = 409
+	opStructFieldOmitEmptyInt8                              opType = 410
+	opStructFieldOmitEmptyInt16                             opType = 411
+	opStructFieldOmitEmptyInt32                             opType = 412
+	opStructFieldOmitEmptyInt64                             opType = 413
+	opStructFieldOmitEmptyUint                              opType = 414
+	opStructFieldOmitEmptyUint8                             opType = 415
+	opStructFieldOmitEmptyUint16                            opType = 416
+	opStructFieldOmitEmptyUint32                            opType = 417
+	opStructFieldOmitEmptyUint64                            opType = 418
+	opStructFieldOmitEmptyFloat32                           opType = 419
+	opStructFieldOmitEmptyFloat64                           opType = 420
+	opStructFieldOmitEmptyBool                              opType = 421
+	opStructFieldOmitEmptyString                            opType = 422
+	opStructFieldOmitEmptyBytes                             opType = 423
+	opStructFieldOmitEmptyArray                             opType = 424
+	opStructFieldOmitEmptyMap                               opType = 425
+	opStructFieldOmitEmptyMapLoad                           opType = 426
+	opStructFieldOmitEmptySlice                             opType = 427
+	opStructFieldOmitEmptyStruct                            opType = 428
+	opStructFieldOmitEmptyMarshalJSON                       opType = 429
+	opStructFieldOmitEmptyMarshalText                       opType = 430
+	opStructFieldOmitEmptyRecursive                         opType = 431
+	opStructFieldStringTagInt                               opType = 432
+	opStructFieldStringTagInt8                              opType = 433
+	opStructFieldStringTagInt16                             opType = 434
+	opStructFieldStringTagInt32                             opType = 435
+	opStructFieldStringTagInt64                             opType = 436
+	opStructFieldStringTagUint                              opType = 437
+	opStructFieldStringTagUint8                             opType = 438
+	opStructFieldStringTagUint16                            opType = 439
+	opStructFieldStringTagUint32                            opType = 440
+	opStructFieldStringTagUint64                            opType = 441
+	opStructFieldStringTagFloat32                           opType = 442
+	opStructFieldStringTagFloat64                           opType = 443
+	opStructFieldStringTagBool                              opType = 444
+	opStructFieldStringTagString                            opType = 445
+	opStructFieldStringTagBytes                             opType = 446
+	opStructFieldStringTagArray                             opType = 447
+	opStructFieldStringTagMap                               opType = 448
+	opStructFieldStringTagMapLoad                           opType = 449
+	opStructFieldStringTagSlice                             opType = 450
+	opStructFieldStringTagStruct                            opType = 451
+	opStructFieldStringTagMarshalJSON                       opType = 452
+	opStructFieldStringTagMarshalText                       opType = 453
+	opStructFieldStringTagRecursive                         opType = 454
+	opEndIndent                                             opType = 455
+	opInterfaceIndent                                       opType = 456
+	opInterfaceEndIndent                                    opType = 457
+	opPtrIndent                                             opType = 458
+	opCustomIndent                                          opType = 459
+	opAppendJSONIndent                                      opType = 460
+	opTimeIndent                                            opType = 461
+	opDurationIndent                                        opType = 462
+	opSliceHeadIndent                                       opType = 463
+	opRootSliceHeadIndent                                   opType = 464
+	opSliceElemIndent                                       opType = 465
+	opRootSliceElemIndent                                   opType = 466
+	opSliceEndIndent                                        opType = 467
+	opArrayHeadIndent                                       opType = 468
+	opArrayElemIndent                                       opType = 469
+	opArrayEndIndent                                        opType = 470
+	opMapHeadIndent                                         opType = 471
+	opMapHeadLoadIndent                                     opType = 472
+	opMapKeyIndent                                          opType = 473
+	opMapValueIndent                                        opType = 474
+	opMapEndIndent                                          opType = 475
+	opStructFieldHeadIndent                                 opType = 476
+	opStructFieldHeadOmitEmptyIndent                        opType = 477
+	opStructFieldHeadStringTagIndent                        opType = 478
+	opStructFieldAnonymousHeadIndent                        opType = 479
+	opStructFieldAnonymousHeadOmitEmptyIndent               opType = 480
+	opStructFieldPtrAnonymousHeadOmitEmptyIndent            opType = 481
+	opStructFieldAnonymousHeadStringTagIndent               opType = 482
+	opStructFieldPtrAnonymousHeadStringTagIndent            opType = 483
+	opStructFieldPtrHeadIndent                              opType = 484
+	opStructFieldPtrHeadOmitEmptyIndent                     opType = 485
+	opStructFieldPtrHeadStringTagIndent                     opType = 486
+	opStructFieldPtrAnonymousHeadIndent                     opType = 487
+	opStructFieldIndent                                     opType = 488
+	opStructFieldOmitEmptyIndent                            opType = 489
+	opStructFieldStringTagIndent                            opType = 490
+	opStructFieldHeadOmitEmptyStringTagIndent               opType = 491
+	opStructFieldPtrHeadOmitEmptyStringTagIndent            opType = 492
+	opStructFieldAnonymousHeadOmitEmptyStringTagIndent      opType = 493
+	opStructFieldPtrAnonymousHeadOmitEmptyStringTagIndent   opType = 494
+	opStructFieldOmitEmptyStringTagIndent                   opType = 495
+	opStructFieldHeadOmitZeroIndent                         opType = 496
+	opStructFieldPtrHeadOmitZeroIndent                      opType = 497
+	opStructFieldAnonymousHeadOmitZeroIndent                opType = 498
+	opStructFieldPtrAnonymousHeadOmitZeroIndent             opType = 499
+	opStructFieldOmitZeroIndent                             opType = 500
+	opStructFieldHeadOmitZeroMethodIndent                   opType = 501
+	opStructFieldPtrHeadOmitZeroMethodIndent                opType = 502
+	opStructFieldAnonymousHeadOmitZeroMethodIndent          opType = 503
+	opStructFieldPtrAnonymousHeadOmitZeroMethodIndent       opType = 504
+	opStructFieldOmitZeroMethodIndent                       opType = 505
+	opStructFieldRecursiveEndIndent                         opType = 506
+	opStructEndIndent                                       opType = 507
+	opStructAnonymousEndIndent                              opType = 508
+	opIntIndent                                             opType = 509
+	opInt8Indent                                            opType = 510
+	opInt16Indent                                           opType = 511
+	opInt32Indent                                           opType = 512
+	opInt64Indent                                           opType = 513
+	opUintIndent                                            opType = 514
+	opUint8Indent                                           opType = 515
+	opUint16Indent                                          opType = 516
+	opUint32Indent                                          opType = 517
+	opUint64Indent                                          opType = 518
+	opFloat32Indent                                         opType = 519
+	opFloat64Indent                                         opType = 520
+	opBoolIndent                                            opType = 521
+	opStringIndent                                          opType = 522
+	opBytesIndent                                           opType = 523
+	opArrayIndent                                           opType = 524
+	opMapIndent                                             opType = 525
+	opMapLoadIndent                                         opType = 526
+	opSliceIndent                                           opType = 527
+	opStructIndent                                          opType = 528
+	opMarshalJSONIndent                                     opType = 529
+	opMarshalTextIndent                                     opType = 530
+	opRecursiveIndent                                       opType = 531
+	opIntStringIndent                                       opType = 532
+	opInt8StringIndent                                      opType = 533
+	opInt16StringIndent                                     opType = 534
+	opInt32StringIndent                                     opType = 535
+	opInt64StringIndent                                     opType = 536
+	opUintStringIndent                                      opType = 537
+	opUint8StringIndent                                     opType = 538
+	opUint16StringIndent                                    opType = 539
+	opUint32StringIndent                                    opType = 540
+	opUint64StringIndent                                    opType = 541
+	opStructFieldHeadIntIndent                              opType = 542
+	opStructFieldHeadInt8Indent                             opType = 543
+	opStructFieldHeadInt16Indent                            opType = 544
+	opStructFieldHeadInt32Indent                            opType = 545
+	opStructFieldHeadInt64Indent                            opType = 546
+	opStructFieldHeadUintIndent                             opType = 547
+	opStructFieldHeadUint8Indent                            opType = 548
+	opStructFieldHeadUint16Indent                           opType = 549
+	opStructFieldHeadUint32Indent                           opType = 550
+	opStructFieldHeadUint64Indent                           opType = 551
+	opStructFieldHeadFloat32Indent                          opType = 552
+	opStructFieldHeadFloat64Indent                          opType = 553
+	opStructFieldHeadBoolIndent                             opType = 554
+	opStructFieldHeadStringIndent                           opType = 555
+	opStructFieldHeadBytesIndent                            opType = 556
+	opStructFieldHeadArrayIndent                            opType = 557
+	opStructFieldHeadMapIndent                              opType = 558
+	opStructFieldHeadMapLoadIndent                          opType = 559
+	opStructFieldHeadSliceIndent                            opType = 560
+	opStructFieldHeadStructIndent                           opType = 561
+	opStructFieldHeadMarshalJSONIndent                      opType = 562
+	opStructFieldHeadMarshalTextIndent                      opType = 563
+	opStructFieldHeadRecursiveIndent                        opType = 564
+	opStructFieldHeadOmitEmptyIntIndent                     opType = 565
+	opStructFieldHeadOmitEmptyInt8Indent                    opType = 566
+	opStructFieldHeadOmitEmptyInt16Indent                   opType = 567
+	opStructFieldHeadOmitEmptyInt32Indent                   opType = 568
+	opStructFieldHeadOmitEmptyInt64Indent                   opType = 569
+	opStructFieldHeadOmitEmptyUintIndent                    opType = 570
+	opStructFieldHeadOmitEmptyUint8Indent                   opType = 571
+	opStructFieldHeadOmitEmptyUint16Indent                  opType = 572
+	opStructFieldHeadOmitEmptyUint32Indent                  opType = 573
+	opStructFieldHeadOmitEmptyUint64Indent                  opType = 574
+	opStructFieldHeadOmitEmptyFloat32Indent                 opType = 575
+	opStructFieldHeadOmitEmptyFloat64Indent                 opType = 576
+	opStructFieldHeadOmitEmptyBoolIndent                    opType = 577
+	opStructFieldHeadOmitEmptyStringIndent                  opType = 578
+	opStructFieldHeadOmitEmptyBytesIndent                   opType = 579
+	opStructFieldHeadOmitEmptyArrayIndent                   opType = 580
+	opStructFieldHeadOmitEmptyMapIndent                     opType = 581
+	opStructFieldHeadOmitEmptyMapLoadIndent                 opType = 582
+	opStructFieldHeadOmitEmptySliceIndent                   opType = 583
+	opStructFieldHeadOmitEmptyStructIndent                  opType = 584
+	opStructFieldHeadOmitEmptyMarshalJSONIndent             opType = 585
+	opStructFieldHeadOmitEmptyMarshalTextIndent             opType = 586
+	opStructFieldHeadOmitEmptyRecursiveIndent               opType = 587
+	opStructFieldHeadStringTagIntIndent                     opType = 588
+	opStructFieldHeadStringTagInt8Indent                    opType = 589
+	opStructFieldHeadStringTagInt16Indent                   opType = 590
+	opStructFieldHeadStringTagInt32Indent                   opType = 591
+	opStructFieldHeadStringTagInt64Indent                   opType = 592
+	opStructFieldHeadStringTagUintIndent                    opType = 593
+	opStructFieldHeadStringTagUint8Indent                   opType = 594
+	opStructFieldHeadStringTagUint16Indent                  opType = 595
+	opStructFieldHeadStringTagUint32Indent                  opType = 596
+	opStructFieldHeadStringTagUint64Indent                  opType = 597
+	opStructFieldHeadStringTagFloat32Indent                 opType = 598
+	opStructFieldHeadStringTagFloat64Indent                 opType = 599
+	opStructFieldHeadStringTagBoolIndent                    opType = 600
+	opStructFieldHeadStringTagStringIndent                  opType = 601
+	opStructFieldHeadStringTagBytesIndent                   opType = 602
+	opStructFieldHeadStringTagArrayIndent                   opType = 603
+	opStructFieldHeadStringTagMapIndent                     opType = 604
+	opStructFieldHeadStringTagMapLoadIndent                 opType = 605
+	opStructFieldHeadStringTagSliceIndent                   opType = 606
+	opStructFieldHeadStringTagStructIndent                  opType = 607
+	opStructFieldHeadStringTagMarshalJSONIndent             opType = 608
+	opStructFieldHeadStringTagMarshalTextIndent             opType = 609
+	opStructFieldHeadStringTagRecursiveIndent               opType = 610
+	opStructFieldAnonymousHeadIntIndent                     opType = 611
+	opStructFieldAnonymousHeadInt8Indent                    opType = 612
+	opStructFieldAnonymousHeadInt16Indent                   opType = 613
+	opStructFieldAnonymousHeadInt32Indent                   opType = 614
+	opStructFieldAnonymousHeadInt64Indent                   opType = 615
+	opStructFieldAnonymousHeadUintIndent                    opType = 616
+	opStructFieldAnonymousHeadUint8Indent                   opType = 617
+	opStructFieldAnonymousHeadUint16Indent                  opType = 618
+	opStructFieldAnonymousHeadUint32Indent                  opType = 619
+	opStructFieldAnonymousHeadUint64Indent                  opType = 620
+	opStructFieldAnonymousHeadFloat32Indent                 opType = 621
+	opStructFieldAnonymousHeadFloat64Indent                 opType = 622
+	opStructFieldAnonymousHeadBoolIndent                    opType = 623
+	opStructFieldAnonymousHeadStringIndent                  opType = 624
+	opStructFieldAnonymousHeadBytesIndent                   opType = 625
+	opStructFieldAnonymousHeadArrayIndent                   opType = 626
+	opStructFieldAnonymousHeadMapIndent                     opType = 627
+	opStructFieldAnonymousHeadMapLoadIndent                 opType = 628
+	opStructFieldAnonymousHeadSliceIndent                   opType = 629
+	opStructFieldAnonymousHeadStructIndent                  opType = 630
+	opStructFieldAnonymousHeadMarshalJSONIndent             opType = 631
+	opStructFieldAnonymousHeadMarshalTextIndent             opType = 632
+	opStructFieldAnonymousHeadRecursiveIndent               opType = 633
+	opStructFieldAnonymousHeadOmitEmptyIntIndent            opType = 634
+	opStructFieldAnonymousHeadOmitEmptyInt8Indent           opType = 635
+	opStructFieldAnonymousHeadOmitEmptyInt16Indent          opType = 636
+	opStructFieldAnonymousHeadOmitEmptyInt32Indent          opType = 637
+	opStructFieldAnonymousHeadOmitEmptyInt64Indent          opType = 638
+	opStructFieldAnonymousHeadOmitEmptyUintIndent           opType = 639
+	opStructFieldAnonymousHeadOmitEmptyUint8Indent          opType = 640
+	opStructFieldAnonymousHeadOmitEmptyUint16Indent         opType = 641
+	opStructFieldAnonymousHeadOmitEmptyUint32Indent         opType = 642
+	opStructFieldAnonymousHeadOmitEmptyUint64Indent         opType = 643
+	opStructFieldAnonymousHeadOmitEmptyFloat32Indent        opType = 644
+	opStructFieldAnonymousHeadOmitEmptyFloat64Indent        opType = 645
+	opStructFieldAnonymousHeadOmitEmptyBoolIndent           opType = 646
+	opStructFieldAnonymousHeadOmitEmptyStringIndent         opType = 647
+	opStructFieldAnonymousHeadOmitEmptyBytesIndent          opType = 648
+	opStructFieldAnonymousHeadOmitEmptyArrayIndent          opType = 649
+	opStructFieldAnonymousHeadOmitEmptyMapIndent            opType = 650
+	opStructFieldAnonymousHeadOmitEmptyMapLoadIndent        opType = 651
+	opStructFieldAnonymousHeadOmitEmptySliceIndent          opType = 652
+	opStructFieldAnonymousHeadOmitEmptyStructIndent         opType = 653
+	opStructFieldAnonymousHeadOmitEmptyMarshalJSONIndent    opType = 654
+	opStructFieldAnonymousHeadOmitEmptyMarshalTextIndent    opType = 655
+	opStructFieldAnonymousHeadOmitEmptyRecursiveIndent      opType = 656
+	opStructFieldAnonymousHeadStringTagIntIndent            opType = 657
+	opStructFieldAnonymousHeadStringTagInt8Indent           opType = 658
+	opStructFieldAnonymousHeadStringTagInt16Indent          opType = 659
+	opStructFieldAnonymousHeadStringTagInt32Indent          opType = 660
+	opStructFieldAnonymousHeadStringTagInt64Indent          opType = 661
+	opStructFieldAnonymousHeadStringTagUintIndent           opType = 662
+	opStructFieldAnonymousHeadStringTagUint8Indent          opType = 663
+	opStructFieldAnonymousHeadStringTagUint16Indent         opType = 664
+	opStructFieldAnonymousHeadStringTagUint32Indent         opType = 665
+	opStructFieldAnonymousHeadStringTagUint64Indent         opType = 666
+	opStructFieldAnonymousHeadStringTagFloat32Indent        opType = 667
+	opStructFieldAnonymousHeadStringTagFloat64Indent        opType = 668
+	opStructFieldAnonymousHeadStringTagBoolIndent           opType = 669
+	opStructFieldAnonymousHeadStringTagStringIndent         opType = 670
+	opStructFieldAnonymousHeadStringTagBytesIndent          opType = 671
+	opStructFieldAnonymousHeadStringTagArrayIndent          opType = 672
+	opStructFieldAnonymousHeadStringTagMapIndent            opType = 673
+	opStructFieldAnonymousHeadStringTagMapLoadIndent        opType = 674
+	opStructFieldAnonymousHeadStringTagSliceIndent          opType = 675
+	opStructFieldAnonymousHeadStringTagStructIndent         opType = 676
+	opStructFieldAnonymousHeadStringTagMarshalJSONIndent    opType = 677
+	opStructFieldAnonymousHeadStringTagMarshalTextIndent    opType = 678
+	opStructFieldAnonymousHeadStringTagRecursiveIndent      opType = 679
+	opStructFieldPtrHeadIntIndent                           opType = 680
+	opStructFieldPtrHeadInt8Indent                          opType = 681
+	opStructFieldPtrHeadInt16Indent                         opType = 682
+	opStructFieldPtrHeadInt32Indent                         opType = 683
+	opStructFieldPtrHeadInt64Indent                         opType = 684
+	opStructFieldPtrHeadUintIndent                          opType = 685
+	opStructFieldPtrHeadUint8Indent                         opType = 686
+	opStructFieldPtrHeadUint16Indent                        opType = 687
+	opStructFieldPtrHeadUint32Indent                        opType = 688
+	opStructFieldPtrHeadUint64Indent                        opType = 689
+	opStructFieldPtrHeadFloat32Indent                       opType = 690
+	opStructFieldPtrHeadFloat64Indent                       opType = 691
+	opStructFieldPtrHeadBoolIndent                          opType = 692
+	opStructFieldPtrHeadStringIndent                        opType = 693
+	opStructFieldPtrHeadBytesIndent                         opType = 694
+	opStructFieldPtrHeadArrayIndent                         opType = 695
+	opStructFieldPtrHeadMapIndent                           opType = 696
+	opStructFieldPtrHeadMapLoadIndent                       opType = 697
+	opStructFieldPtrHeadSliceIndent                         opType = 698
+	opStructFieldPtrHeadStructIndent                        opType = 699
+	opStructFieldPtrHeadMarshalJSONIndent                   opType = 700
+	opStructFieldPtrHeadMarshalTextIndent                   opType = 701
+	opStructFieldPtrHeadRecursiveIndent                     opType = 702
+	opStructFieldPtrHeadOmitEmptyIntIndent                  opType = 703
+	opStructFieldPtrHeadOmitEmptyInt8Indent                 opType = 704
+	opStructFieldPtrHeadOmitEmptyInt16Indent                opType = 705
+	opStructFieldPtrHeadOmitEmptyInt32Indent                opType = 706
+	opStructFieldPtrHeadOmitEmptyInt64Indent                opType = 707
+	opStructFieldPtrHeadOmitEmptyUintIndent                 opType = 708
+	opStructFieldPtrHeadOmitEmptyUint8Indent                opType = 709
+	opStructFieldPtrHeadOmitEmptyUint16Indent               opType = 710
+	opStructFieldPtrHeadOmitEmptyUint32Indent               opType = 711
+	opStructFieldPtrHeadOmitEmptyUint64Indent               opType = 712
+	opStructFieldPtrHeadOmitEmptyFloat32Indent              opType = 713
+	opStructFieldPtrHeadOmitEmptyFloat64Indent              opType = 714
+	opStructFieldPtrHeadOmitEmptyBoolIndent                 opType = 715
+	opStructFieldPtrHeadOmitEmptyStringIndent               opType = 716
+	opStructFieldPtrHeadOmitEmptyBytesIndent                opType = 717
+	opStructFieldPtrHeadOmitEmptyArrayIndent                opType = 718
+	opStructFieldPtrHeadOmitEmptyMapIndent                  opType = 719
+	opStructFieldPtrHeadOmitEmptyMapLoadIndent              opType = 720
+	opStructFieldPtrHeadOmitEmptySliceIndent                opType = 721
+	opStructFieldPtrHeadOmitEmptyStructIndent               opType = 722
+	opStructFieldPtrHeadOmitEmptyMarshalJSONIndent          opType = 723
+	opStructFieldPtrHeadOmitEmptyMarshalTextIndent          opType = 724
+	opStructFieldPtrHeadOmitEmptyRecursiveIndent            opType = 725
+	opStructFieldPtrHeadStringTagIntIndent                  opType = 726
+	opStructFieldPtrHeadStringTagInt8Indent                 opType = 727
+	opStructFieldPtrHeadStringTagInt16Indent                opType = 728
+	opStructFieldPtrHeadStringTagInt32Indent                opType = 729
+	opStructFieldPtrHeadStringTagInt64Indent                opType = 730
+	opStructFieldPtrHeadStringTagUintIndent                 opType = 731
+	opStructFieldPtrHeadStringTagUint8Indent                opType = 732
+	opStructFieldPtrHeadStringTagUint16Indent               opType = 733
+	opStructFieldPtrHeadStringTagUint32Indent               opType = 734
+	opStructFieldPtrHeadStringTagUint64Indent               opType = 735
+	opStructFieldPtrHeadStringTagFloat32Indent              opType = 736
+	opStructFieldPtrHeadStringTagFloat64Indent              opType = 737
+	opStructFieldPtrHeadStringTagBoolIndent                 opType = 738
+	opStructFieldPtrHeadStringTagStringIndent               opType = 739
+	opStructFieldPtrHeadStringTagBytesIndent                opType = 740
+	opStructFieldPtrHeadStringTagArrayIndent                opType = 741
+	opStructFieldPtrHeadStringTagMapIndent                  opType = 742
+	opStructFieldPtrHeadStringTagMapLoadIndent              opType = 743
+	opStructFieldPtrHeadStringTagSliceIndent                opType = 744
+	opStructFieldPtrHeadStringTagStructIndent               opType = 745
+	opStructFieldPtrHeadStringTagMarshalJSONIndent          opType = 746
+	opStructFieldPtrHeadStringTagMarshalTextIndent          opType = 747
+	opStructFieldPtrHeadStringTagRecursiveIndent            opType = 748
+	opStructFieldPtrAnonymousHeadIntIndent                  opType = 749
+	opStructFieldPtrAnonymousHeadInt8Indent                 opType = 750
+	opStructFieldPtrAnonymousHeadInt16Indent                opType = 751
+	opStructFieldPtrAnonymousHeadInt32Indent                opType = 752
+	opStructFieldPtrAnonymousHeadInt64Indent                opType = 753
+	opStructFieldPtrAnonymousHeadUintIndent                 opType = 754
+	opStructFieldPtrAnonymousHeadUint8Indent                opType = 755
+	opStructFieldPtrAnonymousHeadUint16Indent               opType = 756
+	opStructFieldPtrAnonymousHeadUint32Indent               opType = 757
+	opStructFieldPtrAnonymousHeadUint64Indent               opType = 758
+	opStructFieldPtrAnonymousHeadFloat32Indent              opType = 759
+	opStructFieldPtrAnonymousHeadFloat64Indent              opType = 760
+	opStructFieldPtrAnonymousHeadBoolIndent                 opType = 761
+	opStructFieldPtrAnonymousHeadStringIndent               opType = 762
+	opStructFieldPtrAnonymousHeadBytesIndent                opType = 763
+	opStructFieldPtrAnonymousHeadArrayIndent                opType = 764
+	opStructFieldPtrAnonymousHeadMapIndent                  opType = 765
+	opStructFieldPtrAnonymousHeadMapLoadIndent              opType = 766
+	opStructFieldPtrAnonymousHeadSliceIndent                opType = 767
+	opStructFieldPtrAnonymousHeadStructIndent               opType = 768
+	opStructFieldPtrAnonymousHeadMarshalJSONIndent          opType = 769
+	opStructFieldPtrAnonymousHeadMarshalTextIndent          opType = 770
+	opStructFieldPtrAnonymousHeadRecursiveIndent            opType = 771
+	opStructFieldPtrAnonymousHeadOmitEmptyIntIndent         opType = 772
+	opStructFieldPtrAnonymousHeadOmitEmptyInt8Indent        opType = 773
+	opStructFieldPtrAnonymousHeadOmitEmptyInt16Indent       opType = 774
+	opStructFieldPtrAnonymousHeadOmitEmptyInt32Indent       opType = 775
+	opStructFieldPtrAnonymousHeadOmitEmptyInt64Indent       opType = 776
+	opStructFieldPtrAnonymousHeadOmitEmptyUintIndent        opType = 777
+	opStructFieldPtrAnonymousHeadOmitEmptyUint8Indent       opType = 778
+	opStructFieldPtrAnonymousHeadOmitEmptyUint16Indent      opType = 779
+	opStructFieldPtrAnonymousHeadOmitEmptyUint32Indent      opType = 780
+	opStructFieldPtrAnonymousHeadOmitEmptyUint64Indent      opType = 781
+	opStructFieldPtrAnonymousHeadOmitEmptyFloat32Indent     opType = 782
+	opStructFieldPtrAnonymousHeadOmitEmptyFloat64Indent     opType = 783
+	opStructFieldPtrAnonymousHeadOmitEmptyBoolIndent        opType = 784
+	opStructFieldPtrAnonymousHeadOmitEmptyStringIndent      opType = 785
+	opStructFieldPtrAnonymousHeadOmitEmptyBytesIndent       opType = 786
+	opStructFieldPtrAnonymousHeadOmitEmptyArrayIndent       opType = 787
+	opStructFieldPtrAnonymousHeadOmitEmptyMapIndent         opType = 788
+	opStructFieldPtrAnonymousHeadOmitEmptyMapLoadIndent     opType = 789
+	opStructFieldPtrAnonymousHeadOmitEmptySliceIndent       opType = 790
+	opStructFieldPtrAnonymousHeadOmitEmptyStructIndent      opType = 791
+	opStructFieldPtrAnonymousHeadOmitEmptyMarshalJSONIndent opType = 792
+	opStructFieldPtrAnonymousHeadOmitEmptyMarshalTextIndent opType = 793
+	opStructFieldPtrAnonymousHeadOmitEmptyRecursiveIndent   opType = 794
+	opStructFieldPtrAnonymousHeadStringTagIntIndent         opType = 795
+	opStructFieldPtrAnonymousHeadStringTagInt8Indent        opType = 796
+	opStructFieldPtrAnonymousHeadStringTagInt16Indent       opType = 797
+	opStructFieldPtrAnonymousHeadStringTagInt32Indent       opType = 798
+	opStructFieldPtrAnonymousHeadStringTagInt64Indent       opType = 799
+	opStructFieldPtrAnonymousHeadStringTagUintIndent        opType = 800
+	opStructFieldPtrAnonymousHeadStringTagUint8Indent       opType = 801
+	opStructFieldPtrAnonymousHeadStringTagUint16Indent      opType = 802
+	opStructFieldPtrAnonymousHeadStringTagUint32Indent      opType = 803
+	opStructFieldPtrAnonymousHeadStringTagUint64Indent      opType = 804
+	opStructFieldPtrAnonymousHeadStringTagFloat32Indent     opType = 805
+	opStructFieldPtrAnonymousHeadStringTagFloat64Indent     opType = 806
+	opStructFieldPtrAnonymousHeadStringTagBoolIndent        opType = 807
+	opStructFieldPtrAnonymousHeadStringTagStringIndent      opType = 808
+	opStructFieldPtrAnonymousHeadStringTagBytesIndent       opType = 809
+	opStructFieldPtrAnonymousHeadStringTagArrayIndent       opType = 810
+	opStructFieldPtrAnonymousHeadStringTagMapIndent         opType = 811
+	opStructFieldPtrAnonymousHeadStringTagMapLoadIndent     opType = 812
+	opStructFieldPtrAnonymousHeadStringTagSliceIndent       opType = 813
+	opStructFieldPtrAnonymousHeadStringTagStructIndent      opType = 814
+	opStructFieldPtrAnonymousHeadStringTagMarshalJSONIndent opType = 815
+	opStructFieldPtrAnonymousHeadStringTagMarshalTextIndent opType = 816
+	opStructFieldPtrAnonymousHeadStringTagRecursiveIndent   opType = 817
+	opStructFieldIntIndent                                  opType = 818
+	opStructFieldInt8Indent                                 opType = 819
+	opStructFieldInt16Indent                                opType = 820
+	opStructFieldInt32Indent                                opType = 821
+	opStructFieldInt64Indent                                opType = 822
+	opStructFieldUintIndent                                 opType = 823
+	opStructFieldUint8Indent                                opType = 824
+	opStructFieldUint16Indent                               opType = 825
+	opStructFieldUint32Indent                               opType = 826
+	opStructFieldUint64Indent                               opType = 827
+	opStructFieldFloat32Indent                              opType = 828
+	opStructFieldFloat64Indent                              opType = 829
+	opStructFieldBoolIndent                                 opType = 830
+	opStructFieldStringIndent                               opType = 831
+	opStructFieldBytesIndent                                opType = 832
+	opStructFieldArrayIndent                                opType = 833
+	opStructFieldMapIndent                                  opType = 834
+	opStructFieldMapLoadIndent                              opType = 835
+	opStructFieldSliceIndent                                opType = 836
+	opStructFieldStructIndent                               opType = 837
+	opStructFieldMarshalJSONIndent                          opType = 838
+	opStructFieldMarshalTextIndent                          opType = 839
+	opStructFieldRecursiveIndent                            opType = 840
+	opStructFieldPtrIntIndent                               opType = 841
+	opStructFieldPtrInt8Indent                              opType = 842
+	opStructFieldPtrInt16Indent                             opType = 843
+	opStructFieldPtrInt32Indent                             opType = 844
+	opStructFieldPtrInt64Indent                             opType = 845
+	opStructFieldPtrUintIndent                              opType = 846
+	opStructFieldPtrUint8Indent                             opType = 847
+	opStructFieldPtrUint16Indent                            opType = 848
+	opStructFieldPtrUint32Indent                            opType = 849
+	opStructFieldPtrUint64Indent                            opType = 850
+	opStructFieldPtrFloat32Indent                           opType = 851
+	opStructFieldPtrFloat64Indent                           opType = 852
+	opStructFieldPtrBoolIndent                              opType = 853
+	opStructFieldPtrStringIndent                            opType = 854
+	opStructFieldPtrBytesIndent                             opType = 855
+	opStructFieldPtrArrayIndent                             opType = 856
+	opStructFieldPtrMapIndent                               opType = 857
+	opStructFieldPtrMapLoadIndent                           opType = 858
+	opStructFieldPtrSliceIndent                             opType = 859
+	opStructFieldPtrStructIndent                            opType = 860
+	opStructFieldPtrMarshalJSONIndent                       opType = 861
+	opStructFieldPtrMarshalTextIndent                       opType = 862
+	opStructFieldPtrRecursiveIndent                         opType = 863
+	opStructFieldOmitEmptyIntIndent                         opType = 864
+	opStructFieldOmitEmptyInt8Indent                        opType = 865
+	opStructFieldOmitEmptyInt16Indent                       opType = 866
+	opStructFieldOmitEmptyInt32Indent                       opType = 867
+	opStructFieldOmitEmptyInt64Indent                       opType = 868
+	opStructFieldOmitEmptyUintIndent                        opType = 869
+	opStructFieldOmitEmptyUint8Indent                       opType = 870
+	opStructFieldOmitEmptyUint16Indent                      opType = 871
+	opStructFieldOmitEmptyUint32Indent                      opType = 872
+	opStructFieldOmitEmptyUint64Indent                      opType = 873
+	opStructFieldOmitEmptyFloat32Indent                     opType = 874
+	opStructFieldOmitEmptyFloat64Indent                     opType = 875
+	opStructFieldOmitEmptyBoolIndent                        opType = 876
+	opStructFieldOmitEmptyStringIndent                      opType = 877
+	opStructFieldOmitEmptyBytesIndent                       opType = 878
+	opStructFieldOmitEmptyArrayIndent                       opType = 879
+	opStructFieldOmitEmptyMapIndent                         opType = 880
+	opStructFieldOmitEmptyMapLoadIndent                     opType = 881
+	opStructFieldOmitEmptySliceIndent                       opType = 882
+	opStructFieldOmitEmptyStructIndent                      opType = 883
+	opStructFieldOmitEmptyMarshalJSONIndent                 opType = 884
+	opStructFieldOmitEmptyMarshalTextIndent                 opType = 885
+	opStructFieldOmitEmptyRecursiveIndent                   opType = 886
+	opStructFieldStringTagIntIndent                         opType = 887
+	opStructFieldStringTagInt8Indent                        opType = 888
+	opStructFieldStringTagInt16Indent                       opType = 889
+	opStructFieldStringTagInt32Indent                       opType = 890
+	opStructFieldStringTagInt64Indent                       opType = 891
+	opStructFieldStringTagUintIndent                        opType = 892
+	opStructFieldStringTagUint8Indent                       opType = 893
+	opStructFieldStringTagUint16Indent                      opType = 894
+	opStructFieldStringTagUint32Indent                      opType = 895
+	opStructFieldStringTagUint64Indent                      opType = 896
+	opStructFieldStringTagFloat32Indent                     opType = 897
+	opStructFieldStringTagFloat64Indent                     opType = 898
+	opStructFieldStringTagBoolIndent                        opType = 899
+	opStructFieldStringTagStringIndent                      opType = 900
+	opStructFieldStringTagBytesIndent                       opType = 901
+	opStructFieldStringTagArrayIndent                       opType = 902
+	opStructFieldStringTagMapIndent                         opType = 903
+	opStructFieldStringTagMapLoadIndent                     opType = 904
+	opStructFieldStringTagSliceIndent                       opType = 905
+	opStructFieldStringTagStructIndent                      opType = 906
+	opStructFieldStringTagMarshalJSONIndent                 opType = 907
+	opStructFieldStringTagMarshalTextIndent                 opType = 908
+	opStructFieldStringTagRecursiveIndent                   opType = 909
 )
 
 func (t opType) String() string {
@@ -884,6 +942,14 @@ func (t opType) String() string {
 		return "InterfaceEnd"
 	case opPtr:
 		return "Ptr"
+	case opCustom:
+		return "Custom"
+	case opAppendJSON:
+		return "AppendJSON"
+	case opTime:
+		return "Time"
+	case opDuration:
+		return "Duration"
 	case opSliceHead:
 		return "SliceHead"
 	case opRootSliceHead:
@@ -940,6 +1006,36 @@ func (t opType) String() string {
 		return "StructFieldOmitEmpty"
 	case opStructFieldStringTag:
 		return "StructFieldStringTag"
+	case opStructFieldHeadOmitEmptyStringTag:
+		return "StructFieldHeadOmitEmptyStringTag"
+	case opStructFieldPtrHeadOmitEmptyStringTag:
+		return "StructFieldPtrHeadOmitEmptyStringTag"
+	case opStructFieldAnonymousHeadOmitEmptyStringTag:
+		return "StructFieldAnonymousHeadOmitEmptyStringTag"
+	case opStructFieldPtrAnonymousHeadOmitEmptyStringTag:
+		return "StructFieldPtrAnonymousHeadOmitEmptyStringTag"
+	case opStructFieldOmitEmptyStringTag:
+		return "StructFieldOmitEmptyStringTag"
+	case opStructFieldHeadOmitZero:
+		return "StructFieldHeadOmitZero"
+	case opStructFieldPtrHeadOmitZero:
+		return "StructFieldPtrHeadOmitZero"
+	case opStructFieldAnonymousHeadOmitZero:
+		return "StructFieldAnonymousHeadOmitZero"
+	case opStructFieldPtrAnonymousHeadOmitZero:
+		return "StructFieldPtrAnonymousHeadOmitZero"
+	case opStructFieldOmitZero:
+		return "StructFieldOmitZero"
+	case opStructFieldHeadOmitZeroMethod:
+		return "StructFieldHeadOmitZeroMethod"
+	case opStructFieldPtrHeadOmitZeroMethod:
+		return "StructFieldPtrHeadOmitZeroMethod"
+	case opStructFieldAnonymousHeadOmitZeroMethod:
+		return "StructFieldAnonymousHeadOmitZeroMethod"
+	case opStructFieldPtrAnonymousHeadOmitZeroMethod:
+		return "StructFieldPtrAnonymousHeadOmitZeroMethod"
+	case opStructFieldOmitZeroMethod:
+		return "StructFieldOmitZeroMethod"
 	case opStructFieldRecursiveEnd:
 		return "StructFieldRecursiveEnd"
 	case opStructEnd:
@@ -992,6 +1088,26 @@ func (t opType) String() string {
 		return "MarshalText"
 	case opRecursive:
 		return "Recursive"
+	case opIntString:
+		return "IntString"
+	case opInt8String:
+		return "Int8String"
+	case opInt16String:
+		return "Int16String"
+	case opInt32String:
+		return "Int32String"
+	case opInt64String:
+		return "Int64String"
+	case opUintString:
+		return "UintString"
+	case opUint8String:
+		return "Uint8String"
+	case opUint16String:
+		return "Uint16String"
+	case opUint32String:
+		return "Uint32String"
+	case opUint64String:
+		return "Uint64String"
 	case opStructFieldHeadInt:
 		return "StructFieldHeadInt"
 	case opStructFieldHeadInt8:
@@ -1736,6 +1852,14 @@ func (t opType) String() string {
 		return "InterfaceEndIndent"
 	case opPtrIndent:
 		return "PtrIndent"
+	case opCustomIndent:
+		return "CustomIndent"
+	case opAppendJSONIndent:
+		return "AppendJSONIndent"
+	case opTimeIndent:
+		return "TimeIndent"
+	case opDurationIndent:
+		return "DurationIndent"
 	case opSliceHeadIndent:
 		return "SliceHeadIndent"
 	case opRootSliceHeadIndent:
@@ -1792,6 +1916,36 @@ func (t opType) String() string {
 		return "StructFieldOmitEmptyIndent"
 	case opStructFieldStringTagIndent:
 		return "StructFieldStringTagIndent"
+	case opStructFieldHeadOmitEmptyStringTagIndent:
+		return "StructFieldHeadOmitEmptyStringTagIndent"
+	case opStructFieldPtrHeadOmitEmptyStringTagIndent:
+		return "StructFieldPtrHeadOmitEmptyStringTagIndent"
+	case opStructFieldAnonymousHeadOmitEmptyStringTagIndent:
+		return "StructFieldAnonymousHeadOmitEmptyStringTagIndent"
+	case opStructFieldPtrAnonymousHeadOmitEmptyStringTagIndent:
+		return "StructFieldPtrAnonymousHeadOmitEmptyStringTagIndent"
+	case opStructFieldOmitEmptyStringTagIndent:
+		return "StructFieldOmitEmptyStringTagIndent"
+	case opStructFieldHeadOmitZeroIndent:
+		return "StructFieldHeadOmitZeroIndent"
+	case opStructFieldPtrHeadOmitZeroIndent:
+		return "StructFieldPtrHeadOmitZeroIndent"
+	case opStructFieldAnonymousHeadOmitZeroIndent:
+		return "StructFieldAnonymousHeadOmitZeroIndent"
+	case opStructFieldPtrAnonymousHeadOmitZeroIndent:
+		return "StructFieldPtrAnonymousHeadOmitZeroIndent"
+	case opStructFieldOmitZeroIndent:
+		return "StructFieldOmitZeroIndent"
+	case opStructFieldHeadOmitZeroMethodIndent:
+		return "StructFieldHeadOmitZeroMethodIndent"
+	case opStructFieldPtrHeadOmitZeroMethodIndent:
+		return "StructFieldPtrHeadOmitZeroMethodIndent"
+	case opStructFieldAnonymousHeadOmitZeroMethodIndent:
+		return "StructFieldAnonymousHeadOmitZeroMethodIndent"
+	case opStructFieldPtrAnonymousHeadOmitZeroMethodIndent:
+		return "StructFieldPtrAnonymousHeadOmitZeroMethodIndent"
+	case opStructFieldOmitZeroMethodIndent:
+		return "StructFieldOmitZeroMethodIndent"
 	case opStructFieldRecursiveEndIndent:
 		return "StructFieldRecursiveEndIndent"
 	case opStructEndIndent:
@@ -1844,6 +1998,26 @@ func (t opType) String() string {
 		return "MarshalTextIndent"
 	case opRecursiveIndent:
 		return "RecursiveIndent"
+	case opIntStringIndent:
+		return "IntStringIndent"
+	case opInt8StringIndent:
+		return "Int8StringIndent"
+	case opInt16StringIndent:
+		return "Int16StringIndent"
+	case opInt32StringIndent:
+		return "Int32StringIndent"
+	case opInt64StringIndent:
+		return "Int64StringIndent"
+	case opUintStringIndent:
+		return "UintStringIndent"
+	case opUint8StringIndent:
+		return "Uint8StringIndent"
+	case opUint16StringIndent:
+		return "Uint16StringIndent"
+	case opUint32StringIndent:
+		return "Uint32StringIndent"
+	case opUint64StringIndent:
+		return "Uint64StringIndent"
 	case opStructFieldHeadIntIndent:
 		return "StructFieldHeadIntIndent"
 	case opStructFieldHeadInt8Indent:
@@ -2594,6 +2768,14 @@ func (t opType) codeType() codeType {
 		return codeOp
 	case opPtr:
 		return codeOp
+	case opCustom:
+		return codeOp
+	case opAppendJSON:
+		return codeOp
+	case opTime:
+		return codeOp
+	case opDuration:
+		return codeOp
 	case opSliceHead:
 		return codeSliceHead
 	case opRootSliceHead:
@@ -2650,6 +2832,36 @@ func (t opType) codeType() codeType {
 		return codeStructField
 	case opStructFieldStringTag:
 		return codeStructField
+	case opStructFieldHeadOmitEmptyStringTag:
+		return codeStructField
+	case opStructFieldPtrHeadOmitEmptyStringTag:
+		return codeStructField
+	case opStructFieldAnonymousHeadOmitEmptyStringTag:
+		return codeStructField
+	case opStructFieldPtrAnonymousHeadOmitEmptyStringTag:
+		return codeStructField
+	case opStructFieldOmitEmptyStringTag:
+		return codeStructField
+	case opStructFieldHeadOmitZero:
+		return codeStructField
+	case opStructFieldPtrHeadOmitZero:
+		return codeStructField
+	case opStructFieldAnonymousHeadOmitZero:
+		return codeStructField
+	case opStructFieldPtrAnonymousHeadOmitZero:
+		return codeStructField
+	case opStructFieldOmitZero:
+		return codeStructField
+	case opStructFieldHeadOmitZeroMethod:
+		return codeStructField
+	case opStructFieldPtrHeadOmitZeroMethod:
+		return codeStructField
+	case opStructFieldAnonymousHeadOmitZeroMethod:
+		return codeStructField
+	case opStructFieldPtrAnonymousHeadOmitZeroMethod:
+		return codeStructField
+	case opStructFieldOmitZeroMethod:
+		return codeStructField
 	case opStructFieldRecursiveEnd:
 		return codeOp
 	case opStructEnd:
@@ -2702,6 +2914,26 @@ func (t opType) codeType() codeType {
 		return codeOp
 	case opRecursive:
 		return codeOp
+	case opIntString:
+		return codeOp
+	case opInt8String:
+		return codeOp
+	case opInt16String:
+		return codeOp
+	case opInt32String:
+		return codeOp
+	case opInt64String:
+		return codeOp
+	case opUintString:
+		return codeOp
+	case opUint8String:
+		return codeOp
+	case opUint16String:
+		return codeOp
+	case opUint32String:
+		return codeOp
+	case opUint64String:
+		return codeOp
 	case opStructFieldHeadInt:
 		return codeStructField
 	case opStructFieldHeadInt8:
@@ -3446,6 +3678,14 @@ func (t opType) codeType() codeType {
 		return codeOp
 	case opPtrIndent:
 		return codeOp
+	case opCustomIndent:
+		return codeOp
+	case opAppendJSONIndent:
+		return codeOp
+	case opTimeIndent:
+		return codeOp
+	case opDurationIndent:
+		return codeOp
 	case opSliceHeadIndent:
 		return codeSliceHead
 	case opRootSliceHeadIndent:
@@ -3502,6 +3742,36 @@ func (t opType) codeType() codeType {
 		return codeStructField
 	case opStructFieldStringTagIndent:
 		return codeStructField
+	case opStructFieldHeadOmitEmptyStringTagIndent:
+		return codeStructField
+	case opStructFieldPtrHeadOmitEmptyStringTagIndent:
+		return codeStructField
+	case opStructFieldAnonymousHeadOmitEmptyStringTagIndent:
+		return codeStructField
+	case opStructFieldPtrAnonymousHeadOmitEmptyStringTagIndent:
+		return codeStructField
+	case opStructFieldOmitEmptyStringTagIndent:
+		return codeStructField
+	case opStructFieldHeadOmitZeroIndent:
+		return codeStructField
+	case opStructFieldPtrHeadOmitZeroIndent:
+		return codeStructField
+	case opStructFieldAnonymousHeadOmitZeroIndent:
+		return codeStructField
+	case opStructFieldPtrAnonymousHeadOmitZeroIndent:
+		return codeStructField
+	case opStructFieldOmitZeroIndent:
+		return codeStructField
+	case opStructFieldHeadOmitZeroMethodIndent:
+		return codeStructField
+	case opStructFieldPtrHeadOmitZeroMethodIndent:
+		return codeStructField
+	case opStructFieldAnonymousHeadOmitZeroMethodIndent:
+		return codeStructField
+	case opStructFieldPtrAnonymousHeadOmitZeroMethodIndent:
+		return codeStructField
+	case opStructFieldOmitZeroMethodIndent:
+		return codeStructField
 	case opStructFieldRecursiveEndIndent:
 		return codeOp
 	case opStructEndIndent:
@@ -3554,6 +3824,26 @@ func (t opType) codeType() codeType {
 		return codeOp
 	case opRecursiveIndent:
 		return codeOp
+	case opIntStringIndent:
+		return codeOp
+	case opInt8StringIndent:
+		return codeOp
+	case opInt16StringIndent:
+		return codeOp
+	case opInt32StringIndent:
+		return codeOp
+	case opInt64StringIndent:
+		return codeOp
+	case opUintStringIndent:
+		return codeOp
+	case opUint8StringIndent:
+		return codeOp
+	case opUint16StringIndent:
+		return codeOp
+	case opUint32StringIndent:
+		return codeOp
+	case opUint64StringIndent:
+		return codeOp
 	case opStructFieldHeadIntIndent:
 		return codeStructField
 	case opStructFieldHeadInt8Indent:
@@ -4304,6 +4594,14 @@ func (t opType) toIndent() opType {
 		return opInterfaceEndIndent
 	case opPtr:
 		return opPtrIndent
+	case opCustom:
+		return opCustomIndent
+	case opAppendJSON:
+		return opAppendJSONIndent
+	case opTime:
+		return opTimeIndent
+	case opDuration:
+		return opDurationIndent
 	case opSliceHead:
 		return opSliceHeadIndent
 	case opRootSliceHead:
@@ -4360,6 +4658,36 @@ func (t opType) toIndent() opType {
 		return opStructFieldOmitEmptyIndent
 	case opStructFieldStringTag:
 		return opStructFieldStringTagIndent
+	case opStructFieldHeadOmitEmptyStringTag:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadOmitEmptyStringTag:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldAnonymousHeadOmitEmptyStringTag:
+		return opStructFieldAnonymousHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrAnonymousHeadOmitEmptyStringTag:
+		return opStructFieldPtrAnonymousHeadOmitEmptyStringTagIndent
+	case opStructFieldOmitEmptyStringTag:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadOmitZero:
+		return opStructFieldHeadOmitZeroIndent
+	case opStructFieldPtrHeadOmitZero:
+		return opStructFieldPtrHeadOmitZeroIndent
+	case opStructFieldAnonymousHeadOmitZero:
+		return opStructFieldAnonymousHeadOmitZeroIndent
+	case opStructFieldPtrAnonymousHeadOmitZero:
+		return opStructFieldPtrAnonymousHeadOmitZeroIndent
+	case opStructFieldOmitZero:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadOmitZeroMethod:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadOmitZeroMethod:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldAnonymousHeadOmitZeroMethod:
+		return opStructFieldAnonymousHeadOmitZeroMethodIndent
+	case opStructFieldPtrAnonymousHeadOmitZeroMethod:
+		return opStructFieldPtrAnonymousHeadOmitZeroMethodIndent
+	case opStructFieldOmitZeroMethod:
+		return opStructFieldOmitZeroMethodIndent
 	case opStructFieldRecursiveEnd:
 		return opStructFieldRecursiveEndIndent
 	case opStructEnd:
@@ -4412,6 +4740,26 @@ func (t opType) toIndent() opType {
 		return opMarshalTextIndent
 	case opRecursive:
 		return opRecursiveIndent
+	case opIntString:
+		return opIntStringIndent
+	case opInt8String:
+		return opInt8StringIndent
+	case opInt16String:
+		return opInt16StringIndent
+	case opInt32String:
+		return opInt32StringIndent
+	case opInt64String:
+		return opInt64StringIndent
+	case opUintString:
+		return opUintStringIndent
+	case opUint8String:
+		return opUint8StringIndent
+	case opUint16String:
+		return opUint16StringIndent
+	case opUint32String:
+		return opUint32StringIndent
+	case opUint64String:
+		return opUint64StringIndent
 	case opStructFieldHeadInt:
 		return opStructFieldHeadIntIndent
 	case opStructFieldHeadInt8:
@@ -5156,6 +5504,14 @@ func (t opType) toIndent() opType {
 		return opInterfaceEndIndent
 	case opPtrIndent:
 		return opPtrIndent
+	case opCustomIndent:
+		return opCustomIndent
+	case opAppendJSONIndent:
+		return opAppendJSONIndent
+	case opTimeIndent:
+		return opTimeIndent
+	case opDurationIndent:
+		return opDurationIndent
 	case opSliceHeadIndent:
 		return opSliceHeadIndent
 	case opRootSliceHeadIndent:
@@ -5212,6 +5568,36 @@ func (t opType) toIndent() opType {
 		return opStructFieldOmitEmptyIndent
 	case opStructFieldStringTagIndent:
 		return opStructFieldStringTagIndent
+	case opStructFieldHeadOmitEmptyStringTagIndent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadOmitEmptyStringTagIndent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldAnonymousHeadOmitEmptyStringTagIndent:
+		return opStructFieldAnonymousHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrAnonymousHeadOmitEmptyStringTagIndent:
+		return opStructFieldPtrAnonymousHeadOmitEmptyStringTagIndent
+	case opStructFieldOmitEmptyStringTagIndent:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadOmitZeroIndent:
+		return opStructFieldHeadOmitZeroIndent
+	case opStructFieldPtrHeadOmitZeroIndent:
+		return opStructFieldPtrHeadOmitZeroIndent
+	case opStructFieldAnonymousHeadOmitZeroIndent:
+		return opStructFieldAnonymousHeadOmitZeroIndent
+	case opStructFieldPtrAnonymousHeadOmitZeroIndent:
+		return opStructFieldPtrAnonymousHeadOmitZeroIndent
+	case opStructFieldOmitZeroIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadOmitZeroMethodIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadOmitZeroMethodIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldAnonymousHeadOmitZeroMethodIndent:
+		return opStructFieldAnonymousHeadOmitZeroMethodIndent
+	case opStructFieldPtrAnonymousHeadOmitZeroMethodIndent:
+		return opStructFieldPtrAnonymousHeadOmitZeroMethodIndent
+	case opStructFieldOmitZeroMethodIndent:
+		return opStructFieldOmitZeroMethodIndent
 	case opStructFieldRecursiveEndIndent:
 		return opStructFieldRecursiveEndIndent
 	case opStructEndIndent:
@@ -5264,6 +5650,26 @@ func (t opType) toIndent() opType {
 		return opMarshalTextIndent
 	case opRecursiveIndent:
 		return opRecursiveIndent
+	case opIntStringIndent:
+		return opIntStringIndent
+	case opInt8StringIndent:
+		return opInt8StringIndent
+	case opInt16StringIndent:
+		return opInt16StringIndent
+	case opInt32StringIndent:
+		return opInt32StringIndent
+	case opInt64StringIndent:
+		return opInt64StringIndent
+	case opUintStringIndent:
+		return opUintStringIndent
+	case opUint8StringIndent:
+		return opUint8StringIndent
+	case opUint16StringIndent:
+		return opUint16StringIndent
+	case opUint32StringIndent:
+		return opUint32StringIndent
+	case opUint64StringIndent:
+		return opUint64StringIndent
 	case opStructFieldHeadIntIndent:
 		return opStructFieldHeadIntIndent
 	case opStructFieldHeadInt8Indent:
@@ -6582,6 +6988,30 @@ func (t opType) headToPtrHead() opType {
 		return opStructFieldPtrHeadStringTagRecursiveIndent
 	case opStructFieldAnonymousHeadStringTagRecursiveIndent:
 		return opStructFieldPtrAnonymousHeadStringTagRecursiveIndent
+	case opStructFieldHeadOmitEmptyStringTag:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldAnonymousHeadOmitEmptyStringTag:
+		return opStructFieldPtrAnonymousHeadOmitEmptyStringTag
+	case opStructFieldHeadOmitZero:
+		return opStructFieldPtrHeadOmitZero
+	case opStructFieldAnonymousHeadOmitZero:
+		return opStructFieldPtrAnonymousHeadOmitZero
+	case opStructFieldHeadOmitZeroMethod:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldAnonymousHeadOmitZeroMethod:
+		return opStructFieldPtrAnonymousHeadOmitZeroMethod
+	case opStructFieldHeadOmitEmptyStringTagIndent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldAnonymousHeadOmitEmptyStringTagIndent:
+		return opStructFieldPtrAnonymousHeadOmitEmptyStringTagIndent
+	case opStructFieldHeadOmitZeroIndent:
+		return opStructFieldPtrHeadOmitZeroIndent
+	case opStructFieldAnonymousHeadOmitZeroIndent:
+		return opStructFieldPtrAnonymousHeadOmitZeroIndent
+	case opStructFieldHeadOmitZeroMethodIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldAnonymousHeadOmitZeroMethodIndent:
+		return opStructFieldPtrAnonymousHeadOmitZeroMethodIndent
 	}
 	return t
 }
@@ -7164,6 +7594,30 @@ func (t opType) headToAnonymousHead() opType {
 		return opStructFieldAnonymousHeadStringTagRecursiveIndent
 	case opStructFieldPtrHeadStringTagRecursiveIndent:
 		return opStructFieldPtrAnonymousHeadStringTagRecursiveIndent
+	case opStructFieldHeadOmitEmptyStringTag:
+		return opStructFieldAnonymousHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadOmitEmptyStringTag:
+		return opStructFieldPtrAnonymousHeadOmitEmptyStringTag
+	case opStructFieldHeadOmitZero:
+		return opStructFieldAnonymousHeadOmitZero
+	case opStructFieldPtrHeadOmitZero:
+		return opStructFieldPtrAnonymousHeadOmitZero
+	case opStructFieldHeadOmitZeroMethod:
+		return opStructFieldAnonymousHeadOmitZeroMethod
+	case opStructFieldPtrHeadOmitZeroMethod:
+		return opStructFieldPtrAnonymousHeadOmitZeroMethod
+	case opStructFieldHeadOmitEmptyStringTagIndent:
+		return opStructFieldAnonymousHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadOmitEmptyStringTagIndent:
+		return opStructFieldPtrAnonymousHeadOmitEmptyStringTagIndent
+	case opStructFieldHeadOmitZeroIndent:
+		return opStructFieldAnonymousHeadOmitZeroIndent
+	case opStructFieldPtrHeadOmitZeroIndent:
+		return opStructFieldPtrAnonymousHeadOmitZeroIndent
+	case opStructFieldHeadOmitZeroMethodIndent:
+		return opStructFieldAnonymousHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadOmitZeroMethodIndent:
+		return opStructFieldPtrAnonymousHeadOmitZeroMethodIndent
 	}
 	return t
 }
@@ -8142,6 +8596,30 @@ func (t opType) ptrHeadToHead() opType {
 		return opStructFieldHeadStringTagRecursiveIndent
 	case opStructFieldPtrAnonymousHeadStringTagRecursiveIndent:
 		return opStructFieldAnonymousHeadStringTagRecursiveIndent
+	case opStructFieldPtrHeadOmitEmptyStringTag:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrAnonymousHeadOmitEmptyStringTag:
+		return opStructFieldAnonymousHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadOmitZero:
+		return opStructFieldHeadOmitZero
+	case opStructFieldPtrAnonymousHeadOmitZero:
+		return opStructFieldAnonymousHeadOmitZero
+	case opStructFieldPtrHeadOmitZeroMethod:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrAnonymousHeadOmitZeroMethod:
+		return opStructFieldAnonymousHeadOmitZeroMethod
+	case opStructFieldPtrHeadOmitEmptyStringTagIndent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrAnonymousHeadOmitEmptyStringTagIndent:
+		return opStructFieldAnonymousHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadOmitZeroIndent:
+		return opStructFieldHeadOmitZeroIndent
+	case opStructFieldPtrAnonymousHeadOmitZeroIndent:
+		return opStructFieldAnonymousHeadOmitZeroIndent
+	case opStructFieldPtrHeadOmitZeroMethodIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrAnonymousHeadOmitZeroMethodIndent:
+		return opStructFieldAnonymousHeadOmitZeroMethodIndent
 	}
 	return t
 }
@@ -8349,3 +8827,949 @@ func (t opType) fieldToStringTagField() opType {
 	}
 	return t
 }
+
+func (t opType) toOmitEmptyStringTag() opType {
+	switch t {
+	case opStructFieldHeadInt:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadInt:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldInt:
+		return opStructFieldOmitEmptyStringTag
+	case opStructFieldHeadIntIndent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadIntIndent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldIntIndent:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadInt8:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadInt8:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldInt8:
+		return opStructFieldOmitEmptyStringTag
+	case opStructFieldHeadInt8Indent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadInt8Indent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldInt8Indent:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadInt16:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadInt16:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldInt16:
+		return opStructFieldOmitEmptyStringTag
+	case opStructFieldHeadInt16Indent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadInt16Indent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldInt16Indent:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadInt32:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadInt32:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldInt32:
+		return opStructFieldOmitEmptyStringTag
+	case opStructFieldHeadInt32Indent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadInt32Indent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldInt32Indent:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadInt64:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadInt64:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldInt64:
+		return opStructFieldOmitEmptyStringTag
+	case opStructFieldHeadInt64Indent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadInt64Indent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldInt64Indent:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadUint:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadUint:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldUint:
+		return opStructFieldOmitEmptyStringTag
+	case opStructFieldHeadUintIndent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadUintIndent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldUintIndent:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadUint8:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadUint8:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldUint8:
+		return opStructFieldOmitEmptyStringTag
+	case opStructFieldHeadUint8Indent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadUint8Indent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldUint8Indent:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadUint16:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadUint16:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldUint16:
+		return opStructFieldOmitEmptyStringTag
+	case opStructFieldHeadUint16Indent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadUint16Indent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldUint16Indent:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadUint32:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadUint32:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldUint32:
+		return opStructFieldOmitEmptyStringTag
+	case opStructFieldHeadUint32Indent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadUint32Indent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldUint32Indent:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadUint64:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadUint64:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldUint64:
+		return opStructFieldOmitEmptyStringTag
+	case opStructFieldHeadUint64Indent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadUint64Indent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldUint64Indent:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadFloat32:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadFloat32:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldFloat32:
+		return opStructFieldOmitEmptyStringTag
+	case opStructFieldHeadFloat32Indent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadFloat32Indent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldFloat32Indent:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadFloat64:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadFloat64:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldFloat64:
+		return opStructFieldOmitEmptyStringTag
+	case opStructFieldHeadFloat64Indent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadFloat64Indent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldFloat64Indent:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadBool:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadBool:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldBool:
+		return opStructFieldOmitEmptyStringTag
+	case opStructFieldHeadBoolIndent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadBoolIndent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldBoolIndent:
+		return opStructFieldOmitEmptyStringTagIndent
+	case opStructFieldHeadString:
+		return opStructFieldHeadOmitEmptyStringTag
+	case opStructFieldPtrHeadString:
+		return opStructFieldPtrHeadOmitEmptyStringTag
+	case opStructFieldString:
+		return opStructFieldOmitEmptyStringTag
+	case opStructFieldHeadStringIndent:
+		return opStructFieldHeadOmitEmptyStringTagIndent
+	case opStructFieldPtrHeadStringIndent:
+		return opStructFieldPtrHeadOmitEmptyStringTagIndent
+	case opStructFieldStringIndent:
+		return opStructFieldOmitEmptyStringTagIndent
+	}
+	return t
+}
+
+func (t opType) toOmitZero() opType {
+	switch t {
+	case opStructFieldHead:
+		return opStructFieldHeadOmitZero
+	case opStructFieldPtrHead:
+		return opStructFieldPtrHeadOmitZero
+	case opStructField:
+		return opStructFieldOmitZero
+	case opStructFieldHeadIndent:
+		return opStructFieldHeadOmitZeroIndent
+	case opStructFieldPtrHeadIndent:
+		return opStructFieldPtrHeadOmitZeroIndent
+	case opStructFieldIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadInt:
+		return opStructFieldHeadOmitEmptyInt
+	case opStructFieldPtrHeadInt:
+		return opStructFieldPtrHeadOmitEmptyInt
+	case opStructFieldInt:
+		return opStructFieldOmitEmptyInt
+	case opStructFieldPtrInt:
+		return opStructFieldOmitZero
+	case opStructFieldHeadIntIndent:
+		return opStructFieldHeadOmitEmptyIntIndent
+	case opStructFieldPtrHeadIntIndent:
+		return opStructFieldPtrHeadOmitEmptyIntIndent
+	case opStructFieldIntIndent:
+		return opStructFieldOmitEmptyIntIndent
+	case opStructFieldPtrIntIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadInt8:
+		return opStructFieldHeadOmitEmptyInt8
+	case opStructFieldPtrHeadInt8:
+		return opStructFieldPtrHeadOmitEmptyInt8
+	case opStructFieldInt8:
+		return opStructFieldOmitEmptyInt8
+	case opStructFieldPtrInt8:
+		return opStructFieldOmitZero
+	case opStructFieldHeadInt8Indent:
+		return opStructFieldHeadOmitEmptyInt8Indent
+	case opStructFieldPtrHeadInt8Indent:
+		return opStructFieldPtrHeadOmitEmptyInt8Indent
+	case opStructFieldInt8Indent:
+		return opStructFieldOmitEmptyInt8Indent
+	case opStructFieldPtrInt8Indent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadInt16:
+		return opStructFieldHeadOmitEmptyInt16
+	case opStructFieldPtrHeadInt16:
+		return opStructFieldPtrHeadOmitEmptyInt16
+	case opStructFieldInt16:
+		return opStructFieldOmitEmptyInt16
+	case opStructFieldPtrInt16:
+		return opStructFieldOmitZero
+	case opStructFieldHeadInt16Indent:
+		return opStructFieldHeadOmitEmptyInt16Indent
+	case opStructFieldPtrHeadInt16Indent:
+		return opStructFieldPtrHeadOmitEmptyInt16Indent
+	case opStructFieldInt16Indent:
+		return opStructFieldOmitEmptyInt16Indent
+	case opStructFieldPtrInt16Indent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadInt32:
+		return opStructFieldHeadOmitEmptyInt32
+	case opStructFieldPtrHeadInt32:
+		return opStructFieldPtrHeadOmitEmptyInt32
+	case opStructFieldInt32:
+		return opStructFieldOmitEmptyInt32
+	case opStructFieldPtrInt32:
+		return opStructFieldOmitZero
+	case opStructFieldHeadInt32Indent:
+		return opStructFieldHeadOmitEmptyInt32Indent
+	case opStructFieldPtrHeadInt32Indent:
+		return opStructFieldPtrHeadOmitEmptyInt32Indent
+	case opStructFieldInt32Indent:
+		return opStructFieldOmitEmptyInt32Indent
+	case opStructFieldPtrInt32Indent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadInt64:
+		return opStructFieldHeadOmitEmptyInt64
+	case opStructFieldPtrHeadInt64:
+		return opStructFieldPtrHeadOmitEmptyInt64
+	case opStructFieldInt64:
+		return opStructFieldOmitEmptyInt64
+	case opStructFieldPtrInt64:
+		return opStructFieldOmitZero
+	case opStructFieldHeadInt64Indent:
+		return opStructFieldHeadOmitEmptyInt64Indent
+	case opStructFieldPtrHeadInt64Indent:
+		return opStructFieldPtrHeadOmitEmptyInt64Indent
+	case opStructFieldInt64Indent:
+		return opStructFieldOmitEmptyInt64Indent
+	case opStructFieldPtrInt64Indent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadUint:
+		return opStructFieldHeadOmitEmptyUint
+	case opStructFieldPtrHeadUint:
+		return opStructFieldPtrHeadOmitEmptyUint
+	case opStructFieldUint:
+		return opStructFieldOmitEmptyUint
+	case opStructFieldPtrUint:
+		return opStructFieldOmitZero
+	case opStructFieldHeadUintIndent:
+		return opStructFieldHeadOmitEmptyUintIndent
+	case opStructFieldPtrHeadUintIndent:
+		return opStructFieldPtrHeadOmitEmptyUintIndent
+	case opStructFieldUintIndent:
+		return opStructFieldOmitEmptyUintIndent
+	case opStructFieldPtrUintIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadUint8:
+		return opStructFieldHeadOmitEmptyUint8
+	case opStructFieldPtrHeadUint8:
+		return opStructFieldPtrHeadOmitEmptyUint8
+	case opStructFieldUint8:
+		return opStructFieldOmitEmptyUint8
+	case opStructFieldPtrUint8:
+		return opStructFieldOmitZero
+	case opStructFieldHeadUint8Indent:
+		return opStructFieldHeadOmitEmptyUint8Indent
+	case opStructFieldPtrHeadUint8Indent:
+		return opStructFieldPtrHeadOmitEmptyUint8Indent
+	case opStructFieldUint8Indent:
+		return opStructFieldOmitEmptyUint8Indent
+	case opStructFieldPtrUint8Indent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadUint16:
+		return opStructFieldHeadOmitEmptyUint16
+	case opStructFieldPtrHeadUint16:
+		return opStructFieldPtrHeadOmitEmptyUint16
+	case opStructFieldUint16:
+		return opStructFieldOmitEmptyUint16
+	case opStructFieldPtrUint16:
+		return opStructFieldOmitZero
+	case opStructFieldHeadUint16Indent:
+		return opStructFieldHeadOmitEmptyUint16Indent
+	case opStructFieldPtrHeadUint16Indent:
+		return opStructFieldPtrHeadOmitEmptyUint16Indent
+	case opStructFieldUint16Indent:
+		return opStructFieldOmitEmptyUint16Indent
+	case opStructFieldPtrUint16Indent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadUint32:
+		return opStructFieldHeadOmitEmptyUint32
+	case opStructFieldPtrHeadUint32:
+		return opStructFieldPtrHeadOmitEmptyUint32
+	case opStructFieldUint32:
+		return opStructFieldOmitEmptyUint32
+	case opStructFieldPtrUint32:
+		return opStructFieldOmitZero
+	case opStructFieldHeadUint32Indent:
+		return opStructFieldHeadOmitEmptyUint32Indent
+	case opStructFieldPtrHeadUint32Indent:
+		return opStructFieldPtrHeadOmitEmptyUint32Indent
+	case opStructFieldUint32Indent:
+		return opStructFieldOmitEmptyUint32Indent
+	case opStructFieldPtrUint32Indent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadUint64:
+		return opStructFieldHeadOmitEmptyUint64
+	case opStructFieldPtrHeadUint64:
+		return opStructFieldPtrHeadOmitEmptyUint64
+	case opStructFieldUint64:
+		return opStructFieldOmitEmptyUint64
+	case opStructFieldPtrUint64:
+		return opStructFieldOmitZero
+	case opStructFieldHeadUint64Indent:
+		return opStructFieldHeadOmitEmptyUint64Indent
+	case opStructFieldPtrHeadUint64Indent:
+		return opStructFieldPtrHeadOmitEmptyUint64Indent
+	case opStructFieldUint64Indent:
+		return opStructFieldOmitEmptyUint64Indent
+	case opStructFieldPtrUint64Indent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadFloat32:
+		return opStructFieldHeadOmitEmptyFloat32
+	case opStructFieldPtrHeadFloat32:
+		return opStructFieldPtrHeadOmitEmptyFloat32
+	case opStructFieldFloat32:
+		return opStructFieldOmitEmptyFloat32
+	case opStructFieldPtrFloat32:
+		return opStructFieldOmitZero
+	case opStructFieldHeadFloat32Indent:
+		return opStructFieldHeadOmitEmptyFloat32Indent
+	case opStructFieldPtrHeadFloat32Indent:
+		return opStructFieldPtrHeadOmitEmptyFloat32Indent
+	case opStructFieldFloat32Indent:
+		return opStructFieldOmitEmptyFloat32Indent
+	case opStructFieldPtrFloat32Indent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadFloat64:
+		return opStructFieldHeadOmitEmptyFloat64
+	case opStructFieldPtrHeadFloat64:
+		return opStructFieldPtrHeadOmitEmptyFloat64
+	case opStructFieldFloat64:
+		return opStructFieldOmitEmptyFloat64
+	case opStructFieldPtrFloat64:
+		return opStructFieldOmitZero
+	case opStructFieldHeadFloat64Indent:
+		return opStructFieldHeadOmitEmptyFloat64Indent
+	case opStructFieldPtrHeadFloat64Indent:
+		return opStructFieldPtrHeadOmitEmptyFloat64Indent
+	case opStructFieldFloat64Indent:
+		return opStructFieldOmitEmptyFloat64Indent
+	case opStructFieldPtrFloat64Indent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadBool:
+		return opStructFieldHeadOmitEmptyBool
+	case opStructFieldPtrHeadBool:
+		return opStructFieldPtrHeadOmitEmptyBool
+	case opStructFieldBool:
+		return opStructFieldOmitEmptyBool
+	case opStructFieldPtrBool:
+		return opStructFieldOmitZero
+	case opStructFieldHeadBoolIndent:
+		return opStructFieldHeadOmitEmptyBoolIndent
+	case opStructFieldPtrHeadBoolIndent:
+		return opStructFieldPtrHeadOmitEmptyBoolIndent
+	case opStructFieldBoolIndent:
+		return opStructFieldOmitEmptyBoolIndent
+	case opStructFieldPtrBoolIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadString:
+		return opStructFieldHeadOmitEmptyString
+	case opStructFieldPtrHeadString:
+		return opStructFieldPtrHeadOmitEmptyString
+	case opStructFieldString:
+		return opStructFieldOmitEmptyString
+	case opStructFieldPtrString:
+		return opStructFieldOmitZero
+	case opStructFieldHeadStringIndent:
+		return opStructFieldHeadOmitEmptyStringIndent
+	case opStructFieldPtrHeadStringIndent:
+		return opStructFieldPtrHeadOmitEmptyStringIndent
+	case opStructFieldStringIndent:
+		return opStructFieldOmitEmptyStringIndent
+	case opStructFieldPtrStringIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadBytes:
+		return opStructFieldHeadOmitZero
+	case opStructFieldPtrHeadBytes:
+		return opStructFieldPtrHeadOmitZero
+	case opStructFieldBytes:
+		return opStructFieldOmitZero
+	case opStructFieldPtrBytes:
+		return opStructFieldOmitZero
+	case opStructFieldHeadBytesIndent:
+		return opStructFieldHeadOmitZeroIndent
+	case opStructFieldPtrHeadBytesIndent:
+		return opStructFieldPtrHeadOmitZeroIndent
+	case opStructFieldBytesIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldPtrBytesIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadArray:
+		return opStructFieldHeadOmitZero
+	case opStructFieldPtrHeadArray:
+		return opStructFieldPtrHeadOmitZero
+	case opStructFieldArray:
+		return opStructFieldOmitZero
+	case opStructFieldPtrArray:
+		return opStructFieldOmitZero
+	case opStructFieldHeadArrayIndent:
+		return opStructFieldHeadOmitZeroIndent
+	case opStructFieldPtrHeadArrayIndent:
+		return opStructFieldPtrHeadOmitZeroIndent
+	case opStructFieldArrayIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldPtrArrayIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadMap:
+		return opStructFieldHeadOmitZero
+	case opStructFieldPtrHeadMap:
+		return opStructFieldPtrHeadOmitZero
+	case opStructFieldMap:
+		return opStructFieldOmitZero
+	case opStructFieldPtrMap:
+		return opStructFieldOmitZero
+	case opStructFieldHeadMapIndent:
+		return opStructFieldHeadOmitZeroIndent
+	case opStructFieldPtrHeadMapIndent:
+		return opStructFieldPtrHeadOmitZeroIndent
+	case opStructFieldMapIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldPtrMapIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadMapLoad:
+		return opStructFieldHeadOmitZero
+	case opStructFieldPtrHeadMapLoad:
+		return opStructFieldPtrHeadOmitZero
+	case opStructFieldMapLoad:
+		return opStructFieldOmitZero
+	case opStructFieldPtrMapLoad:
+		return opStructFieldOmitZero
+	case opStructFieldHeadMapLoadIndent:
+		return opStructFieldHeadOmitZeroIndent
+	case opStructFieldPtrHeadMapLoadIndent:
+		return opStructFieldPtrHeadOmitZeroIndent
+	case opStructFieldMapLoadIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldPtrMapLoadIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadSlice:
+		return opStructFieldHeadOmitZero
+	case opStructFieldPtrHeadSlice:
+		return opStructFieldPtrHeadOmitZero
+	case opStructFieldSlice:
+		return opStructFieldOmitZero
+	case opStructFieldPtrSlice:
+		return opStructFieldOmitZero
+	case opStructFieldHeadSliceIndent:
+		return opStructFieldHeadOmitZeroIndent
+	case opStructFieldPtrHeadSliceIndent:
+		return opStructFieldPtrHeadOmitZeroIndent
+	case opStructFieldSliceIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldPtrSliceIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadStruct:
+		return opStructFieldHeadOmitZero
+	case opStructFieldPtrHeadStruct:
+		return opStructFieldPtrHeadOmitZero
+	case opStructFieldStruct:
+		return opStructFieldOmitZero
+	case opStructFieldPtrStruct:
+		return opStructFieldOmitZero
+	case opStructFieldHeadStructIndent:
+		return opStructFieldHeadOmitZeroIndent
+	case opStructFieldPtrHeadStructIndent:
+		return opStructFieldPtrHeadOmitZeroIndent
+	case opStructFieldStructIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldPtrStructIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadMarshalJSON:
+		return opStructFieldHeadOmitZero
+	case opStructFieldPtrHeadMarshalJSON:
+		return opStructFieldPtrHeadOmitZero
+	case opStructFieldMarshalJSON:
+		return opStructFieldOmitZero
+	case opStructFieldPtrMarshalJSON:
+		return opStructFieldOmitZero
+	case opStructFieldHeadMarshalJSONIndent:
+		return opStructFieldHeadOmitZeroIndent
+	case opStructFieldPtrHeadMarshalJSONIndent:
+		return opStructFieldPtrHeadOmitZeroIndent
+	case opStructFieldMarshalJSONIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldPtrMarshalJSONIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadMarshalText:
+		return opStructFieldHeadOmitZero
+	case opStructFieldPtrHeadMarshalText:
+		return opStructFieldPtrHeadOmitZero
+	case opStructFieldMarshalText:
+		return opStructFieldOmitZero
+	case opStructFieldPtrMarshalText:
+		return opStructFieldOmitZero
+	case opStructFieldHeadMarshalTextIndent:
+		return opStructFieldHeadOmitZeroIndent
+	case opStructFieldPtrHeadMarshalTextIndent:
+		return opStructFieldPtrHeadOmitZeroIndent
+	case opStructFieldMarshalTextIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldPtrMarshalTextIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldHeadRecursive:
+		return opStructFieldHeadOmitZero
+	case opStructFieldPtrHeadRecursive:
+		return opStructFieldPtrHeadOmitZero
+	case opStructFieldRecursive:
+		return opStructFieldOmitZero
+	case opStructFieldPtrRecursive:
+		return opStructFieldOmitZero
+	case opStructFieldHeadRecursiveIndent:
+		return opStructFieldHeadOmitZeroIndent
+	case opStructFieldPtrHeadRecursiveIndent:
+		return opStructFieldPtrHeadOmitZeroIndent
+	case opStructFieldRecursiveIndent:
+		return opStructFieldOmitZeroIndent
+	case opStructFieldPtrRecursiveIndent:
+		return opStructFieldOmitZeroIndent
+	}
+	return t
+}
+
+func (t opType) toOmitZeroMethod() opType {
+	switch t {
+	case opStructFieldHead:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHead:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructField:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadInt:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadInt:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldInt:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrInt:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadIntIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadIntIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldIntIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrIntIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadInt8:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadInt8:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldInt8:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrInt8:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadInt8Indent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadInt8Indent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldInt8Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrInt8Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadInt16:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadInt16:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldInt16:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrInt16:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadInt16Indent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadInt16Indent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldInt16Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrInt16Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadInt32:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadInt32:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldInt32:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrInt32:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadInt32Indent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadInt32Indent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldInt32Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrInt32Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadInt64:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadInt64:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldInt64:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrInt64:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadInt64Indent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadInt64Indent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldInt64Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrInt64Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadUint:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadUint:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldUint:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrUint:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadUintIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadUintIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldUintIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrUintIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadUint8:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadUint8:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldUint8:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrUint8:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadUint8Indent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadUint8Indent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldUint8Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrUint8Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadUint16:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadUint16:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldUint16:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrUint16:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadUint16Indent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadUint16Indent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldUint16Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrUint16Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadUint32:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadUint32:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldUint32:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrUint32:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadUint32Indent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadUint32Indent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldUint32Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrUint32Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadUint64:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadUint64:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldUint64:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrUint64:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadUint64Indent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadUint64Indent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldUint64Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrUint64Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadFloat32:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadFloat32:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldFloat32:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrFloat32:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadFloat32Indent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadFloat32Indent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldFloat32Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrFloat32Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadFloat64:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadFloat64:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldFloat64:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrFloat64:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadFloat64Indent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadFloat64Indent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldFloat64Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrFloat64Indent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadBool:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadBool:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldBool:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrBool:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadBoolIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadBoolIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldBoolIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrBoolIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadString:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadString:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldString:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrString:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadStringIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadStringIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldStringIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrStringIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadBytes:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadBytes:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldBytes:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrBytes:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadBytesIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadBytesIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldBytesIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrBytesIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadArray:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadArray:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldArray:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrArray:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadArrayIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadArrayIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldArrayIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrArrayIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadMap:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadMap:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldMap:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrMap:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadMapIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadMapIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldMapIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrMapIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadMapLoad:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadMapLoad:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldMapLoad:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrMapLoad:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadMapLoadIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadMapLoadIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldMapLoadIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrMapLoadIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadSlice:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadSlice:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldSlice:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrSlice:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadSliceIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadSliceIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldSliceIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrSliceIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadStruct:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadStruct:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldStruct:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrStruct:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadStructIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadStructIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldStructIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrStructIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadMarshalJSON:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadMarshalJSON:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldMarshalJSON:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrMarshalJSON:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadMarshalJSONIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadMarshalJSONIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldMarshalJSONIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrMarshalJSONIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadMarshalText:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadMarshalText:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldMarshalText:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrMarshalText:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadMarshalTextIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadMarshalTextIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldMarshalTextIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrMarshalTextIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldHeadRecursive:
+		return opStructFieldHeadOmitZeroMethod
+	case opStructFieldPtrHeadRecursive:
+		return opStructFieldPtrHeadOmitZeroMethod
+	case opStructFieldRecursive:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldPtrRecursive:
+		return opStructFieldOmitZeroMethod
+	case opStructFieldHeadRecursiveIndent:
+		return opStructFieldHeadOmitZeroMethodIndent
+	case opStructFieldPtrHeadRecursiveIndent:
+		return opStructFieldPtrHeadOmitZeroMethodIndent
+	case opStructFieldRecursiveIndent:
+		return opStructFieldOmitZeroMethodIndent
+	case opStructFieldPtrRecursiveIndent:
+		return opStructFieldOmitZeroMethodIndent
+	}
+	return t
+}
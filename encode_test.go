@@ -10,8 +10,10 @@ import (
 	"reflect"
 	"regexp"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
+	"unsafe"
 
 	"github.com/goccy/go-json"
 )
@@ -500,6 +502,974 @@ func Test_MarshalIndent(t *testing.T) {
 			assertEq(t, "map[string]interface{}", result, string(bytes))
 		})
 	})
+	t.Run("all fields are omitted", func(t *testing.T) {
+		type T struct {
+			A int    `json:"a,omitempty"`
+			B string `json:"b,omitempty"`
+		}
+		bytes, err := json.MarshalIndent(T{}, prefix, indent)
+		assertErr(t, err)
+		assertEq(t, "struct", "{}", string(bytes))
+		bytes, err = json.MarshalIndent(struct {
+			X T   `json:"x"`
+			Y int `json:"y"`
+		}{}, prefix, indent)
+		assertErr(t, err)
+		assertEq(t, "field", "{\n-\t\"x\": {},\n-\t\"y\": 0\n-}", string(bytes))
+		bytes, err = json.MarshalIndent([]T{{}, {A: 1}}, prefix, indent)
+		assertErr(t, err)
+		assertEq(t, "slice", "[\n-\t{},\n-\t{\n-\t\t\"a\": 1\n-\t}\n-]", string(bytes))
+	})
+}
+
+func Test_FilterFields(t *testing.T) {
+	type Owner struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	type Embedded struct {
+		Kind string `json:"kind"`
+	}
+	type Repo struct {
+		ID int `json:"id"`
+		Embedded
+		Name   string           `json:"name"`
+		Owner  *Owner           `json:"owner"`
+		Owners []Owner          `json:"owners"`
+		Meta   map[string]Owner `json:"meta"`
+		Any    interface{}      `json:"any"`
+	}
+	repo := &Repo{
+		ID:       1,
+		Embedded: Embedded{Kind: "git"},
+		Name:     "go-json",
+		Owner:    &Owner{Name: "a", Email: "a@example.com"},
+		Owners:   []Owner{{Name: "b", Email: "b@example.com"}, {Name: "c", Email: "c@example.com"}},
+		Meta:     map[string]Owner{"d": {Name: "d", Email: "d@example.com"}},
+		Any:      Owner{Name: "e", Email: "e@example.com"},
+	}
+	tests := []struct {
+		name   string
+		fields []string
+		v      interface{}
+		want   string
+	}{
+		{
+			name:   "nested",
+			fields: []string{"id", "name", "owner.email"},
+			v:      repo,
+			want:   `{"id":1,"name":"go-json","owner":{"email":"a@example.com"}}`,
+		},
+		{
+			name:   "all fields of value",
+			fields: []string{"owner", "owner.email"},
+			v:      repo,
+			want:   `{"owner":{"name":"a","email":"a@example.com"}}`,
+		},
+		{
+			name:   "embedded, elements and interface",
+			fields: []string{"kind", "owners.name", "meta.email", "any.name"},
+			v:      repo,
+			want:   `{"kind":"git","owners":[{"name":"b"},{"name":"c"}],"meta":{"d":{"email":"d@example.com"}},"any":{"name":"e"}}`,
+		},
+		{
+			name:   "head field only",
+			fields: []string{"id"},
+			v:      repo,
+			want:   `{"id":1}`,
+		},
+		{
+			name:   "no fields",
+			fields: []string{},
+			v:      repo,
+			want:   `{}`,
+		},
+		{
+			name:   "null struct",
+			fields: []string{"name", "owner.name"},
+			v:      []*Repo{nil, {Name: "x"}},
+			want:   `[null,{"name":"x","owner":null}]`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bytes, err := json.MarshalWithOption(test.v, json.FilterFields(test.fields...))
+			assertErr(t, err)
+			assertEq(t, "filtered", test.want, string(bytes))
+		})
+	}
+	t.Run("indent", func(t *testing.T) {
+		v := struct {
+			A int    `json:"a"`
+			B string `json:"b"`
+			C *Owner `json:"c"`
+		}{A: 1, B: "b", C: &Owner{Name: "n", Email: "e"}}
+		bytes, err := json.MarshalIndentWithOption(v, "", "  ", json.FilterFields("b", "c.email"))
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n  \"b\": \"b\",\n  \"c\": {\n    \"email\": \"e\"\n  }\n}", string(bytes))
+		bytes, err = json.MarshalIndentWithOption(v, "", "  ", json.FilterFields("d"))
+		assertErr(t, err)
+		assertEq(t, "indent", "{}", string(bytes))
+		bytes, err = json.MarshalIndentWithOption(v, "", "  ", json.FilterFields("a", "c.id"))
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n  \"a\": 1,\n  \"c\": {}\n}", string(bytes))
+	})
+	t.Run("indent pointer to struct", func(t *testing.T) {
+		type Item struct {
+			ID    int    `json:"id"`
+			Name  string `json:"name"`
+			Owner *Owner `json:"owner"`
+		}
+		v := &Item{ID: 1, Name: "go-json", Owner: &Owner{Name: "a", Email: "a@example.com"}}
+		bytes, err := json.MarshalIndentWithOption(v, "", "  ", json.FilterFields("owner"))
+		assertErr(t, err)
+		assertEq(t, "owner", "{\n  \"owner\": {\n    \"name\": \"a\",\n    \"email\": \"a@example.com\"\n  }\n}", string(bytes))
+		bytes, err = json.MarshalIndentWithOption(v, "", "  ", json.FilterFields("owner.email"))
+		assertErr(t, err)
+		assertEq(t, "owner.email", "{\n  \"owner\": {\n    \"email\": \"a@example.com\"\n  }\n}", string(bytes))
+		bytes, err = json.MarshalIndentWithOption(&Item{ID: 2}, "", "  ", json.FilterFields("id", "owner.email"))
+		assertErr(t, err)
+		assertEq(t, "nil owner", "{\n  \"id\": 2,\n  \"owner\": null\n}", string(bytes))
+	})
+	t.Run("recursive", func(t *testing.T) {
+		type Node struct {
+			Name  string `json:"name"`
+			Value int    `json:"value"`
+			Next  *Node  `json:"next"`
+		}
+		v := &Node{Name: "a", Value: 1, Next: &Node{Name: "b", Value: 2, Next: &Node{Name: "c", Value: 3}}}
+		bytes, err := json.MarshalWithOption(v, json.FilterFields("value", "next.name", "next.next.value"))
+		assertErr(t, err)
+		assertEq(t, "recursive", `{"value":1,"next":{"name":"b","next":{"value":3}}}`, string(bytes))
+	})
+	t.Run("reuse option", func(t *testing.T) {
+		opt := json.FilterFields("name")
+		for i := 0; i < 2; i++ {
+			bytes, err := json.MarshalWithOption(repo, opt)
+			assertErr(t, err)
+			assertEq(t, "repo", `{"name":"go-json"}`, string(bytes))
+			bytes, err = json.MarshalWithOption(Owner{Name: "a", Email: "b"}, opt)
+			assertErr(t, err)
+			assertEq(t, "owner", `{"name":"a"}`, string(bytes))
+		}
+	})
+	t.Run("invalid path", func(t *testing.T) {
+		if _, err := json.MarshalWithOption(repo, json.FilterFields("owner..email")); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func Test_StructTagOptions(t *testing.T) {
+	type T struct {
+		A int    `json:"a,omitempty,string"`
+		B string `json:"b,string,omitempty"`
+		C bool   `json:"c,omitempty,string"`
+		D int8   `json:"d,string"`
+	}
+	t.Run("omitempty and string", func(t *testing.T) {
+		bytes, err := json.Marshal(T{A: 1, B: "b", C: true, D: 2})
+		assertErr(t, err)
+		assertEq(t, "all", `{"a":"1","b":"\"b\"","c":"true","d":"2"}`, string(bytes))
+		bytes, err = json.Marshal(&T{})
+		assertErr(t, err)
+		assertEq(t, "empty", `{"d":"0"}`, string(bytes))
+		bytes, err = json.MarshalIndent(T{B: "b"}, "", "  ")
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n  \"b\": \"\\\"b\\\"\",\n  \"d\": \"0\"\n}", string(bytes))
+	})
+	t.Run("strict", func(t *testing.T) {
+		type U struct {
+			A int `json:"a,omitempty,omitnothing"`
+		}
+		v := struct {
+			T
+			U []U
+		}{}
+		bytes, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "not strict", `{"d":"0","U":null}`, string(bytes))
+		_, err = json.MarshalWithOption(v, json.StrictTagOptions())
+		optErr, ok := err.(*json.UnsupportedTagOptionError)
+		if !ok {
+			t.Fatalf("expected *json.UnsupportedTagOptionError, got %T", err)
+		}
+		assertEq(t, "field", "A", optErr.Field)
+		assertEq(t, "option", "omitnothing", optErr.Option)
+		_, err = json.MarshalWithOption(T{}, json.StrictTagOptions())
+		assertErr(t, err)
+		_, err = json.Marshal(v)
+		assertErr(t, err)
+	})
+}
+
+type zeroFlag int
+
+func (f zeroFlag) IsZero() bool { return f < 0 }
+
+type zeroPtrReceiver struct {
+	N int
+}
+
+func (z *zeroPtrReceiver) IsZero() bool { return z.N == 42 }
+
+func Test_OmitZero(t *testing.T) {
+	type Inner struct {
+		A int
+		B string
+	}
+	type T struct {
+		Time  time.Time       `json:"time,omitzero"`
+		Inner Inner           `json:"inner,omitzero"`
+		Array [2]int          `json:"array,omitzero"`
+		Slice []int           `json:"slice,omitzero"`
+		Int   int             `json:"int,omitzero"`
+		Str   string          `json:"str,omitzero,string"`
+		Flag  zeroFlag        `json:"flag,omitzero"`
+		Ptr   zeroPtrReceiver `json:"ptr,omitzero"`
+		Iface interface{}     `json:"iface,omitzero"`
+		Last  int             `json:"last"`
+	}
+	t.Run("zero", func(t *testing.T) {
+		bytes, err := json.Marshal(T{})
+		assertErr(t, err)
+		assertEq(t, "zero", `{"flag":0,"ptr":{"N":0},"last":0}`, string(bytes))
+		bytes, err = json.Marshal(&T{Flag: -1, Ptr: zeroPtrReceiver{N: 42}})
+		assertErr(t, err)
+		assertEq(t, "IsZero", `{"last":0}`, string(bytes))
+	})
+	t.Run("not zero", func(t *testing.T) {
+		bytes, err := json.Marshal(T{
+			Time:  time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			Inner: Inner{B: "b"},
+			Array: [2]int{0, 1},
+			Slice: []int{},
+			Int:   1,
+			Str:   "s",
+			Flag:  1,
+			Iface: false,
+		})
+		assertErr(t, err)
+		assertEq(t, "not zero",
+			`{"time":"2020-01-02T03:04:05Z","inner":{"A":0,"B":"b"},"array":[0,1],"slice":[],"int":1,"str":"\"s\"","flag":1,"ptr":{"N":0},"iface":false,"last":0}`,
+			string(bytes),
+		)
+	})
+	t.Run("kinds", func(t *testing.T) {
+		type Padded struct {
+			A int8
+			B int64
+		}
+		type U struct {
+			Padded Padded           `json:"padded,omitzero"`
+			Float  [1]float64       `json:"float,omitzero"`
+			Strs   [2]string        `json:"strs,omitzero"`
+			Ptr    *zeroPtrReceiver `json:"ptr,omitzero"`
+			Zeroer interface {
+				IsZero() bool
+			} `json:"zeroer,omitzero"`
+		}
+		s := "s"
+		bytes, err := json.Marshal(U{Strs: [2]string{s[:0]}, Zeroer: zeroFlag(-1)})
+		assertErr(t, err)
+		assertEq(t, "zero", `{}`, string(bytes))
+		bytes, err = json.Marshal(U{
+			Padded: Padded{B: 1},
+			Float:  [1]float64{math.Copysign(0, -1)},
+			Strs:   [2]string{"", s},
+			Ptr:    &zeroPtrReceiver{N: 1},
+			Zeroer: zeroFlag(1),
+		})
+		assertErr(t, err)
+		assertEq(t, "not zero", `{"padded":{"A":0,"B":1},"float":[-0],"strs":["","s"],"ptr":{"N":1},"zeroer":1}`, string(bytes))
+		bytes, err = json.Marshal(U{Ptr: &zeroPtrReceiver{N: 42}})
+		assertErr(t, err)
+		assertEq(t, "IsZero of pointer", `{}`, string(bytes))
+	})
+	t.Run("head", func(t *testing.T) {
+		type U struct {
+			Inner Inner `json:"inner,omitzero"`
+			N     int   `json:"n"`
+		}
+		bytes, err := json.Marshal(U{})
+		assertErr(t, err)
+		assertEq(t, "zero", `{"n":0}`, string(bytes))
+		bytes, err = json.Marshal(&U{Inner: Inner{A: 1}})
+		assertErr(t, err)
+		assertEq(t, "not zero", `{"inner":{"A":1,"B":""},"n":0}`, string(bytes))
+	})
+	t.Run("indent", func(t *testing.T) {
+		v := struct {
+			A int   `json:"a"`
+			B Inner `json:"b,omitzero"`
+			C int   `json:"c,omitzero"`
+		}{A: 1}
+		bytes, err := json.MarshalIndent(v, "", "  ")
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n  \"a\": 1\n}", string(bytes))
+	})
+}
+
+type customID [2]byte
+
+type customMarshalerID [2]byte
+
+func (customMarshalerID) MarshalJSON() ([]byte, error) {
+	return []byte(`"marshaler"`), nil
+}
+
+func encodeCustomID(b []byte, p unsafe.Pointer) ([]byte, error) {
+	id := (*customID)(p)
+	return append(b, fmt.Sprintf(`"%x"`, id[:])...), nil
+}
+
+func Test_CustomEncoder(t *testing.T) {
+	json.RegisterEncoder(reflect.TypeOf(customID{}), encodeCustomID)
+	id := customID{1, 2}
+	type T struct {
+		A customID            `json:"a"`
+		B *customID           `json:"b"`
+		C []customID          `json:"c"`
+		D map[string]customID `json:"d"`
+	}
+	t.Run("global", func(t *testing.T) {
+		bytes, err := json.Marshal(id)
+		assertErr(t, err)
+		assertEq(t, "value", `"0102"`, string(bytes))
+		bytes, err = json.Marshal(&id)
+		assertErr(t, err)
+		assertEq(t, "pointer", `"0102"`, string(bytes))
+		bytes, err = json.Marshal(T{})
+		assertErr(t, err)
+		assertEq(t, "nil pointer", `{"a":"0000","b":null,"c":null,"d":null}`, string(bytes))
+		bytes, err = json.Marshal(T{A: id, B: &id, C: []customID{id}, D: map[string]customID{"k": id}})
+		assertErr(t, err)
+		assertEq(t, "struct", `{"a":"0102","b":"0102","c":["0102"],"d":{"k":"0102"}}`, string(bytes))
+	})
+	t.Run("indent", func(t *testing.T) {
+		bytes, err := json.MarshalIndent(struct {
+			A customID `json:"a"`
+		}{A: id}, "", "  ")
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n  \"a\": \"0102\"\n}", string(bytes))
+	})
+	t.Run("scoped", func(t *testing.T) {
+		type U struct {
+			ID  customMarshalerID `json:"id"`
+			Map map[string]int    `json:"map"`
+		}
+		encodeMap := func(b []byte, p unsafe.Pointer) ([]byte, error) {
+			return append(b, strconv.Itoa(len(*(*map[string]int)(p)))...), nil
+		}
+		encodeID := func(b []byte, p unsafe.Pointer) ([]byte, error) {
+			return append(b, `"custom"`...), nil
+		}
+		v := U{Map: map[string]int{"a": 1, "b": 2}}
+		bytes, err := json.MarshalWithOption(v,
+			json.CustomEncoder(reflect.TypeOf(customMarshalerID{}), encodeID),
+			json.CustomEncoder(reflect.TypeOf(map[string]int{}), encodeMap),
+		)
+		assertErr(t, err)
+		assertEq(t, "scoped", `{"id":"custom","map":2}`, string(bytes))
+		bytes, err = json.MarshalWithOption(v.Map, json.CustomEncoder(reflect.TypeOf(map[string]int{}), encodeMap))
+		assertErr(t, err)
+		assertEq(t, "root map", `2`, string(bytes))
+		bytes, err = json.Marshal(U{})
+		assertErr(t, err)
+		assertEq(t, "not scoped", `{"id":"marshaler","map":null}`, string(bytes))
+		bytes, err = json.MarshalWithOption(id, json.CustomEncoder(reflect.TypeOf(customID{}), nil))
+		assertErr(t, err)
+		assertEq(t, "disabled", `[1,2]`, string(bytes))
+	})
+	t.Run("cached", func(t *testing.T) {
+		type V struct {
+			ID customID `json:"id"`
+		}
+		encodeA := func(b []byte, p unsafe.Pointer) ([]byte, error) {
+			return append(b, `"a"`...), nil
+		}
+		encodeB := func(b []byte, p unsafe.Pointer) ([]byte, error) {
+			return append(b, `"b"`...), nil
+		}
+		before := json.EncodeCacheStats()
+		for i := 0; i < 2; i++ {
+			bytes, err := json.MarshalWithOption(V{}, json.CustomEncoder(reflect.TypeOf(customID{}), encodeA))
+			assertErr(t, err)
+			assertEq(t, "first", `{"id":"a"}`, string(bytes))
+		}
+		stats := json.EncodeCacheStats()
+		assertEq(t, "hits", before.Hits+1, stats.Hits)
+		assertEq(t, "misses", before.Misses+1, stats.Misses)
+		bytes, err := json.MarshalWithOption(V{}, json.CustomEncoder(reflect.TypeOf(customID{}), encodeB))
+		assertErr(t, err)
+		assertEq(t, "other encoder", `{"id":"b"}`, string(bytes))
+		bytes, err = json.Marshal(V{})
+		assertErr(t, err)
+		assertEq(t, "global encoder", `{"id":"0000"}`, string(bytes))
+	})
+	t.Run("compact", func(t *testing.T) {
+		encodeObject := func(b []byte, p unsafe.Pointer) ([]byte, error) {
+			return append(b, "{ \"a\" : \"<b>\",\n \"c\": [1, 2] }"...), nil
+		}
+		bytes, err := json.MarshalWithOption([]customID{id}, json.CustomEncoder(reflect.TypeOf(customID{}), encodeObject))
+		assertErr(t, err)
+		assertEq(t, "compact", `[{"a":"\u003cb\u003e","c":[1,2]}]`, string(bytes))
+	})
+	t.Run("error", func(t *testing.T) {
+		_, err := json.MarshalWithOption(id, json.CustomEncoder(reflect.TypeOf(customID{}), func(b []byte, p unsafe.Pointer) ([]byte, error) {
+			return nil, errors.New("custom error")
+		}))
+		var marshalerErr *json.MarshalerError
+		if !errors.As(err, &marshalerErr) {
+			t.Fatalf("expected MarshalerError but got %v", err)
+		}
+	})
+}
+
+type appendMarshalerID struct {
+	ID int
+}
+
+func (appendMarshalerID) MarshalJSON() ([]byte, error) {
+	return []byte(`"marshaler"`), nil
+}
+
+func (id *appendMarshalerID) AppendJSON(b []byte) ([]byte, error) {
+	if id.ID < 0 {
+		return nil, errors.New("negative id")
+	}
+	b = strconv.AppendInt(append(b, `{"id":`...), int64(id.ID), 10)
+	return append(b, '}'), nil
+}
+
+type appendMarshalerPtr struct {
+	p *int
+}
+
+func (appendMarshalerPtr) MarshalJSON() ([]byte, error) {
+	return []byte(`"marshaler"`), nil
+}
+
+func (v *appendMarshalerPtr) AppendJSON(b []byte) ([]byte, error) {
+	if v.p == nil {
+		return append(b, "0"...), nil
+	}
+	return strconv.AppendInt(b, int64(*v.p), 10), nil
+}
+
+func Test_AppendMarshaler(t *testing.T) {
+	id := appendMarshalerID{ID: 1}
+	i := 2
+	for _, tc := range []struct {
+		name     string
+		v        interface{}
+		expected string
+	}{
+		{"value", id, `{"id":1}`},
+		{"pointer", &id, `{"id":1}`},
+		{"nil pointer", (*appendMarshalerID)(nil), `null`},
+		{"direct", appendMarshalerPtr{&i}, `2`},
+		{"direct nil", appendMarshalerPtr{}, `0`},
+		{"fields", struct {
+			A appendMarshalerID  `json:"a"`
+			B *appendMarshalerID `json:"b"`
+			C *appendMarshalerID `json:"c"`
+			D appendMarshalerPtr `json:"d"`
+		}{A: id, B: &id, D: appendMarshalerPtr{&i}}, `{"a":{"id":1},"b":{"id":1},"c":null,"d":2}`},
+		{"pointer head", &struct {
+			A *appendMarshalerID `json:"a"`
+			B int                `json:"b"`
+		}{A: &id}, `{"a":{"id":1},"b":0}`},
+		{"single field", struct{ A *appendMarshalerID }{&id}, `{"A":{"id":1}}`},
+		{"slice", []appendMarshalerID{id, {ID: 2}}, `[{"id":1},{"id":2}]`},
+	} {
+		bytes, err := json.Marshal(tc.v)
+		assertErr(t, err)
+		assertEq(t, tc.name, tc.expected, string(bytes))
+		bytes, err = json.MarshalIndent(tc.v, "", "  ")
+		assertErr(t, err)
+		assertEq(t, tc.name+" indent", tc.expected, strings.NewReplacer("\n", "", " ", "").Replace(string(bytes)))
+	}
+	_, err := json.Marshal([]appendMarshalerID{{ID: -1}})
+	var marshalerErr *json.MarshalerError
+	if !errors.As(err, &marshalerErr) {
+		t.Fatalf("expected MarshalerError but got %v", err)
+	}
+}
+
+func Test_TimeFormat(t *testing.T) {
+	tm := time.Date(2021, 3, 4, 5, 6, 7, 890123456, time.UTC)
+	d := 90 * time.Minute
+	type T struct {
+		Time     time.Time      `json:"time"`
+		TimePtr  *time.Time     `json:"timePtr"`
+		Duration time.Duration  `json:"duration"`
+		DurPtr   *time.Duration `json:"durPtr"`
+	}
+	v := T{Time: tm, TimePtr: &tm, Duration: d, DurPtr: &d}
+	t.Run("default", func(t *testing.T) {
+		bytes, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "default",
+			`{"time":"2021-03-04T05:06:07.890123456Z","timePtr":"2021-03-04T05:06:07.890123456Z","duration":5400000000000,"durPtr":5400000000000}`,
+			string(bytes),
+		)
+		bytes, err = json.Marshal(T{})
+		assertErr(t, err)
+		assertEq(t, "nil", `{"time":"0001-01-01T00:00:00Z","timePtr":null,"duration":0,"durPtr":null}`, string(bytes))
+		_, err = json.Marshal(time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC))
+		if err == nil {
+			t.Fatal("expected error for year outside of range")
+		}
+	})
+	for _, tc := range []struct {
+		name     string
+		format   json.TimeFormat
+		expected string
+	}{
+		{"RFC3339", json.TimeFormatRFC3339, `"2021-03-04T05:06:07Z"`},
+		{"RFC3339Nano", json.TimeFormatRFC3339Nano, `"2021-03-04T05:06:07.890123456Z"`},
+		{"layout", json.TimeFormatLayout("2006/01/02"), `"2021/03/04"`},
+		{"escaped layout", json.TimeFormatLayout(`"2006"`), `"\"2021\""`},
+		{"unix", json.TimeFormatUnix, `1614834367`},
+		{"unix milli", json.TimeFormatUnixMilli, `1614834367890`},
+		{"unix nano", json.TimeFormatUnixNano, `1614834367890123456`},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			bytes, err := json.MarshalWithOption(v, json.EncodeTimeFormat(tc.format))
+			assertErr(t, err)
+			assertEq(t, "struct",
+				fmt.Sprintf(`{"time":%[1]s,"timePtr":%[1]s,"duration":5400000000000,"durPtr":5400000000000}`, tc.expected),
+				string(bytes),
+			)
+			bytes, err = json.MarshalWithOption(&tm, json.EncodeTimeFormat(tc.format))
+			assertErr(t, err)
+			assertEq(t, "root", tc.expected, string(bytes))
+		})
+	}
+	t.Run("duration string", func(t *testing.T) {
+		bytes, err := json.MarshalWithOption(v, json.EncodeDurationFormat(json.DurationFormatString))
+		assertErr(t, err)
+		assertEq(t, "struct",
+			`{"time":"2021-03-04T05:06:07.890123456Z","timePtr":"2021-03-04T05:06:07.890123456Z","duration":"1h30m0s","durPtr":"1h30m0s"}`,
+			string(bytes),
+		)
+		bytes, err = json.Marshal(d)
+		assertErr(t, err)
+		assertEq(t, "not affected", `5400000000000`, string(bytes))
+	})
+	t.Run("indent", func(t *testing.T) {
+		bytes, err := json.MarshalIndentWithOption(struct {
+			A time.Time     `json:"a"`
+			B time.Duration `json:"b"`
+		}{A: tm, B: d}, "", "  ", json.EncodeTimeFormat(json.TimeFormatUnix), json.EncodeDurationFormat(json.DurationFormatString))
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n  \"a\": 1614834367,\n  \"b\": \"1h30m0s\"\n}", string(bytes))
+	})
+	t.Run("omitempty", func(t *testing.T) {
+		type O struct {
+			A time.Time     `json:"a,omitempty"`
+			B time.Time     `json:"b,omitempty"`
+			C time.Duration `json:"c,omitempty"`
+			D time.Duration `json:"d,omitempty"`
+		}
+		sec := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		for _, tc := range []struct {
+			name     string
+			v        O
+			expected string
+		}{
+			{"whole second", O{A: sec, B: sec, D: d}, `{"a":"2020-01-01T00:00:00Z","b":"2020-01-01T00:00:00Z","d":5400000000000}`},
+			{"zero", O{}, `{"a":"0001-01-01T00:00:00Z","b":"0001-01-01T00:00:00Z"}`},
+		} {
+			bytes, err := json.Marshal(tc.v)
+			assertErr(t, err)
+			assertEq(t, tc.name, tc.expected, string(bytes))
+			bytes, err = json.MarshalIndent(tc.v, "", "  ")
+			assertErr(t, err)
+			assertEq(t, tc.name+" indent", tc.expected, strings.NewReplacer("\n", "", " ", "").Replace(string(bytes)))
+		}
+	})
+	t.Run("pointer", func(t *testing.T) {
+		type U struct {
+			A *time.Time     `json:"a"`
+			B *time.Time     `json:"b"`
+			C *time.Duration `json:"c"`
+			D *time.Duration `json:"d"`
+		}
+		u := U{A: &tm, C: &d}
+		opts := []json.EncodeOption{json.EncodeTimeFormat(json.TimeFormatUnix), json.EncodeDurationFormat(json.DurationFormatString)}
+		for _, tc := range []struct {
+			name     string
+			v        interface{}
+			opts     []json.EncodeOption
+			expected string
+		}{
+			{"default", u, nil, `{"a":"2021-03-04T05:06:07.890123456Z","b":null,"c":5400000000000,"d":null}`},
+			{"format", u, opts, `{"a":1614834367,"b":null,"c":"1h30m0s","d":null}`},
+			{"pointer struct", &u, opts, `{"a":1614834367,"b":null,"c":"1h30m0s","d":null}`},
+			{"single field", struct{ A *time.Time }{&tm}, opts, `{"A":1614834367}`},
+			{"pointer to pointer", func() interface{} { p := &tm; return &p }(), opts, `1614834367`},
+		} {
+			bytes, err := json.MarshalWithOption(tc.v, tc.opts...)
+			assertErr(t, err)
+			assertEq(t, tc.name, tc.expected, string(bytes))
+			bytes, err = json.MarshalIndentWithOption(tc.v, "", "  ", tc.opts...)
+			assertErr(t, err)
+			assertEq(t, tc.name+" indent", tc.expected, strings.NewReplacer("\n", "", " ", "").Replace(string(bytes)))
+		}
+		bytes, err := json.MarshalIndentWithOption(u, "", "  ", opts...)
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n  \"a\": 1614834367,\n  \"b\": null,\n  \"c\": \"1h30m0s\",\n  \"d\": null\n}", string(bytes))
+	})
+}
+
+func Test_Precompile(t *testing.T) {
+	type Item struct {
+		ID   int      `json:"id"`
+		Tags []string `json:"tags"`
+	}
+	type Order struct {
+		Items []*Item `json:"items"`
+	}
+	t.Run("compile", func(t *testing.T) {
+		assertErr(t, json.Precompile(Order{}, (*Item)(nil), nil))
+		bytes, err := json.Marshal(&Order{Items: []*Item{{ID: 1, Tags: []string{"a"}}}})
+		assertErr(t, err)
+		assertEq(t, "marshal", `{"items":[{"id":1,"tags":["a"]}]}`, string(bytes))
+		var v Order
+		assertErr(t, json.Unmarshal(bytes, &v))
+		assertEq(t, "unmarshal", 1, v.Items[0].ID)
+	})
+	t.Run("unsupported type", func(t *testing.T) {
+		type T struct {
+			C chan int
+		}
+		err := json.Precompile(Order{}, T{})
+		if _, ok := err.(*json.UnsupportedTypeError); !ok {
+			t.Fatalf("expected *json.UnsupportedTypeError, got %T", err)
+		}
+	})
+}
+
+func Test_CachePolicy(t *testing.T) {
+	type A struct {
+		A int `json:"a"`
+	}
+	type B struct {
+		B string `json:"b"`
+	}
+	defer json.SetCachePolicy(json.CachePolicyUnbounded)
+	cachedTypes := func(stats json.CacheStats) string {
+		types := []string{}
+		for _, ts := range stats.Types {
+			types = append(types, ts.Type.String())
+		}
+		return strings.Join(types, ",")
+	}
+	t.Run("unbounded", func(t *testing.T) {
+		json.SetCachePolicy(json.CachePolicyUnbounded)
+		type D struct {
+			D int `json:"d"`
+		}
+		type C struct {
+			D
+			P *int        `json:"p"`
+			I interface{} `json:"i"`
+			C []D         `json:"c"`
+		}
+		before := json.EncodeCacheStats()
+		for i := 0; i < 2; i++ {
+			_, err := json.Marshal(C{})
+			assertErr(t, err)
+		}
+		stats := json.EncodeCacheStats()
+		assertEq(t, "hits", before.Hits+1, stats.Hits)
+		assertEq(t, "misses", before.Misses+1, stats.Misses)
+		assertEq(t, "types", "json_test.C", cachedTypes(stats))
+		assertEq(t, "type hits", uint64(1), stats.Types[0].Hits)
+		program, err := json.DumpEncodeProgram(C{})
+		assertErr(t, err)
+		assertEq(t, "opcodes", len(strings.Split(program, "\n")), stats.Types[0].Opcodes)
+	})
+	t.Run("lru", func(t *testing.T) {
+		json.SetCachePolicy(json.CachePolicyLRU(1))
+		var a A
+		var b B
+		assertErr(t, json.Unmarshal([]byte(`{"a":1}`), &a))
+		assertErr(t, json.Unmarshal([]byte(`{"b":"b"}`), &b))
+		assertEq(t, "types", "*json_test.B", cachedTypes(json.DecodeCacheStats()))
+		assertErr(t, json.Unmarshal([]byte(`{"a":2}`), &a))
+		assertEq(t, "types", "*json_test.A", cachedTypes(json.DecodeCacheStats()))
+		assertEq(t, "value", 2, a.A)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		json.SetCachePolicy(json.CachePolicyDisabled)
+		before := json.EncodeCacheStats()
+		for i := 0; i < 2; i++ {
+			bytes, err := json.Marshal(A{A: 1})
+			assertErr(t, err)
+			assertEq(t, "marshal", `{"a":1}`, string(bytes))
+		}
+		stats := json.EncodeCacheStats()
+		assertEq(t, "hits", before.Hits, stats.Hits)
+		assertEq(t, "misses", before.Misses+2, stats.Misses)
+		assertEq(t, "types", 0, len(stats.Types))
+	})
+}
+
+func Test_DumpEncodeProgram(t *testing.T) {
+	type T struct {
+		A int    `json:"a"`
+		B string `json:"b"`
+	}
+	t.Run("dump", func(t *testing.T) {
+		dump, err := json.DumpEncodeProgram(T{})
+		assertErr(t, err)
+		expected := strings.Join([]string{
+			"[0]StructFieldHeadInt ([idx:0][key:a][offset:0][headIdx:0])",
+			"[1]-StructFieldString ([idx:2][key:b][offset:8][headIdx:0])",
+			"[2]StructEnd ([idx:3][key:][offset:0][headIdx:0])",
+		}, "\n")
+		assertEq(t, "dump", expected, dump)
+	})
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := json.DumpEncodeProgram(make(chan int))
+		if _, ok := err.(*json.UnsupportedTypeError); !ok {
+			t.Fatalf("expected *json.UnsupportedTypeError, got %T", err)
+		}
+	})
+	t.Run("trace", func(t *testing.T) {
+		var buf bytes.Buffer
+		bytes, err := json.MarshalWithOption(T{A: 1}, json.EncodeTrace(&buf))
+		assertErr(t, err)
+		assertEq(t, "marshal", `{"a":1,"b":""}`, string(bytes))
+		trace := regexp.MustCompile(`\[ptr:0x[0-9a-f]+\]`).ReplaceAllString(buf.String(), "[ptr]")
+		expected := strings.Join([]string{
+			"[0]StructFieldHeadInt ([idx:0][key:a][ptr])",
+			"[1]-StructFieldString ([idx:2][key:b][ptr])",
+			"[2]StructEnd ([idx:3][key:][ptr])",
+			"[2]End ([idx:3][ptr])",
+			"",
+		}, "\n")
+		assertEq(t, "trace", expected, trace)
+	})
+}
+
+type writeCounter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *writeCounter) Write(b []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(b)
+}
+
+func Test_FlushThreshold(t *testing.T) {
+	type T struct {
+		ID    int      `json:"id"`
+		Name  string   `json:"name"`
+		Tags  []string `json:"tags"`
+		Empty struct{} `json:"empty"`
+		Next  *T       `json:"next,omitempty"`
+	}
+	var v []T
+	for i := 0; i < 100; i++ {
+		v = append(v, T{ID: i, Name: "name", Tags: []string{"a", "b"}, Next: &T{ID: i}})
+	}
+	t.Run("flush", func(t *testing.T) {
+		expected, err := json.Marshal(v)
+		assertErr(t, err)
+		for _, threshold := range []int{1, 16, 1024} {
+			var w writeCounter
+			enc := json.NewEncoder(&w)
+			enc.SetFlushThreshold(threshold)
+			assertErr(t, enc.Encode(v))
+			assertEq(t, "encoded", string(expected)+"\n", w.String())
+			if w.writes < 2 {
+				t.Fatalf("expected multiple writes with threshold %d, got %d", threshold, w.writes)
+			}
+		}
+	})
+	t.Run("indent", func(t *testing.T) {
+		type U struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}
+		var u []U
+		for i := 0; i < 100; i++ {
+			u = append(u, U{ID: i, Name: "name"})
+		}
+		expected, err := json.MarshalIndent(u, "", "  ")
+		assertErr(t, err)
+		var w writeCounter
+		enc := json.NewEncoder(&w)
+		enc.SetIndent("", "  ")
+		enc.SetFlushThreshold(64)
+		assertErr(t, enc.Encode(u))
+		assertEq(t, "encoded", string(expected)+"\n", w.String())
+	})
+	t.Run("map", func(t *testing.T) {
+		m := map[string]int{}
+		expected := []byte{'{'}
+		for i := 0; i < 100; i++ {
+			key := fmt.Sprintf("key%02d", i)
+			m[key] = i
+			expected = append(expected, fmt.Sprintf(`"%s":%d,`, key, i)...)
+		}
+		expected[len(expected)-1] = '}'
+		var w writeCounter
+		enc := json.NewEncoder(&w)
+		enc.SetFlushThreshold(16)
+		assertErr(t, enc.Encode(m))
+		assertEq(t, "encoded", string(expected)+"\n", w.String())
+	})
+	t.Run("disabled", func(t *testing.T) {
+		var w writeCounter
+		assertErr(t, json.NewEncoder(&w).Encode(v))
+		assertEq(t, "writes", 1, w.writes)
+	})
+}
+
+func Test_AppendMarshal(t *testing.T) {
+	type T struct {
+		A int    `json:"a"`
+		B string `json:"b"`
+	}
+	t.Run("append", func(t *testing.T) {
+		b, err := json.AppendMarshal([]byte("prefix:"), T{A: 1, B: "b"})
+		assertErr(t, err)
+		assertEq(t, "appended", `prefix:{"a":1,"b":"b"}`, string(b))
+	})
+	t.Run("reuse", func(t *testing.T) {
+		buf := make([]byte, 0, 64)
+		for i := 0; i < 3; i++ {
+			b, err := json.AppendMarshal(buf[:0], T{A: i})
+			assertErr(t, err)
+			assertEq(t, "appended", fmt.Sprintf(`{"a":%d,"b":""}`, i), string(b))
+			if &b[0] != &buf[:1][0] {
+				t.Fatal("expected the buffer to be reused")
+			}
+		}
+	})
+	t.Run("nil", func(t *testing.T) {
+		b, err := json.AppendMarshal([]byte("["), nil)
+		assertErr(t, err)
+		assertEq(t, "appended", "[null", string(b))
+	})
+	t.Run("error", func(t *testing.T) {
+		b, err := json.AppendMarshal([]byte("prefix"), make(chan int))
+		if _, ok := err.(*json.UnsupportedTypeError); !ok {
+			t.Fatalf("expected *json.UnsupportedTypeError, got %T", err)
+		}
+		assertEq(t, "dst", "prefix", string(b))
+	})
+}
+
+func Test_Writer(t *testing.T) {
+	write := func(w *json.Writer) {
+		w.BeginObject()
+		w.Key("id")
+		w.Int(-1)
+		w.Key("name")
+		w.String("<a>")
+		w.Key("values")
+		w.BeginArray()
+		w.Uint(1)
+		w.Float(1.5)
+		w.Bool(true)
+		w.Null()
+		w.Raw([]byte(`{"raw":1}`))
+		w.BeginObject()
+		w.End()
+		w.End()
+		w.Key("empty")
+		w.BeginArray()
+		w.End()
+		w.End()
+	}
+	t.Run("compact", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := json.NewWriter(&buf)
+		write(w)
+		write(w)
+		assertErr(t, w.Flush())
+		value := `{"id":-1,"name":"\u003ca\u003e","values":[1,1.5,true,null,{"raw":1},{}],"empty":[]}`
+		assertEq(t, "output", value+"\n"+value, buf.String())
+		assertEq(t, "buffered", 0, len(w.Bytes()))
+	})
+	t.Run("indent and html", func(t *testing.T) {
+		w := json.NewWriter(nil)
+		w.SetIndent(">", "  ")
+		w.SetEscapeHTML(false)
+		write(w)
+		assertErr(t, w.Flush())
+		expected := strings.Join([]string{
+			`{`,
+			`>  "id": -1,`,
+			`>  "name": "<a>",`,
+			`>  "values": [`,
+			`>    1,`,
+			`>    1.5,`,
+			`>    true,`,
+			`>    null,`,
+			`>    {"raw":1},`,
+			`>    {}`,
+			`>  ],`,
+			`>  "empty": []`,
+			`>}`,
+		}, "\n")
+		assertEq(t, "output", expected, string(w.Bytes()))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for name, fn := range map[string]func(w *json.Writer){
+			"value without key": func(w *json.Writer) { w.BeginObject(); w.Int(1) },
+			"key in array":      func(w *json.Writer) { w.BeginArray(); w.Key("a") },
+			"key after key":     func(w *json.Writer) { w.BeginObject(); w.Key("a"); w.Key("b") },
+			"end after key":     func(w *json.Writer) { w.BeginObject(); w.Key("a"); w.End() },
+			"end":               func(w *json.Writer) { w.End() },
+		} {
+			w := json.NewWriter(nil)
+			fn(w)
+			if _, ok := w.Err().(*json.WriterError); !ok {
+				t.Fatalf("%s: expected *json.WriterError, got %v", name, w.Err())
+			}
+		}
+		w := json.NewWriter(nil)
+		w.Float(math.NaN())
+		if _, ok := w.Flush().(*json.UnsupportedValueError); !ok {
+			t.Fatalf("expected *json.UnsupportedValueError, got %v", w.Err())
+		}
+		w.Reset(nil)
+		w.Int(1)
+		assertErr(t, w.Err())
+		assertEq(t, "output", "1", string(w.Bytes()))
+	})
+	t.Run("allocs", func(t *testing.T) {
+		w := json.NewWriter(nil)
+		allocs := testing.AllocsPerRun(10, func() {
+			w.Reset(nil)
+			write(w)
+		})
+		assertEq(t, "allocs", 0.0, allocs)
+	})
+}
+
+func Test_EncoderReset(t *testing.T) {
+	type T struct {
+		A string `json:"a"`
+	}
+	var first, second bytes.Buffer
+	enc := json.NewEncoder(&first)
+	enc.SetIndent("", " ")
+	enc.SetEscapeHTML(false)
+	assertErr(t, enc.Encode(T{A: "<b>"}))
+	enc.Reset(&second)
+	assertErr(t, enc.Encode(T{A: "<d>"}))
+	assertEq(t, "first", "{\n \"a\": \"<b>\"\n}\n", first.String())
+	assertEq(t, "second", "{\n \"a\": \"<d>\"\n}\n", second.String())
 }
 
 type StringTag struct {
@@ -907,6 +1877,34 @@ func TestTextMarshalerMapKeysAreSorted(t *testing.T) {
 	}
 }
 
+func TestMarshalMapKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{"int", map[int]int{10: 1, 9: 2, -1: 3}, `{"-1":3,"10":1,"9":2}`},
+		{"int8", map[int8]bool{-128: true}, `{"-128":true}`},
+		{"uint32", map[uint32]string{2: "b", 1: "a"}, `{"1":"a","2":"b"}`},
+		{"uint64", map[uint64]int{math.MaxUint64: 1}, `{"18446744073709551615":1}`},
+		{"uintptr", map[uintptr]int{5: 1}, `{"5":1}`},
+		{"escaped string", map[string]int{"a!": 1, "a": 2, "a<": 3}, `{"a":2,"a!":1,"a\u003c":3}`},
+		{"text marshaler", map[unmarshalerText]int{{"b", "a"}: 1, {"a", "b"}: 2}, `{"a:b":2,"b:a":1}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := json.Marshal(test.v)
+			assertErr(t, err)
+			assertEq(t, "map", test.want, string(b))
+		})
+	}
+	t.Run("indent", func(t *testing.T) {
+		b, err := json.MarshalIndent(map[int64]int{2: 1, 1: 2}, "", " ")
+		assertErr(t, err)
+		assertEq(t, "map", "{\n \"1\": 2,\n \"2\": 1\n}", string(b))
+	})
+}
+
 // https://golang.org/issue/33675
 func TestNilMarshalerTextMapKey(t *testing.T) {
 	v := map[*unmarshalerText]int{
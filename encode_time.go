@@ -0,0 +1,53 @@
+package json
+
+import (
+	"strconv"
+	"time"
+)
+
+// isFormatType reports whether typ is encoded in the format of the encoder.
+// time.Duration is encoded as int64 in the default format.
+func (e *Encoder) isFormatType(typ *rtype) bool {
+	return typ == timeType || (typ == durationType && e.durationFormat == DurationFormatString)
+}
+
+func (e *Encoder) compileFormat(ctx *encodeCompileContext) *opcode {
+	var code *opcode
+	if ctx.typ == timeType {
+		code = newOpCode(ctx, opTime)
+	} else {
+		code = newOpCode(ctx, opDuration)
+	}
+	ctx.incIndex()
+	return code
+}
+
+func (e *Encoder) encodeTime(t time.Time) error {
+	f := e.timeFormat
+	switch {
+	case f.isDefault():
+		if y := t.Year(); y < 0 || y >= 10000 {
+			// the same error as time.Time.MarshalJSON
+			_, err := t.MarshalJSON()
+			return err
+		}
+		e.buf = append(e.buf, '"')
+		e.buf = t.AppendFormat(e.buf, time.RFC3339Nano)
+		e.buf = append(e.buf, '"')
+	case f.unit != 0:
+		e.buf = strconv.AppendInt(e.buf, f.unixTime(t), 10)
+	case f.escape:
+		e.encodeString(t.Format(f.layout))
+	default:
+		e.buf = append(e.buf, '"')
+		e.buf = t.AppendFormat(e.buf, f.layout)
+		e.buf = append(e.buf, '"')
+	}
+	return nil
+}
+
+func (e *Encoder) encodeDuration(d time.Duration) {
+	e.buf = append(e.buf, '"')
+	e.buf = append(e.buf, d.String()...)
+	e.buf = append(e.buf, '"')
+}
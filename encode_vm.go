@@ -9,6 +9,8 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
+	"time"
 	"unsafe"
 )
 
@@ -54,15 +56,39 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 	seenPtr := map[uintptr]struct{}{}
 	ptrOffset := uintptr(0)
 	ctxptr := ctx.ptr()
+	if e.fieldFilter != nil {
+		e.initFieldFilter()
+	}
 
 	for {
+		if e.fieldFilter != nil {
+			code = e.filterField(code)
+		}
+		if e.trace != nil {
+			e.traceOpcode(ctxptr, code)
+		}
 		switch code.op {
 		default:
 			return fmt.Errorf("failed to handle opcode. doesn't implement %s", code.op)
 		case opPtr, opPtrIndent:
-			ptr := load(ctxptr, code.idx)
+			ptr := e.ptrToPtr(load(ctxptr, code.idx))
+			if ptr == 0 {
+				// nil pointer to the value encoded by the next code
+				e.encodeNull()
+				if code.op == opPtrIndent {
+					e.encodeBytes([]byte{',', '\n'})
+				} else {
+					e.encodeByte(',')
+				}
+				code = code.next
+				for code.op == opPtr || code.op == opPtrIndent {
+					code = code.next
+				}
+				code = code.next
+				break
+			}
 			code = code.next
-			store(ctxptr, code.idx, e.ptrToPtr(ptr))
+			store(ctxptr, code.idx, ptr)
 		case opInt:
 			e.encodeInt(e.ptrToInt(load(ctxptr, code.idx)))
 			e.encodeByte(',')
@@ -167,6 +193,106 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 			e.encodeFloat64(v)
 			e.encodeBytes([]byte{',', '\n'})
 			code = code.next
+		case opIntString:
+			e.encodeByte('"')
+			e.encodeInt(e.ptrToInt(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ','})
+			code = code.next
+		case opIntStringIndent:
+			e.encodeByte('"')
+			e.encodeInt(e.ptrToInt(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ',', '\n'})
+			code = code.next
+		case opInt8String:
+			e.encodeByte('"')
+			e.encodeInt8(e.ptrToInt8(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ','})
+			code = code.next
+		case opInt8StringIndent:
+			e.encodeByte('"')
+			e.encodeInt8(e.ptrToInt8(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ',', '\n'})
+			code = code.next
+		case opInt16String:
+			e.encodeByte('"')
+			e.encodeInt16(e.ptrToInt16(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ','})
+			code = code.next
+		case opInt16StringIndent:
+			e.encodeByte('"')
+			e.encodeInt16(e.ptrToInt16(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ',', '\n'})
+			code = code.next
+		case opInt32String:
+			e.encodeByte('"')
+			e.encodeInt32(e.ptrToInt32(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ','})
+			code = code.next
+		case opInt32StringIndent:
+			e.encodeByte('"')
+			e.encodeInt32(e.ptrToInt32(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ',', '\n'})
+			code = code.next
+		case opInt64String:
+			e.encodeByte('"')
+			e.encodeInt64(e.ptrToInt64(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ','})
+			code = code.next
+		case opInt64StringIndent:
+			e.encodeByte('"')
+			e.encodeInt64(e.ptrToInt64(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ',', '\n'})
+			code = code.next
+		case opUintString:
+			e.encodeByte('"')
+			e.encodeUint(e.ptrToUint(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ','})
+			code = code.next
+		case opUintStringIndent:
+			e.encodeByte('"')
+			e.encodeUint(e.ptrToUint(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ',', '\n'})
+			code = code.next
+		case opUint8String:
+			e.encodeByte('"')
+			e.encodeUint8(e.ptrToUint8(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ','})
+			code = code.next
+		case opUint8StringIndent:
+			e.encodeByte('"')
+			e.encodeUint8(e.ptrToUint8(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ',', '\n'})
+			code = code.next
+		case opUint16String:
+			e.encodeByte('"')
+			e.encodeUint16(e.ptrToUint16(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ','})
+			code = code.next
+		case opUint16StringIndent:
+			e.encodeByte('"')
+			e.encodeUint16(e.ptrToUint16(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ',', '\n'})
+			code = code.next
+		case opUint32String:
+			e.encodeByte('"')
+			e.encodeUint32(e.ptrToUint32(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ','})
+			code = code.next
+		case opUint32StringIndent:
+			e.encodeByte('"')
+			e.encodeUint32(e.ptrToUint32(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ',', '\n'})
+			code = code.next
+		case opUint64String:
+			e.encodeByte('"')
+			e.encodeUint64(e.ptrToUint64(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ','})
+			code = code.next
+		case opUint64StringIndent:
+			e.encodeByte('"')
+			e.encodeUint64(e.ptrToUint64(load(ctxptr, code.idx)))
+			e.encodeBytes([]byte{'"', ',', '\n'})
+			code = code.next
 		case opString:
 			e.encodeString(e.ptrToString(load(ctxptr, code.idx)))
 			e.encodeByte(',')
@@ -364,6 +490,169 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 			ctxptr = ctx.ptr() + offset
 			ptrOffset = offset
 			code = code.next
+		case opCustom:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 && !code.root {
+				// nil pointer to the value
+				e.encodeNull()
+				e.encodeByte(',')
+				code = code.next
+				break
+			}
+			p := e.ptrToUnsafePtr(ptr)
+			if code.root {
+				p = unsafe.Pointer(&ptr)
+			}
+			pos := len(e.buf)
+			b, err := code.customEncoder(e.buf, p)
+			if err != nil {
+				return errMarshaler(code, err)
+			}
+			if len(b) == pos {
+				return errUnexpectedEndOfJSON(
+					fmt.Sprintf("error calling custom encoder for type %s", code.typ),
+					0,
+				)
+			}
+			var buf bytes.Buffer
+			if err := compact(&buf, b[pos:], e.enabledHTMLEscape); err != nil {
+				return err
+			}
+			e.buf = append(b[:pos], buf.Bytes()...)
+			e.encodeByte(',')
+			code = code.next
+		case opCustomIndent:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 && !code.root {
+				// nil pointer to the value
+				e.encodeNull()
+				e.encodeBytes([]byte{',', '\n'})
+				code = code.next
+				break
+			}
+			p := e.ptrToUnsafePtr(ptr)
+			if code.root {
+				p = unsafe.Pointer(&ptr)
+			}
+			b, err := code.customEncoder(nil, p)
+			if err != nil {
+				return errMarshaler(code, err)
+			}
+			if len(b) == 0 {
+				return errUnexpectedEndOfJSON(
+					fmt.Sprintf("error calling custom encoder for type %s", code.typ),
+					0,
+				)
+			}
+			var buf bytes.Buffer
+			if err := encodeWithIndent(
+				&buf,
+				b,
+				string(e.prefix)+string(bytes.Repeat(e.indentStr, code.indent)),
+				string(e.indentStr),
+			); err != nil {
+				return err
+			}
+			e.encodeBytes(buf.Bytes())
+			e.encodeBytes([]byte{',', '\n'})
+			code = code.next
+		case opTime:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 {
+				e.encodeNull()
+			} else if err := e.encodeTime(e.ptrToTime(ptr)); err != nil {
+				return errMarshaler(code, err)
+			}
+			e.encodeByte(',')
+			code = code.next
+		case opTimeIndent:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 {
+				e.encodeNull()
+			} else if err := e.encodeTime(e.ptrToTime(ptr)); err != nil {
+				return errMarshaler(code, err)
+			}
+			e.encodeBytes([]byte{',', '\n'})
+			code = code.next
+		case opDuration:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 {
+				e.encodeNull()
+			} else {
+				e.encodeDuration(e.ptrToDuration(ptr))
+			}
+			e.encodeByte(',')
+			code = code.next
+		case opDurationIndent:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 {
+				e.encodeNull()
+			} else {
+				e.encodeDuration(e.ptrToDuration(ptr))
+			}
+			e.encodeBytes([]byte{',', '\n'})
+			code = code.next
+		case opAppendJSON:
+			ptr := load(ctxptr, code.idx)
+			p := e.ptrToUnsafePtr(ptr)
+			if code.root {
+				p = unsafe.Pointer(&ptr)
+			} else if p == nil {
+				e.encodeNull()
+				e.encodeByte(',')
+				code = code.next
+				break
+			}
+			v := *(*interface{})(unsafe.Pointer(&interfaceHeader{typ: code.typ, ptr: p}))
+			pos := len(e.buf)
+			b, err := v.(appendMarshaler).AppendJSON(e.buf)
+			if err != nil {
+				return errMarshaler(code, err)
+			}
+			if len(b) == pos {
+				return errUnexpectedEndOfJSON(
+					fmt.Sprintf("error calling AppendJSON for type %s", code.typ),
+					0,
+				)
+			}
+			// the output of the generated code is already compact
+			e.buf = b
+			e.encodeByte(',')
+			code = code.next
+		case opAppendJSONIndent:
+			ptr := load(ctxptr, code.idx)
+			p := e.ptrToUnsafePtr(ptr)
+			if code.root {
+				p = unsafe.Pointer(&ptr)
+			} else if p == nil {
+				e.encodeNull()
+				e.encodeBytes([]byte{',', '\n'})
+				code = code.next
+				break
+			}
+			v := *(*interface{})(unsafe.Pointer(&interfaceHeader{typ: code.typ, ptr: p}))
+			b, err := v.(appendMarshaler).AppendJSON(nil)
+			if err != nil {
+				return errMarshaler(code, err)
+			}
+			if len(b) == 0 {
+				return errUnexpectedEndOfJSON(
+					fmt.Sprintf("error calling AppendJSON for type %s", code.typ),
+					0,
+				)
+			}
+			var buf bytes.Buffer
+			if err := encodeWithIndent(
+				&buf,
+				b,
+				string(e.prefix)+string(bytes.Repeat(e.indentStr, code.indent)),
+				string(e.indentStr),
+			); err != nil {
+				return err
+			}
+			e.encodeBytes(buf.Bytes())
+			e.encodeBytes([]byte{',', '\n'})
+			code = code.next
 		case opMarshalJSON:
 			ptr := load(ctxptr, code.idx)
 			v := e.ptrToInterface(code, ptr)
@@ -480,6 +769,9 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				}
 			}
 		case opSliceElem:
+			if err := e.flushAtBoundary(); err != nil {
+				return err
+			}
 			idx := load(ctxptr, code.elemIdx)
 			length := load(ctxptr, code.length)
 			idx++
@@ -498,7 +790,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opSliceHeadIndent:
 			p := load(ctxptr, code.idx)
 			if p == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -521,7 +812,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opRootSliceHeadIndent:
 			p := load(ctxptr, code.idx)
 			if p == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -542,6 +832,9 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				}
 			}
 		case opSliceElemIndent:
+			if err := e.flushAtBoundary(); err != nil {
+				return err
+			}
 			idx := load(ctxptr, code.elemIdx)
 			length := load(ctxptr, code.length)
 			idx++
@@ -560,6 +853,9 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				code = code.end.next
 			}
 		case opRootSliceElemIndent:
+			if err := e.flushAtBoundary(); err != nil {
+				return err
+			}
 			idx := load(ctxptr, code.elemIdx)
 			length := load(ctxptr, code.length)
 			idx++
@@ -593,6 +889,9 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				}
 			}
 		case opArrayElem:
+			if err := e.flushAtBoundary(); err != nil {
+				return err
+			}
 			idx := load(ctxptr, code.elemIdx)
 			idx++
 			if idx < code.length {
@@ -610,7 +909,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opArrayHeadIndent:
 			p := load(ctxptr, code.idx)
 			if p == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -628,6 +926,9 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				}
 			}
 		case opArrayElemIndent:
+			if err := e.flushAtBoundary(); err != nil {
+				return err
+			}
 			idx := load(ctxptr, code.elemIdx)
 			idx++
 			if idx < code.length {
@@ -666,6 +967,7 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 						posPtr := unsafe.Pointer(&pos)
 						ctx.keepRefs = append(ctx.keepRefs, posPtr)
 						store(ctxptr, code.end.mapPos, uintptr(posPtr))
+						e.sortingMaps++
 					}
 					key := mapiterkey(iter)
 					store(ctxptr, code.next.idx, uintptr(key))
@@ -707,6 +1009,7 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 						posPtr := unsafe.Pointer(&pos)
 						ctx.keepRefs = append(ctx.keepRefs, posPtr)
 						store(ctxptr, code.end.mapPos, uintptr(posPtr))
+						e.sortingMaps++
 					}
 					code = code.next
 				} else {
@@ -715,6 +1018,9 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				}
 			}
 		case opMapKey:
+			if err := e.flushAtBoundary(); err != nil {
+				return err
+			}
 			idx := load(ctxptr, code.elemIdx)
 			length := load(ctxptr, code.length)
 			idx++
@@ -764,10 +1070,12 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 			code = code.next
 		case opMapEnd:
 			// this operation only used by sorted map.
+			e.sortingMaps--
 			length := int(load(ctxptr, code.length))
 			type mapKV struct {
-				key   string
-				value string
+				key     string
+				value   string
+				sortKey string
 			}
 			kvs := make([]mapKV, 0, length)
 			ptr := load(ctxptr, code.mapPos)
@@ -782,13 +1090,15 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				} else {
 					endValue = len(e.buf)
 				}
+				key := string(e.buf[startKey:startValue])
 				kvs = append(kvs, mapKV{
-					key:   string(e.buf[startKey:startValue]),
-					value: string(e.buf[startValue:endValue]),
+					key:     key,
+					value:   string(e.buf[startValue:endValue]),
+					sortKey: mapKeyString(key),
 				})
 			}
 			sort.Slice(kvs, func(i, j int) bool {
-				return kvs[i].key < kvs[j].key
+				return kvs[i].sortKey < kvs[j].sortKey
 			})
 			buf := e.buf[pos[0]:]
 			buf = buf[:0]
@@ -805,7 +1115,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opMapHeadIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -826,6 +1135,7 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 						posPtr := unsafe.Pointer(&pos)
 						ctx.keepRefs = append(ctx.keepRefs, posPtr)
 						store(ctxptr, code.end.mapPos, uintptr(posPtr))
+						e.sortingMaps++
 					} else {
 						e.encodeIndent(code.next.indent)
 					}
@@ -842,7 +1152,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opMapHeadLoadIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				code = code.end.next
 			} else {
@@ -850,7 +1159,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				ptr = e.ptrToPtr(ptr)
 				uptr := e.ptrToUnsafePtr(ptr)
 				if uintptr(uptr) == 0 {
-					e.encodeIndent(code.indent)
 					e.encodeNull()
 					e.encodeBytes([]byte{',', '\n'})
 					code = code.end.next
@@ -873,6 +1181,7 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 						posPtr := unsafe.Pointer(&pos)
 						ctx.keepRefs = append(ctx.keepRefs, posPtr)
 						store(ctxptr, code.end.mapPos, uintptr(posPtr))
+						e.sortingMaps++
 					} else {
 						e.encodeIndent(code.next.indent)
 					}
@@ -885,6 +1194,9 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				}
 			}
 		case opMapKeyIndent:
+			if err := e.flushAtBoundary(); err != nil {
+				return err
+			}
 			idx := load(ctxptr, code.elemIdx)
 			length := load(ctxptr, code.length)
 			idx++
@@ -935,10 +1247,12 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 			code = code.next
 		case opMapEndIndent:
 			// this operation only used by sorted map
+			e.sortingMaps--
 			length := int(load(ctxptr, code.length))
 			type mapKV struct {
-				key   string
-				value string
+				key     string
+				value   string
+				sortKey string
 			}
 			kvs := make([]mapKV, 0, length)
 			ptr := load(ctxptr, code.mapPos)
@@ -952,13 +1266,15 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				} else {
 					endValue = len(e.buf)
 				}
+				key := string(e.buf[startKey:startValue])
 				kvs = append(kvs, mapKV{
-					key:   string(e.buf[startKey:startValue]),
-					value: string(e.buf[startValue:endValue]),
+					key:     key,
+					value:   string(e.buf[startValue:endValue]),
+					sortKey: mapKeyString(key),
 				})
 			}
 			sort.Slice(kvs, func(i, j int) bool {
-				return kvs[i].key < kvs[j].key
+				return kvs[i].sortKey < kvs[j].sortKey
 			})
 			buf := e.buf[pos[0]:]
 			buf = buf[:0]
@@ -1913,13 +2229,11 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else if code.next == code.end {
 				// not exists fields
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '}', ',', '\n'})
 				code = code.end.next
 				store(ctxptr, code.idx, ptr)
@@ -1938,7 +2252,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
 				if code.op == opStructFieldPtrHeadIntIndent {
-					e.encodeIndent(code.indent)
 					e.encodeNull()
 					e.encodeBytes([]byte{',', '\n'})
 				} else {
@@ -1960,12 +2273,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadInt8Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				e.encodeIndent(code.indent + 1)
 				e.encodeKey(code)
@@ -1984,7 +2295,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				e.encodeIndent(code.indent + 1)
 				e.encodeKey(code)
@@ -1999,12 +2309,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadInt32Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				e.encodeIndent(code.indent + 1)
 				e.encodeKey(code)
@@ -2019,12 +2327,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadInt64Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				e.encodeIndent(code.indent + 1)
 				e.encodeKey(code)
@@ -2039,12 +2345,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadUintIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				e.encodeIndent(code.indent + 1)
 				e.encodeKey(code)
@@ -2059,12 +2363,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadUint8Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				e.encodeIndent(code.indent + 1)
 				e.encodeKey(code)
@@ -2079,12 +2381,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadUint16Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				e.encodeIndent(code.indent + 1)
 				e.encodeKey(code)
@@ -2099,12 +2399,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadUint32Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				e.encodeIndent(code.indent + 1)
 				e.encodeKey(code)
@@ -2119,12 +2417,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadUint64Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				e.encodeIndent(code.indent + 1)
 				e.encodeKey(code)
@@ -2139,12 +2435,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadFloat32Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				e.encodeIndent(code.indent + 1)
 				e.encodeKey(code)
@@ -2159,7 +2453,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadFloat64Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -2168,7 +2461,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				if math.IsInf(v, 0) || math.IsNaN(v) {
 					return errUnsupportedFloat(v)
 				}
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				e.encodeIndent(code.indent + 1)
 				e.encodeKey(code)
@@ -2183,12 +2475,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				e.encodeIndent(code.indent + 1)
 				e.encodeKey(code)
@@ -2203,12 +2493,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadBoolIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				e.encodeIndent(code.indent + 1)
 				e.encodeKey(code)
@@ -2223,12 +2511,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadBytesIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				e.encodeIndent(code.indent + 1)
 				e.encodeKey(code)
@@ -2283,6 +2569,92 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 					store(ctxptr, code.idx, p)
 				}
 			}
+		case opStructFieldPtrHeadOmitZero:
+			ptr := load(ctxptr, code.idx)
+			if ptr != 0 {
+				store(ctxptr, code.idx, e.ptrToPtr(ptr))
+			}
+			fallthrough
+		case opStructFieldHeadOmitZero:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 {
+				e.encodeNull()
+				e.encodeByte(',')
+				code = code.end.next
+			} else {
+				e.encodeByte('{')
+				p := ptr + code.offset
+				if code.isZero(p) {
+					code = code.nextField
+				} else {
+					e.encodeKey(code)
+					code = code.next
+					store(ctxptr, code.idx, p)
+				}
+			}
+		case opStructFieldPtrAnonymousHeadOmitZero:
+			ptr := load(ctxptr, code.idx)
+			if ptr != 0 {
+				store(ctxptr, code.idx, e.ptrToPtr(ptr))
+			}
+			fallthrough
+		case opStructFieldAnonymousHeadOmitZero:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 {
+				code = code.end.next
+			} else {
+				p := ptr + code.offset
+				if code.isZero(p) {
+					code = code.nextField
+				} else {
+					e.encodeKey(code)
+					code = code.next
+					store(ctxptr, code.idx, p)
+				}
+			}
+		case opStructFieldPtrHeadOmitZeroMethod:
+			ptr := load(ctxptr, code.idx)
+			if ptr != 0 {
+				store(ctxptr, code.idx, e.ptrToPtr(ptr))
+			}
+			fallthrough
+		case opStructFieldHeadOmitZeroMethod:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 {
+				e.encodeNull()
+				e.encodeByte(',')
+				code = code.end.next
+			} else {
+				e.encodeByte('{')
+				p := ptr + code.offset
+				if code.isZero(p) {
+					code = code.nextField
+				} else {
+					e.encodeKey(code)
+					code = code.next
+					store(ctxptr, code.idx, p)
+				}
+			}
+		case opStructFieldPtrAnonymousHeadOmitZeroMethod:
+			ptr := load(ctxptr, code.idx)
+			if ptr != 0 {
+				store(ctxptr, code.idx, e.ptrToPtr(ptr))
+			}
+			fallthrough
+		case opStructFieldAnonymousHeadOmitZeroMethod:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 {
+				code = code.end.next
+			} else {
+				p := ptr + code.offset
+				if code.isZero(p) {
+					code = code.nextField
+				} else {
+					e.encodeKey(code)
+					code = code.next
+					store(ctxptr, code.idx, p)
+				}
+			}
 		case opStructFieldPtrHeadOmitEmptyInt:
 			ptr := load(ctxptr, code.idx)
 			if ptr != 0 {
@@ -3086,60 +3458,108 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 						}
 					}
 					e.encodeKey(code)
-					e.encodeString(*(*string)(unsafe.Pointer(&bytes)))
-					e.encodeByte(',')
+					e.encodeString(*(*string)(unsafe.Pointer(&bytes)))
+					e.encodeByte(',')
+					code = code.next
+				}
+			}
+		case opStructFieldPtrAnonymousHeadOmitEmptyMarshalText:
+			ptr := load(ctxptr, code.idx)
+			if ptr != 0 {
+				store(ctxptr, code.idx, e.ptrToPtr(ptr))
+			}
+			fallthrough
+		case opStructFieldAnonymousHeadOmitEmptyMarshalText:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 {
+				code = code.end.next
+			} else {
+				ptr += code.offset
+				p := e.ptrToUnsafePtr(ptr)
+				isPtr := code.typ.Kind() == reflect.Ptr
+				if p == nil || (!isPtr && *(*unsafe.Pointer)(p) == nil) {
+					code = code.nextField
+				} else {
+					v := *(*interface{})(unsafe.Pointer(&interfaceHeader{typ: code.typ, ptr: p}))
+					bytes, err := v.(encoding.TextMarshaler).MarshalText()
+					if err != nil {
+						return &MarshalerError{
+							Type: rtype2type(code.typ),
+							Err:  err,
+						}
+					}
+					e.encodeKey(code)
+					e.encodeString(*(*string)(unsafe.Pointer(&bytes)))
+					e.encodeByte(',')
+					code = code.next
+				}
+			}
+		case opStructFieldPtrHeadOmitEmptyIndent:
+			ptr := load(ctxptr, code.idx)
+			if ptr != 0 {
+				store(ctxptr, code.idx, e.ptrToPtr(ptr))
+			}
+			fallthrough
+		case opStructFieldHeadOmitEmptyIndent:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 {
+				e.encodeNull()
+				e.encodeBytes([]byte{',', '\n'})
+				code = code.end.next
+			} else {
+				e.encodeBytes([]byte{'{', '\n'})
+				p := ptr + code.offset
+				if p == 0 || *(*uintptr)(*(*unsafe.Pointer)(unsafe.Pointer(&p))) == 0 {
+					code = code.nextField
+				} else {
+					e.encodeIndent(code.indent + 1)
+					e.encodeKey(code)
+					e.encodeByte(' ')
 					code = code.next
+					store(ctxptr, code.idx, p)
 				}
 			}
-		case opStructFieldPtrAnonymousHeadOmitEmptyMarshalText:
+		case opStructFieldPtrHeadOmitZeroIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr != 0 {
 				store(ctxptr, code.idx, e.ptrToPtr(ptr))
 			}
 			fallthrough
-		case opStructFieldAnonymousHeadOmitEmptyMarshalText:
+		case opStructFieldHeadOmitZeroIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
+				e.encodeNull()
+				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				ptr += code.offset
-				p := e.ptrToUnsafePtr(ptr)
-				isPtr := code.typ.Kind() == reflect.Ptr
-				if p == nil || (!isPtr && *(*unsafe.Pointer)(p) == nil) {
+				e.encodeBytes([]byte{'{', '\n'})
+				p := ptr + code.offset
+				if code.isZero(p) {
 					code = code.nextField
 				} else {
-					v := *(*interface{})(unsafe.Pointer(&interfaceHeader{typ: code.typ, ptr: p}))
-					bytes, err := v.(encoding.TextMarshaler).MarshalText()
-					if err != nil {
-						return &MarshalerError{
-							Type: rtype2type(code.typ),
-							Err:  err,
-						}
-					}
+					e.encodeIndent(code.indent + 1)
 					e.encodeKey(code)
-					e.encodeString(*(*string)(unsafe.Pointer(&bytes)))
-					e.encodeByte(',')
+					e.encodeByte(' ')
 					code = code.next
+					store(ctxptr, code.idx, p)
 				}
 			}
-		case opStructFieldPtrHeadOmitEmptyIndent:
+		case opStructFieldPtrHeadOmitZeroMethodIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr != 0 {
 				store(ctxptr, code.idx, e.ptrToPtr(ptr))
 			}
 			fallthrough
-		case opStructFieldHeadOmitEmptyIndent:
+		case opStructFieldHeadOmitZeroMethodIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				p := ptr + code.offset
-				if p == 0 || *(*uintptr)(*(*unsafe.Pointer)(unsafe.Pointer(&p))) == 0 {
+				if code.isZero(p) {
 					code = code.nextField
 				} else {
 					e.encodeIndent(code.indent + 1)
@@ -3158,12 +3578,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyIntIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToInt(ptr + code.offset)
 				if v == 0 {
@@ -3186,12 +3604,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyInt8Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToInt8(ptr + code.offset)
 				if v == 0 {
@@ -3214,12 +3630,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyInt16Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToInt16(ptr + code.offset)
 				if v == 0 {
@@ -3242,12 +3656,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyInt32Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToInt32(ptr + code.offset)
 				if v == 0 {
@@ -3270,12 +3682,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyInt64Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToInt64(ptr + code.offset)
 				if v == 0 {
@@ -3298,12 +3708,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyUintIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToUint(ptr + code.offset)
 				if v == 0 {
@@ -3326,12 +3734,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyUint8Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToUint8(ptr + code.offset)
 				if v == 0 {
@@ -3354,12 +3760,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyUint16Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToUint16(ptr + code.offset)
 				if v == 0 {
@@ -3382,12 +3786,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyUint32Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToUint32(ptr + code.offset)
 				if v == 0 {
@@ -3410,12 +3812,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyUint64Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToUint64(ptr + code.offset)
 				if v == 0 {
@@ -3438,12 +3838,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyFloat32Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToFloat32(ptr + code.offset)
 				if v == 0 {
@@ -3466,12 +3864,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyFloat64Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToFloat64(ptr + code.offset)
 				if v == 0 {
@@ -3497,12 +3893,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyStringIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToString(ptr + code.offset)
 				if v == "" {
@@ -3525,12 +3919,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyBoolIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToBool(ptr + code.offset)
 				if !v {
@@ -3553,12 +3945,10 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadOmitEmptyBytesIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
 			} else {
-				e.encodeIndent(code.indent)
 				e.encodeBytes([]byte{'{', '\n'})
 				v := e.ptrToBytes(ptr + code.offset)
 				if len(v) == 0 {
@@ -3609,6 +3999,55 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				code = code.next
 				store(ctxptr, code.idx, ptr+code.offset)
 			}
+		case opStructFieldPtrHeadOmitEmptyStringTag:
+			ptr := load(ctxptr, code.idx)
+			if ptr != 0 {
+				store(ctxptr, code.idx, e.ptrToPtr(ptr))
+			}
+			fallthrough
+		case opStructFieldHeadOmitEmptyStringTag:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 {
+				e.encodeNull()
+				e.encodeByte(',')
+				code = code.end.next
+			} else {
+				e.encodeByte('{')
+				p := ptr + code.offset
+				if e.isEmptyStringTagValue(code, p) {
+					code = code.nextField
+				} else {
+					e.encodeKey(code)
+					if err := e.encodeStringTagValue(code, p); err != nil {
+						return err
+					}
+					e.encodeByte(',')
+					code = code.next
+				}
+			}
+		case opStructFieldPtrAnonymousHeadOmitEmptyStringTag:
+			ptr := load(ctxptr, code.idx)
+			if ptr != 0 {
+				store(ctxptr, code.idx, e.ptrToPtr(ptr))
+			}
+			fallthrough
+		case opStructFieldAnonymousHeadOmitEmptyStringTag:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 {
+				code = code.end.next
+			} else {
+				p := ptr + code.offset
+				if e.isEmptyStringTagValue(code, p) {
+					code = code.nextField
+				} else {
+					e.encodeKey(code)
+					if err := e.encodeStringTagValue(code, p); err != nil {
+						return err
+					}
+					e.encodeByte(',')
+					code = code.next
+				}
+			}
 		case opStructFieldPtrHeadStringTagInt:
 			ptr := load(ctxptr, code.idx)
 			if ptr != 0 {
@@ -4302,7 +4741,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4315,6 +4753,59 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				code = code.next
 				store(ctxptr, code.idx, p)
 			}
+		case opStructFieldPtrHeadOmitEmptyStringTagIndent:
+			ptr := load(ctxptr, code.idx)
+			if ptr != 0 {
+				store(ctxptr, code.idx, e.ptrToPtr(ptr))
+			}
+			fallthrough
+		case opStructFieldHeadOmitEmptyStringTagIndent:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 {
+				e.encodeNull()
+				e.encodeBytes([]byte{',', '\n'})
+				code = code.end.next
+			} else {
+				e.encodeBytes([]byte{'{', '\n'})
+				p := ptr + code.offset
+				if e.isEmptyStringTagValue(code, p) {
+					code = code.nextField
+				} else {
+					e.encodeIndent(code.indent + 1)
+					e.encodeKey(code)
+					e.encodeByte(' ')
+					if err := e.encodeStringTagValue(code, p); err != nil {
+						return err
+					}
+					e.encodeBytes([]byte{',', '\n'})
+					code = code.next
+				}
+			}
+		case opStructFieldPtrAnonymousHeadOmitEmptyStringTagIndent:
+			ptr := load(ctxptr, code.idx)
+			if ptr != 0 {
+				store(ctxptr, code.idx, e.ptrToPtr(ptr))
+			}
+			fallthrough
+		case opStructFieldAnonymousHeadOmitEmptyStringTagIndent:
+			ptr := load(ctxptr, code.idx)
+			if ptr == 0 {
+				code = code.end.next
+			} else {
+				p := ptr + code.offset
+				if e.isEmptyStringTagValue(code, p) {
+					code = code.nextField
+				} else {
+					e.encodeIndent(code.indent)
+					e.encodeKey(code)
+					e.encodeByte(' ')
+					if err := e.encodeStringTagValue(code, p); err != nil {
+						return err
+					}
+					e.encodeBytes([]byte{',', '\n'})
+					code = code.next
+				}
+			}
 		case opStructFieldPtrHeadStringTagIntIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr != 0 {
@@ -4324,7 +4815,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagIntIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4346,7 +4836,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagInt8Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4368,7 +4857,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagInt16Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4390,7 +4878,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagInt32Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4412,7 +4899,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagInt64Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4434,7 +4920,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagUintIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4456,7 +4941,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagUint8Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4478,7 +4962,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagUint16Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4500,7 +4983,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagUint32Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4522,7 +5004,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagUint64Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4544,7 +5025,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagFloat32Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4566,7 +5046,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagFloat64Indent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4592,7 +5071,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagStringIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4622,7 +5100,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagBoolIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4644,7 +5121,6 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructFieldHeadStringTagBytesIndent:
 			ptr := load(ctxptr, code.idx)
 			if ptr == 0 {
-				e.encodeIndent(code.indent)
 				e.encodeNull()
 				e.encodeBytes([]byte{',', '\n'})
 				code = code.end.next
@@ -4663,6 +5139,9 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				code = code.next
 			}
 		case opStructField:
+			if err := e.flushAtBoundary(); err != nil {
+				return err
+			}
 			if !code.anonymousKey {
 				e.encodeKey(code)
 			}
@@ -4982,6 +5461,9 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 			code = code.next
 			store(ctxptr, code.idx, p)
 		case opStructFieldIndent:
+			if err := e.flushAtBoundary(); err != nil {
+				return err
+			}
 			e.encodeIndent(code.indent)
 			e.encodeKey(code)
 			e.encodeByte(' ')
@@ -5231,6 +5713,26 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				code = code.next
 				store(ctxptr, code.idx, p)
 			}
+		case opStructFieldOmitZero:
+			ptr := load(ctxptr, code.headIdx)
+			p := ptr + code.offset
+			if code.isZero(p) {
+				code = code.nextField
+			} else {
+				e.encodeKey(code)
+				code = code.next
+				store(ctxptr, code.idx, p)
+			}
+		case opStructFieldOmitZeroMethod:
+			ptr := load(ctxptr, code.headIdx)
+			p := ptr + code.offset
+			if code.isZero(p) {
+				code = code.nextField
+			} else {
+				e.encodeKey(code)
+				code = code.next
+				store(ctxptr, code.idx, p)
+			}
 		case opStructFieldOmitEmptyInt:
 			ptr := load(ctxptr, code.headIdx)
 			v := e.ptrToInt(ptr + code.offset)
@@ -5455,6 +5957,30 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 				code = code.next
 				store(ctxptr, code.idx, p)
 			}
+		case opStructFieldOmitZeroIndent:
+			ptr := load(ctxptr, code.headIdx)
+			p := ptr + code.offset
+			if code.isZero(p) {
+				code = code.nextField
+			} else {
+				e.encodeIndent(code.indent)
+				e.encodeKey(code)
+				e.encodeByte(' ')
+				code = code.next
+				store(ctxptr, code.idx, p)
+			}
+		case opStructFieldOmitZeroMethodIndent:
+			ptr := load(ctxptr, code.headIdx)
+			p := ptr + code.offset
+			if code.isZero(p) {
+				code = code.nextField
+			} else {
+				e.encodeIndent(code.indent)
+				e.encodeKey(code)
+				e.encodeByte(' ')
+				code = code.next
+				store(ctxptr, code.idx, p)
+			}
 		case opStructFieldOmitEmptyIntIndent:
 			ptr := load(ctxptr, code.headIdx)
 			v := e.ptrToInt(ptr + code.offset)
@@ -5707,6 +6233,17 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 			e.encodeKey(code)
 			code = code.next
 			store(ctxptr, code.idx, p)
+		case opStructFieldOmitEmptyStringTag:
+			ptr := load(ctxptr, code.headIdx)
+			p := ptr + code.offset
+			if !e.isEmptyStringTagValue(code, p) {
+				e.encodeKey(code)
+				if err := e.encodeStringTagValue(code, p); err != nil {
+					return err
+				}
+				e.encodeByte(',')
+			}
+			code = code.next
 		case opStructFieldStringTagInt:
 			ptr := load(ctxptr, code.headIdx)
 			e.encodeKey(code)
@@ -5838,6 +6375,19 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 			e.encodeByte(' ')
 			code = code.next
 			store(ctxptr, code.idx, p)
+		case opStructFieldOmitEmptyStringTagIndent:
+			ptr := load(ctxptr, code.headIdx)
+			p := ptr + code.offset
+			if !e.isEmptyStringTagValue(code, p) {
+				e.encodeIndent(code.indent)
+				e.encodeKey(code)
+				e.encodeByte(' ')
+				if err := e.encodeStringTagValue(code, p); err != nil {
+					return err
+				}
+				e.encodeBytes([]byte{',', '\n'})
+			}
+			code = code.next
 		case opStructFieldStringTagIntIndent:
 			ptr := load(ctxptr, code.headIdx)
 			e.encodeIndent(code.indent)
@@ -6004,6 +6554,9 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 			e.encodeBytes([]byte{',', '\n'})
 			code = code.next
 		case opStructEnd:
+			if err := e.flushAtBoundary(); err != nil {
+				return err
+			}
 			last := len(e.buf) - 1
 			if e.buf[last] == ',' {
 				e.buf[last] = '}'
@@ -6015,7 +6568,17 @@ func (e *Encoder) run(ctx *encodeRuntimeContext, code *opcode) error {
 		case opStructAnonymousEnd:
 			code = code.next
 		case opStructEndIndent:
+			if err := e.flushAtBoundary(); err != nil {
+				return err
+			}
 			last := len(e.buf) - 1
+			if e.buf[last] == '\n' && e.buf[last-1] == '{' {
+				// all fields are omitted
+				e.buf[last] = '}'
+				e.encodeBytes([]byte{',', '\n'})
+				code = code.next
+				break
+			}
 			if e.buf[last] == '\n' {
 				// to remove ',' and '\n' characters
 				e.buf = e.buf[:len(e.buf)-2]
@@ -6062,3 +6625,110 @@ func (e *Encoder) ptrToInterface(code *opcode, p uintptr) interface{} {
 		ptr: *(*unsafe.Pointer)(unsafe.Pointer(&p)),
 	}))
 }
+
+func (e *Encoder) ptrToTime(p uintptr) time.Time {
+	return **(**time.Time)(unsafe.Pointer(&p))
+}
+
+func (e *Encoder) ptrToDuration(p uintptr) time.Duration {
+	return **(**time.Duration)(unsafe.Pointer(&p))
+}
+
+// mapKeyString returns the string of the encoded map key such as `"key",`,
+// so that map keys are sorted in the same order as encoding/json.
+func mapKeyString(key string) string {
+	end := strings.LastIndexByte(key, '"')
+	if end <= 0 {
+		return key
+	}
+	if strings.IndexByte(key[:end], '\\') < 0 {
+		return key[1:end]
+	}
+	if s, ok := unquoteBytes([]byte(key[:end+1])); ok {
+		return string(s)
+	}
+	return key
+}
+
+// isEmptyStringTagValue reports whether the scalar value of the field is empty
+// for the field that has both omitempty and string options.
+func (e *Encoder) isEmptyStringTagValue(code *opcode, p uintptr) bool {
+	switch code.typ.Kind() {
+	case reflect.Int:
+		return e.ptrToInt(p) == 0
+	case reflect.Int8:
+		return e.ptrToInt8(p) == 0
+	case reflect.Int16:
+		return e.ptrToInt16(p) == 0
+	case reflect.Int32:
+		return e.ptrToInt32(p) == 0
+	case reflect.Int64:
+		return e.ptrToInt64(p) == 0
+	case reflect.Uint:
+		return e.ptrToUint(p) == 0
+	case reflect.Uint8:
+		return e.ptrToUint8(p) == 0
+	case reflect.Uint16:
+		return e.ptrToUint16(p) == 0
+	case reflect.Uint32:
+		return e.ptrToUint32(p) == 0
+	case reflect.Uint64:
+		return e.ptrToUint64(p) == 0
+	case reflect.Float32:
+		return e.ptrToFloat32(p) == 0
+	case reflect.Float64:
+		return e.ptrToFloat64(p) == 0
+	case reflect.Bool:
+		return !e.ptrToBool(p)
+	case reflect.String:
+		return e.ptrToString(p) == ""
+	}
+	return false
+}
+
+// encodeStringTagValue encodes the scalar value of the field as JSON string
+// for the field that has both omitempty and string options.
+func (e *Encoder) encodeStringTagValue(code *opcode, p uintptr) error {
+	var v interface{}
+	switch code.typ.Kind() {
+	case reflect.Int:
+		v = e.ptrToInt(p)
+	case reflect.Int8:
+		v = e.ptrToInt8(p)
+	case reflect.Int16:
+		v = e.ptrToInt16(p)
+	case reflect.Int32:
+		v = e.ptrToInt32(p)
+	case reflect.Int64:
+		v = e.ptrToInt64(p)
+	case reflect.Uint:
+		v = e.ptrToUint(p)
+	case reflect.Uint8:
+		v = e.ptrToUint8(p)
+	case reflect.Uint16:
+		v = e.ptrToUint16(p)
+	case reflect.Uint32:
+		v = e.ptrToUint32(p)
+	case reflect.Uint64:
+		v = e.ptrToUint64(p)
+	case reflect.Float32:
+		v = e.ptrToFloat32(p)
+	case reflect.Float64:
+		f := e.ptrToFloat64(p)
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return errUnsupportedFloat(f)
+		}
+		v = f
+	case reflect.Bool:
+		v = e.ptrToBool(p)
+	case reflect.String:
+		var b bytes.Buffer
+		enc := NewEncoder(&b)
+		enc.encodeString(e.ptrToString(p))
+		e.encodeString(string(enc.buf))
+		enc.release()
+		return nil
+	}
+	e.encodeString(fmt.Sprint(v))
+	return nil
+}
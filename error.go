@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 )
 
 // Before Go 1.2, an InvalidUTF8Error was returned by Marshal when
@@ -79,6 +80,17 @@ func (e *UnmarshalFieldError) Error() string {
 	)
 }
 
+// A PathError is returned when a path ( e.g. JSON Pointer ) is invalid
+// or the value it refers to does not exist in the input.
+type PathError struct {
+	Path string // the path given by the caller
+	msg  string
+}
+
+func (e *PathError) Error() string {
+	return fmt.Sprintf("json: %s in path %q", e.msg, e.Path)
+}
+
 // An UnmarshalTypeError describes a JSON value that was
 // not appropriate for a value of a specific Go type.
 type UnmarshalTypeError struct {
@@ -90,14 +102,119 @@ type UnmarshalTypeError struct {
 }
 
 func (e *UnmarshalTypeError) Error() string {
-	if e.Struct != "" || e.Field != "" {
+	if e.Struct != "" {
 		return fmt.Sprintf("json: cannot unmarshal %s into Go struct field %s.%s of type %s",
 			e.Value, e.Struct, e.Field, e.Type,
 		)
 	}
+	if e.Field != "" {
+		return fmt.Sprintf("json: cannot unmarshal %s into Go value of type %s at %s", e.Value, e.Type, e.Field)
+	}
 	return fmt.Sprintf("json: cannot unmarshal %s into Go value of type %s", e.Value, e.Type)
 }
 
+// addField prepends the name of the struct field ( or map key ) that contains
+// the value to Field. Struct is set by the innermost struct only.
+func (e *UnmarshalTypeError) addField(structName, field string) {
+	if e.Struct == "" {
+		e.Struct = structName
+	}
+	switch {
+	case e.Field == "":
+		e.Field = field
+	case e.Field[0] == '[':
+		e.Field = field + e.Field
+	default:
+		e.Field = field + "." + e.Field
+	}
+}
+
+// addIndex prepends the index of the array element that contains the value to Field.
+func (e *UnmarshalTypeError) addIndex(idx int) {
+	index := "[" + strconv.Itoa(idx) + "]"
+	switch {
+	case e.Field == "":
+		e.Field = index
+	case e.Field[0] == '[':
+		e.Field = index + e.Field
+	default:
+		e.Field = index + "." + e.Field
+	}
+}
+
+// UnmarshalTypeErrors is returned when decoding with DecodeCollectErrors.
+// It lists every UnmarshalTypeError found in the input, in the order of appearance.
+type UnmarshalTypeErrors []*UnmarshalTypeError
+
+func (e UnmarshalTypeErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Unwrap returns the collected errors.
+func (e UnmarshalTypeErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// typeErrorWithField adds the struct field ( or map key ) to the path of
+// the UnmarshalTypeError(s) in err.
+func typeErrorWithField(err error, structName, field string) error {
+	switch e := err.(type) {
+	case *UnmarshalTypeError:
+		e.addField(structName, field)
+	case UnmarshalTypeErrors:
+		for _, typeErr := range e {
+			typeErr.addField(structName, field)
+		}
+	}
+	return err
+}
+
+// typeErrorWithIndex adds the array index to the path of the UnmarshalTypeError(s) in err.
+func typeErrorWithIndex(err error, idx int) error {
+	switch e := err.(type) {
+	case *UnmarshalTypeError:
+		e.addIndex(idx)
+	case UnmarshalTypeErrors:
+		for _, typeErr := range e {
+			typeErr.addIndex(idx)
+		}
+	}
+	return err
+}
+
+// collectTypeError appends err to errs if err is an UnmarshalTypeError(s)
+// and decoding is allowed to continue by DecodeCollectErrors.
+// Decoders return UnmarshalTypeError after skipping the whole value,
+// so the caller can go on with the next value.
+func collectTypeError(ctx *decodeRuntimeContext, errs UnmarshalTypeErrors, err error) (UnmarshalTypeErrors, bool) {
+	if !ctx.collectErrors {
+		return errs, false
+	}
+	switch e := err.(type) {
+	case *UnmarshalTypeError:
+		return append(errs, e), true
+	case UnmarshalTypeErrors:
+		return append(errs, e...), true
+	}
+	return errs, false
+}
+
+// typeErrors returns errs as error, or nil if errs is empty.
+func typeErrors(errs UnmarshalTypeErrors) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
 // An UnsupportedTypeError is returned by Marshal when attempting
 // to encode an unsupported value type.
 type UnsupportedTypeError struct {
@@ -108,6 +225,18 @@ func (e *UnsupportedTypeError) Error() string {
 	return fmt.Sprintf("json: unsupported type: %s", e.Type)
 }
 
+// An UnsupportedTagOptionError is returned by Marshal and Unmarshal with the strict tag options mode
+// when a struct field tag has an option that is not supported.
+type UnsupportedTagOptionError struct {
+	Type   reflect.Type // type of the struct that has the field
+	Field  string       // name of the field
+	Option string
+}
+
+func (e *UnsupportedTagOptionError) Error() string {
+	return fmt.Sprintf("json: unsupported option %q in tag of field %s.%s", e.Option, e.Type, e.Field)
+}
+
 type UnsupportedValueError struct {
 	Value reflect.Value
 	Str   string
@@ -117,6 +246,16 @@ func (e *UnsupportedValueError) Error() string {
 	return fmt.Sprintf("json: unsupported value: %s", e.Str)
 }
 
+// A WriterError is returned by Writer when the calls do not make a valid JSON value,
+// such as a value in an object without a key.
+type WriterError struct {
+	msg string
+}
+
+func (e *WriterError) Error() string {
+	return fmt.Sprintf("json: invalid write: %s", e.msg)
+}
+
 func errNotAtBeginningOfValue(cursor int64) *SyntaxError {
 	return &SyntaxError{msg: "not at beginning of value", Offset: cursor}
 }
@@ -132,9 +271,25 @@ func errExpected(msg string, cursor int64) *SyntaxError {
 	return &SyntaxError{msg: fmt.Sprintf("expected %s", msg), Offset: cursor}
 }
 
+func errUnmarshalType(value string, typ *rtype, cursor int64) *UnmarshalTypeError {
+	return &UnmarshalTypeError{Value: value, Type: rtype2type(typ), Offset: cursor}
+}
+
 func errInvalidCharacter(c byte, context string, cursor int64) *SyntaxError {
 	return &SyntaxError{
 		msg:    fmt.Sprintf("invalid character %c as %s", c, context),
 		Offset: cursor,
 	}
 }
+
+func errInvalidPath(path, msg string) *PathError {
+	return &PathError{Path: path, msg: msg}
+}
+
+func errPathNotFound(token string) *PathError {
+	return &PathError{msg: fmt.Sprintf("value not found at %q", token)}
+}
+
+func errWriter(msg string) *WriterError {
+	return &WriterError{msg: msg}
+}
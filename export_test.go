@@ -1,6 +1,9 @@
 package json
 
-import "reflect"
+import (
+	"io"
+	"reflect"
+)
 
 func NewSyntaxError(msg string, offset int64) *SyntaxError {
 	return &SyntaxError{
@@ -16,3 +19,11 @@ func NewMarshalerError(typ reflect.Type, err error, msg string) *MarshalerError
 		sourceFunc: msg,
 	}
 }
+
+// NewDecoderWithReadChunkSize returns a new decoder that reads size bytes from r at once.
+func NewDecoderWithReadChunkSize(r io.Reader, size int) *Decoder {
+	d := &Decoder{}
+	d.s = &stream{r: r, chunkSize: size, ctx: &d.ctx}
+	d.s.read()
+	return d
+}
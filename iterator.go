@@ -0,0 +1,256 @@
+package json
+
+import (
+	"io"
+	"reflect"
+)
+
+// ValueType is the type of the next JSON value reported by Iterator.WhatIsNext.
+type ValueType int
+
+const (
+	InvalidValue ValueType = iota
+	StringValue
+	NumberValue
+	NullValue
+	BoolValue
+	ArrayValue
+	ObjectValue
+)
+
+var (
+	int64Type   = type2rtype(reflect.TypeOf(int64(0)))
+	float64Type = type2rtype(reflect.TypeOf(float64(0)))
+	boolType    = type2rtype(reflect.TypeOf(false))
+)
+
+// An Iterator reads JSON values from an input stream one by one without decoding them into Go values.
+// Objects and arrays are walked with ReadObjectKey and ReadArray, e.g.
+//
+//	for {
+//		key, ok, err := it.ReadObjectKey()
+//		if err != nil || !ok {
+//			break
+//		}
+//		switch string(key) {
+//		case "id":
+//			id, err = it.ReadInt64()
+//		default:
+//			err = it.Skip()
+//		}
+//	}
+//
+// The values of a wrong type are skipped and reported as UnmarshalTypeError, and null is read as the zero value.
+type Iterator struct {
+	s            *stream
+	ctx          decodeRuntimeContext
+	intDecoder   *intDecoder
+	floatDecoder *floatDecoder
+}
+
+// NewIterator returns a new iterator that reads from r.
+//
+// The iterator introduces its own buffering and may
+// read data from r beyond the JSON values requested.
+func NewIterator(r io.Reader) *Iterator {
+	it := &Iterator{
+		intDecoder:   newIntDecoder(int64Type, nil),
+		floatDecoder: newFloatDecoder(float64Type, nil),
+	}
+	it.s = newStream(r, &it.ctx)
+	it.s.read()
+	return it
+}
+
+// InputOffset returns the input stream byte offset of the current iterator position.
+func (it *Iterator) InputOffset() int64 {
+	return it.s.totalOffset()
+}
+
+// WhatIsNext returns the type of the next value without reading it.
+// It returns InvalidValue at the end of the input or before a character that does not begin a value.
+func (it *Iterator) WhatIsNext() ValueType {
+	s := it.s
+	s.skipWhiteSpace()
+	switch s.char() {
+	case '"':
+		return StringValue
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return NumberValue
+	case 'n':
+		return NullValue
+	case 't', 'f':
+		return BoolValue
+	case '[':
+		return ArrayValue
+	case '{':
+		return ObjectValue
+	}
+	return InvalidValue
+}
+
+// ReadObjectKey reads the beginning of the object or the comma after the previous value in the object,
+// and returns the next key and true. It returns false after reading the end of the object or null.
+// The key is unescaped and valid until the next read.
+func (it *Iterator) ReadObjectKey() ([]byte, bool, error) {
+	s := it.s
+	s.skipWhiteSpace()
+	switch s.char() {
+	case '{':
+		s.cursor++
+		s.skipWhiteSpace()
+		if s.char() == '}' {
+			s.cursor++
+			return nil, false, nil
+		}
+	case ',':
+		s.cursor++
+		s.skipWhiteSpace()
+	case '}':
+		s.cursor++
+		return nil, false, nil
+	case 'n':
+		if err := nullBytes(s); err != nil {
+			return nil, false, err
+		}
+		return nil, false, nil
+	case nul:
+		return nil, false, errUnexpectedEndOfJSON("object", s.totalOffset())
+	default:
+		return nil, false, errExpected("object", s.totalOffset())
+	}
+	if s.char() != '"' {
+		return nil, false, errExpected("object key", s.totalOffset())
+	}
+	key, err := stringBytes(s)
+	if err != nil {
+		return nil, false, err
+	}
+	s.skipWhiteSpace()
+	if s.char() != ':' {
+		return nil, false, errExpected("colon after object key", s.totalOffset())
+	}
+	s.cursor++
+	return key, true, nil
+}
+
+// ReadArray reads the beginning of the array or the comma after the previous element,
+// and reports whether an element follows. It returns false after reading the end of the array or null.
+func (it *Iterator) ReadArray() (bool, error) {
+	s := it.s
+	s.skipWhiteSpace()
+	switch s.char() {
+	case '[':
+		s.cursor++
+		s.skipWhiteSpace()
+		if s.char() == ']' {
+			s.cursor++
+			return false, nil
+		}
+		return true, nil
+	case ',':
+		s.cursor++
+		return true, nil
+	case ']':
+		s.cursor++
+		return false, nil
+	case 'n':
+		if err := nullBytes(s); err != nil {
+			return false, err
+		}
+		return false, nil
+	case nul:
+		return false, errUnexpectedEndOfJSON("array", s.totalOffset())
+	}
+	return false, errExpected("array", s.totalOffset())
+}
+
+// ReadBytes reads a string and returns its unescaped content.
+// The returned slice refers to the buffer of the iterator and is valid until the next read.
+func (it *Iterator) ReadBytes() ([]byte, error) {
+	s := it.s
+	s.skipWhiteSpace()
+	switch s.char() {
+	case '"':
+		return stringBytes(s)
+	case 'n':
+		return nil, nullBytes(s)
+	case nul:
+		return nil, errUnexpectedEndOfJSON("string", s.totalOffset())
+	}
+	return nil, s.skipTypeMismatch(stringType)
+}
+
+// ReadString reads a string.
+func (it *Iterator) ReadString() (string, error) {
+	b, err := it.ReadBytes()
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ReadInt64 reads an integer.
+func (it *Iterator) ReadInt64() (int64, error) {
+	b, err := it.intDecoder.decodeStreamByte(it.s)
+	if err != nil || b == nil {
+		return 0, err
+	}
+	return it.intDecoder.parseInt(b)
+}
+
+// ReadFloat64 reads a number.
+func (it *Iterator) ReadFloat64() (float64, error) {
+	b, err := it.floatDecoder.decodeStreamByte(it.s)
+	if err != nil || b == nil {
+		return 0, err
+	}
+	return it.floatDecoder.parseFloat(b)
+}
+
+// ReadBool reads a boolean.
+func (it *Iterator) ReadBool() (bool, error) {
+	s := it.s
+	s.skipWhiteSpace()
+	switch s.char() {
+	case 't':
+		if err := trueBytes(s); err != nil {
+			return false, err
+		}
+		return true, nil
+	case 'f':
+		return false, falseBytes(s)
+	case 'n':
+		return false, nullBytes(s)
+	case nul:
+		return false, errUnexpectedEndOfJSON("bool", s.totalOffset())
+	}
+	return false, s.skipTypeMismatch(boolType)
+}
+
+// ReadNull reads null and returns true if the next value is null.
+// Otherwise it returns false without reading the value.
+func (it *Iterator) ReadNull() (bool, error) {
+	s := it.s
+	s.skipWhiteSpace()
+	if s.char() != 'n' {
+		return false, nil
+	}
+	if err := nullBytes(s); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Skip skips the next value.
+func (it *Iterator) Skip() error {
+	s := it.s
+	s.skipWhiteSpace()
+	if s.char() == nul {
+		return errUnexpectedEndOfJSON("value", s.totalOffset())
+	}
+	if valueTypeName[s.char()] == "" {
+		return errNotAtBeginningOfValue(s.totalOffset())
+	}
+	return s.skipValue()
+}
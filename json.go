@@ -175,6 +175,24 @@ func MarshalWithOption(v interface{}, opts ...EncodeOption) ([]byte, error) {
 	return bytes, nil
 }
 
+// AppendMarshal appends the JSON encoding of v to dst with EncodeOption and returns the extended buffer.
+// The encoding is written directly to dst without the copy that Marshal makes,
+// so the caller can reuse the buffer across calls, e.g. buf, err = json.AppendMarshal(buf[:0], v).
+// If an error occurs, dst is returned with its original length, but the bytes after it may have been overwritten.
+func AppendMarshal(dst []byte, v interface{}, opts ...EncodeOption) ([]byte, error) {
+	var b *bytes.Buffer
+	enc := NewEncoder(b)
+	for _, opt := range opts {
+		if err := opt(enc); err != nil {
+			enc.release()
+			return dst, err
+		}
+	}
+	bytes, err := enc.encodeForAppend(dst, v)
+	enc.release()
+	return bytes, err
+}
+
 // MarshalIndent is like Marshal but applies Indent to format the output.
 // Each JSON element in the output will begin on a new line beginning with prefix
 // followed by one or more copies of indent according to the indentation nesting.
@@ -276,10 +294,51 @@ func MarshalIndentWithOption(v interface{}, prefix, indent string, opts ...Encod
 // character U+FFFD.
 //
 func Unmarshal(data []byte, v interface{}) error {
+	return UnmarshalWithOption(data, v)
+}
+
+// UnmarshalWithOption parses the JSON-encoded data with DecodeOption
+// and stores the result in the value pointed to by v.
+func UnmarshalWithOption(data []byte, v interface{}, opts ...DecodeOption) error {
 	src := make([]byte, len(data)+1) // append nul byte to end
 	copy(src, data)
 	var dec Decoder
-	return dec.decodeForUnmarshal(src, v)
+	for _, opt := range opts {
+		if err := opt(&dec); err != nil {
+			return err
+		}
+	}
+	return dec.decodeForUnmarshal(src, 0, v)
+}
+
+// UnmarshalPath decodes only the value that path refers to in data
+// and stores the result in the value pointed to by v.
+//
+// path is a JSON Pointer ( e.g. "/items/0/name" ) or a JSONPath that consists of
+// member names and array indexes ( e.g. "$.items[0].name" or "$['items'][0]" ).
+// The values on the way to the target are skipped without building Go values.
+// If the value is not found, UnmarshalPath returns PathError.
+func UnmarshalPath(data []byte, path string, v interface{}, opts ...DecodeOption) error {
+	tokens, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	src := make([]byte, len(data)+1) // append nul byte to end
+	copy(src, data)
+	cursor, err := lookupValue(src, tokens)
+	if err != nil {
+		if pathErr, ok := err.(*PathError); ok {
+			pathErr.Path = path
+		}
+		return err
+	}
+	var dec Decoder
+	for _, opt := range opts {
+		if err := opt(&dec); err != nil {
+			return err
+		}
+	}
+	return dec.decodeForUnmarshal(src, cursor, v)
 }
 
 func UnmarshalNoEscape(data []byte, v interface{}) error {
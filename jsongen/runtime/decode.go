@@ -0,0 +1,470 @@
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/goccy/go-json"
+)
+
+// UnmarshalObject calls fn with the unescaped key and the JSON value of each member of the JSON object data in order.
+// v is the pointer to the struct that the object is decoded into, and is used for the error of the value that is not an object.
+// UnmarshalObject does nothing for null.
+func UnmarshalObject(data []byte, v interface{}, fn func(key, value []byte) error) error {
+	s := &scanner{data: data}
+	s.skipWhiteSpace()
+	switch c := s.char(); {
+	case c == '{':
+	case c == 'n' && s.skipLiteral("null"):
+		return nil
+	default:
+		return s.typeMismatch(reflect.TypeOf(v).Elem())
+	}
+	s.cursor++
+	s.skipWhiteSpace()
+	if s.char() == '}' {
+		return nil
+	}
+	for {
+		if s.char() != '"' {
+			return syntaxError(data)
+		}
+		key, err := s.scanKey()
+		if err != nil {
+			return err
+		}
+		s.skipWhiteSpace()
+		if s.char() != ':' {
+			return syntaxError(data)
+		}
+		s.cursor++
+		s.skipWhiteSpace()
+		start := s.cursor
+		if !s.skipValue() {
+			return syntaxError(data)
+		}
+		if err := fn(key, data[start:s.cursor:s.cursor]); err != nil {
+			return err
+		}
+		s.skipWhiteSpace()
+		switch s.char() {
+		case '}':
+			return nil
+		case ',':
+			s.cursor++
+			s.skipWhiteSpace()
+		default:
+			return syntaxError(data)
+		}
+	}
+}
+
+// LowerKey returns the lower case of the object key that matches no field, which is looked up again as the decoder does.
+// It returns nil if the lower case is the same as key.
+func LowerKey(key []byte) []byte {
+	upper := false
+	for _, c := range key {
+		if ('A' <= c && c <= 'Z') || c >= utf8.RuneSelf {
+			upper = true
+			break
+		}
+	}
+	if !upper {
+		return nil
+	}
+	lower := bytes.ToLower(key)
+	if bytes.Equal(lower, key) {
+		return nil
+	}
+	return lower
+}
+
+// Unquote returns the content of the JSON string data that is the value of the field with the string option.
+// It returns data as it is for null.
+func Unquote(data []byte) ([]byte, error) {
+	if isNullValue(data) {
+		return data, nil
+	}
+	if len(data) == 0 || data[0] != '"' {
+		return nil, typeMismatch(data, reflect.TypeOf(""))
+	}
+	s := &scanner{data: data}
+	content, ok := s.scanString()
+	if !ok {
+		return nil, syntaxError(data)
+	}
+	return unescape(content, data)
+}
+
+// UnmarshalBool stores the JSON bool data in v, which must be a pointer to a bool type.
+// It does nothing for null.
+func UnmarshalBool(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v).Elem()
+	switch string(data) {
+	case "true":
+		rv.SetBool(true)
+	case "false":
+		rv.SetBool(false)
+	case "null":
+	default:
+		return typeMismatch(data, rv.Type())
+	}
+	return nil
+}
+
+// UnmarshalString stores the JSON string data in v, which must be a pointer to a string type.
+// It does nothing for null.
+func UnmarshalString(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v).Elem()
+	if isNullValue(data) {
+		return nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return typeMismatch(data, rv.Type())
+	}
+	content := data[1 : len(data)-1]
+	for _, c := range content {
+		if c == '\\' || c == '"' {
+			var err error
+			if content, err = Unquote(data); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	rv.SetString(string(content))
+	return nil
+}
+
+// UnmarshalInt stores the JSON number data in v, which must be a pointer to a signed integer type.
+// It does nothing for null.
+func UnmarshalInt(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v).Elem()
+	if isNullValue(data) {
+		return nil
+	}
+	if !isIntegerValue(data) {
+		return typeMismatch(data, rv.Type())
+	}
+	i64, err := strconv.ParseInt(string(data), 10, rv.Type().Bits())
+	if err != nil {
+		return overflow(data, rv.Type())
+	}
+	rv.SetInt(i64)
+	return nil
+}
+
+// UnmarshalUint stores the JSON number data in v, which must be a pointer to an unsigned integer type.
+// It does nothing for null.
+func UnmarshalUint(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v).Elem()
+	if isNullValue(data) {
+		return nil
+	}
+	if !isIntegerValue(data) || data[0] == '-' {
+		return typeMismatch(data, rv.Type())
+	}
+	u64, err := strconv.ParseUint(string(data), 10, rv.Type().Bits())
+	if err != nil {
+		return overflow(data, rv.Type())
+	}
+	rv.SetUint(u64)
+	return nil
+}
+
+// UnmarshalFloat stores the JSON number data in v, which must be a pointer to a floating-point type.
+// It does nothing for null.
+func UnmarshalFloat(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v).Elem()
+	if isNullValue(data) {
+		return nil
+	}
+	if len(data) == 0 || valueType(data[0]) != "number" {
+		return typeMismatch(data, rv.Type())
+	}
+	f64, err := strconv.ParseFloat(string(data), rv.Type().Bits())
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return overflow(data, rv.Type())
+		}
+		return err
+	}
+	rv.SetFloat(f64)
+	return nil
+}
+
+func isNullValue(data []byte) bool {
+	return string(data) == "null"
+}
+
+func isIntegerValue(data []byte) bool {
+	num := data
+	if len(num) > 0 && num[0] == '-' {
+		num = num[1:]
+	}
+	if len(num) == 0 {
+		return false
+	}
+	for _, c := range num {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// valueType returns the description of the JSON value beginning with c reported by UnmarshalTypeError.
+func valueType(c byte) string {
+	switch c {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "bool"
+	case 'n':
+		return "null"
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return "number"
+	}
+	return ""
+}
+
+// typeMismatch returns UnmarshalTypeError for the JSON value data that cannot be stored in typ.
+// The number is reported with its literal only for the number types as the decoder does.
+func typeMismatch(data []byte, typ reflect.Type) *json.UnmarshalTypeError {
+	value := "value"
+	if len(data) > 0 {
+		if name := valueType(data[0]); name != "" {
+			value = name
+		}
+	}
+	if value == "number" && typ.Kind() != reflect.Bool && typ.Kind() != reflect.String {
+		value = "number " + string(data)
+	}
+	return &json.UnmarshalTypeError{Value: value, Type: typ, Offset: int64(len(data))}
+}
+
+// overflow returns UnmarshalTypeError for the JSON number data that overflows typ.
+func overflow(data []byte, typ reflect.Type) *json.UnmarshalTypeError {
+	return &json.UnmarshalTypeError{Value: "number " + string(data), Type: typ}
+}
+
+// syntaxError returns the error of Unmarshal for the invalid JSON data.
+// Unmarshal validates the input before calling UnmarshalJSON, so it is only returned when UnmarshalJSON is called directly.
+func syntaxError(data []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	return fmt.Errorf("json: invalid JSON input %q", data)
+}
+
+// scanner reads the JSON values in data from cursor.
+type scanner struct {
+	data   []byte
+	cursor int
+}
+
+// char returns the character at cursor, or 0 at the end of data.
+func (s *scanner) char() byte {
+	if s.cursor < len(s.data) {
+		return s.data[s.cursor]
+	}
+	return 0
+}
+
+func (s *scanner) skipWhiteSpace() {
+	for {
+		switch s.char() {
+		case ' ', '\n', '\t', '\r':
+			s.cursor++
+		default:
+			return
+		}
+	}
+}
+
+func (s *scanner) skipLiteral(literal string) bool {
+	end := s.cursor + len(literal)
+	if end > len(s.data) || string(s.data[s.cursor:end]) != literal {
+		return false
+	}
+	s.cursor = end
+	return true
+}
+
+// scanString returns the raw content of the JSON string at cursor and moves cursor after the string.
+func (s *scanner) scanString() ([]byte, bool) {
+	start := s.cursor + 1
+	for i := start; i < len(s.data); i++ {
+		switch s.data[i] {
+		case '\\':
+			i++
+		case '"':
+			s.cursor = i + 1
+			return s.data[start:i], true
+		}
+	}
+	return nil, false
+}
+
+// scanKey returns the unescaped key of the object member at cursor.
+func (s *scanner) scanKey() ([]byte, error) {
+	content, ok := s.scanString()
+	if !ok {
+		return nil, syntaxError(s.data)
+	}
+	return unescape(content, s.data)
+}
+
+// skipValue moves cursor after the JSON value at cursor.
+// It only checks the brackets and the literals, and the input is assumed to be validated by Unmarshal.
+func (s *scanner) skipValue() bool {
+	switch c := s.char(); c {
+	case '"':
+		_, ok := s.scanString()
+		return ok
+	case '{', '[':
+		depth := 0
+		for s.cursor < len(s.data) {
+			switch s.data[s.cursor] {
+			case '"':
+				if _, ok := s.scanString(); !ok {
+					return false
+				}
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					s.cursor++
+					return true
+				}
+			}
+			s.cursor++
+		}
+		return false
+	case 't':
+		return s.skipLiteral("true")
+	case 'f':
+		return s.skipLiteral("false")
+	case 'n':
+		return s.skipLiteral("null")
+	default:
+		if valueType(c) != "number" {
+			return false
+		}
+		s.cursor++
+		for s.cursor < len(s.data) && isNumberChar(s.data[s.cursor]) {
+			s.cursor++
+		}
+		return true
+	}
+}
+
+func isNumberChar(c byte) bool {
+	return '0' <= c && c <= '9' || c == '.' || c == 'e' || c == 'E' || c == '+' || c == '-'
+}
+
+// typeMismatch skips the JSON value at cursor that cannot be stored in typ and returns UnmarshalTypeError.
+// The offset is the beginning of the object and the array and the end of the other values as the decoder reports.
+func (s *scanner) typeMismatch(typ reflect.Type) error {
+	value := valueType(s.char())
+	if value == "" {
+		return syntaxError(s.data)
+	}
+	offset := s.cursor + 1
+	if !s.skipValue() {
+		return syntaxError(s.data)
+	}
+	if value != "object" && value != "array" {
+		offset = s.cursor
+	}
+	return &json.UnmarshalTypeError{Value: value, Type: typ, Offset: int64(offset)}
+}
+
+// unescape returns the raw content of a JSON string with the escape sequences replaced.
+// data is the whole input and is used for the syntax error.
+func unescape(content, data []byte) ([]byte, error) {
+	i := 0
+	for i < len(content) && content[i] != '\\' {
+		i++
+	}
+	if i == len(content) {
+		return content, nil
+	}
+	b := append(make([]byte, 0, len(content)), content[:i]...)
+	for i < len(content) {
+		c := content[i]
+		if c != '\\' {
+			b = append(b, c)
+			i++
+			continue
+		}
+		if i+1 == len(content) {
+			return nil, syntaxError(data)
+		}
+		switch c := content[i+1]; c {
+		case '"', '\\', '/':
+			b = append(b, c)
+		case 'b':
+			b = append(b, '\b')
+		case 'f':
+			b = append(b, '\f')
+		case 'n':
+			b = append(b, '\n')
+		case 'r':
+			b = append(b, '\r')
+		case 't':
+			b = append(b, '\t')
+		case 'u':
+			if i+6 > len(content) {
+				return nil, syntaxError(data)
+			}
+			r := hexRune(content[i+2 : i+6])
+			escaped := 6 // length of \uXXXX
+			if utf16.IsSurrogate(r) && i+12 <= len(content) && content[i+6] == '\\' && content[i+7] == 'u' {
+				// combine the surrogate pair such as \ud83d\ude00
+				if pair := utf16.DecodeRune(r, hexRune(content[i+8:i+12])); pair != utf8.RuneError {
+					r = pair
+					escaped = 12
+				}
+			}
+			b = append(b, string(r)...)
+			i += escaped
+			continue
+		default:
+			return nil, syntaxError(data)
+		}
+		i += 2
+	}
+	return b, nil
+}
+
+// hexRune returns the rune of the four hex digits in code.
+func hexRune(code []byte) rune {
+	r := rune(0)
+	for _, c := range code {
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c -= 'a' - 10
+		case 'A' <= c && c <= 'F':
+			c -= 'A' - 10
+		default:
+			// the decoder also reads the invalid digit as 0
+			c = 0
+		}
+		r = r<<4 | rune(c)
+	}
+	return r
+}
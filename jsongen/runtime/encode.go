@@ -0,0 +1,120 @@
+// Package runtime provides the functions used by the MarshalJSON and UnmarshalJSON methods generated by cmd/jsongen.
+// They encode and decode a single value in the same way as Marshal and Unmarshal of go-json.
+// The package is not intended to be used directly.
+package runtime
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/goccy/go-json"
+)
+
+const hex = "0123456789abcdef"
+
+// AppendString appends s to b as the JSON string encoded by Marshal.
+func AppendString(b []byte, s string) []byte {
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if htmlSafe(c) {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '\\', '"':
+				b = append(b, '\\', c)
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				// the control characters and <, > and & are escaped in the same way as the encoder with the HTML escape
+				b = append(b, `\u00`...)
+				b = append(b, hex[c>>4], hex[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, s[start:i]...)
+			b = append(b, `\ufffd`...)
+			i++
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			// U+2028 and U+2029 are escaped because they don't work in JSONP
+			b = append(b, s[start:i]...)
+			b = append(b, `\u202`...)
+			b = append(b, hex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
+
+// htmlSafe reports whether the ASCII character c is written in the JSON string as it is.
+func htmlSafe(c byte) bool {
+	return c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&'
+}
+
+// AppendFloat64 appends v to b as the JSON number encoded by Marshal.
+// It returns UnsupportedValueError for NaN and infinity.
+func AppendFloat64(b []byte, v float64) ([]byte, error) {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil, unsupportedFloat(v)
+	}
+	format := byte('f')
+	if abs := math.Abs(v); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	return strconv.AppendFloat(b, v, format, -1, 64), nil
+}
+
+// AppendFloat32 appends v to b as the JSON number encoded by Marshal.
+// It returns UnsupportedValueError for NaN and infinity.
+func AppendFloat32(b []byte, v float32) ([]byte, error) {
+	f64 := float64(v)
+	if math.IsInf(f64, 0) || math.IsNaN(f64) {
+		return nil, unsupportedFloat(f64)
+	}
+	format := byte('f')
+	// the cutoffs are compared as float32 to be precise
+	if abs := float32(math.Abs(f64)); abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	return strconv.AppendFloat(b, f64, format, -1, 32), nil
+}
+
+func unsupportedFloat(v float64) *json.UnsupportedValueError {
+	return &json.UnsupportedValueError{
+		Value: reflect.ValueOf(v),
+		Str:   strconv.FormatFloat(v, 'g', -1, 64),
+	}
+}
+
+// AppendTime appends t to b as the JSON string encoded by Marshal without EncodeTimeFormat.
+func AppendTime(b []byte, t time.Time) ([]byte, error) {
+	if y := t.Year(); y < 0 || y >= 10000 {
+		// the same error as time.Time.MarshalJSON
+		_, err := t.MarshalJSON()
+		return nil, err
+	}
+	b = append(b, '"')
+	b = t.AppendFormat(b, time.RFC3339Nano)
+	return append(b, '"'), nil
+}
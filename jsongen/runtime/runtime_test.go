@@ -0,0 +1,196 @@
+package runtime_test
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/goccy/go-json/jsongen/runtime"
+)
+
+func assertEq(t *testing.T, msg string, exp interface{}, act interface{}) {
+	t.Helper()
+	if exp != act {
+		t.Fatalf("failed to test for %s. exp=[%v] but act=[%v]", msg, exp, act)
+	}
+}
+
+// errString returns the message of err to compare the errors of Unmarshal and the runtime functions.
+func errString(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%T %v", err, err)
+}
+
+func TestAppend(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		for _, s := range []string{"", "abc", "a\"b\\c\n\r\t\x01\x7f", "<a>&", "\xff\u2028\u2029é\U0001F600"} {
+			expected, err := json.Marshal(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertEq(t, fmt.Sprintf("%q", s), string(expected), string(runtime.AppendString([]byte("x"), s)[1:]))
+		}
+	})
+	t.Run("float", func(t *testing.T) {
+		for _, f := range []float64{0, 1.5, -3.25, 1e-7, 1e20, 1e21, 123456789, math.MaxFloat32} {
+			expected, _ := json.Marshal(f)
+			b, err := runtime.AppendFloat64(nil, f)
+			assertEq(t, fmt.Sprint(f), string(expected)+"<nil>", string(b)+errString(err))
+			expected, _ = json.Marshal(float32(f))
+			b, err = runtime.AppendFloat32(nil, float32(f))
+			assertEq(t, fmt.Sprint(float32(f)), string(expected)+"<nil>", string(b)+errString(err))
+		}
+		for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+			expected := fmt.Sprintf("*json.UnsupportedValueError json: unsupported value: %v", f)
+			_, err := runtime.AppendFloat64(nil, f)
+			assertEq(t, fmt.Sprint(f), expected, errString(err))
+			_, err = runtime.AppendFloat32(nil, float32(f))
+			assertEq(t, fmt.Sprint(float32(f)), expected, errString(err))
+		}
+	})
+	t.Run("time", func(t *testing.T) {
+		for _, tm := range []time.Time{
+			time.Date(2021, 3, 4, 5, 6, 7, 8, time.UTC),
+			time.Date(2021, 3, 4, 5, 6, 7, 0, time.FixedZone("JST", 9*60*60)),
+			time.Date(10000, 1, 1, 0, 0, 0, 0, time.UTC),
+		} {
+			expected, expectedErr := tm.MarshalJSON()
+			b, err := runtime.AppendTime(nil, tm)
+			assertEq(t, tm.String(), errString(expectedErr), errString(err))
+			if err == nil {
+				assertEq(t, tm.String(), string(expected), string(b))
+			}
+		}
+	})
+}
+
+func TestUnmarshalObject(t *testing.T) {
+	type T struct {
+		A int
+	}
+	t.Run("members", func(t *testing.T) {
+		data := `{"a":1, "bé😀\n\"" : [1,{"x":"}"}],"c":"s\"","d":null,"e":true,"f":{},"g":-1.5e3}`
+		var expected map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(data), &expected); err != nil {
+			t.Fatal(err)
+		}
+		members := 0
+		err := runtime.UnmarshalObject([]byte(data), &T{}, func(key, value []byte) error {
+			members++
+			assertEq(t, string(key), string(expected[string(key)]), string(value))
+			return nil
+		})
+		assertEq(t, "error", "<nil>", errString(err))
+		assertEq(t, "members", len(expected), members)
+	})
+	t.Run("empty", func(t *testing.T) {
+		for _, data := range []string{`{}`, ` { } `, `null`} {
+			err := runtime.UnmarshalObject([]byte(data), &T{}, func(key, value []byte) error {
+				t.Fatalf("unexpected key %s", key)
+				return nil
+			})
+			assertEq(t, data, "<nil>", errString(err))
+		}
+	})
+	t.Run("type mismatch", func(t *testing.T) {
+		for _, data := range []string{`[1]`, `"x"`, `123`, `true`} {
+			expected := json.Unmarshal([]byte(data), &T{})
+			err := runtime.UnmarshalObject([]byte(data), &T{}, func(key, value []byte) error {
+				return nil
+			})
+			assertEq(t, data, errString(expected), errString(err))
+			assertEq(t, data+" offset", expected.(*json.UnmarshalTypeError).Offset, err.(*json.UnmarshalTypeError).Offset)
+		}
+	})
+	t.Run("syntax error", func(t *testing.T) {
+		for _, data := range []string{`{"a":1`, `{"a" 1}`, `{"a":1 "b":2}`, `{"a":tru}`, `{"\x":1}`} {
+			err := runtime.UnmarshalObject([]byte(data), &T{}, func(key, value []byte) error {
+				return nil
+			})
+			if _, ok := err.(*json.SyntaxError); !ok {
+				t.Fatalf("%s: expected SyntaxError but got %v", data, err)
+			}
+		}
+	})
+}
+
+type (
+	age   int8
+	count uint16
+)
+
+func TestUnmarshalScalar(t *testing.T) {
+	inputs := []string{
+		`true`, `false`, `null`, `1`, `-1`, `-0`, `300`, `-129`, `1.5`, `1e3`, `1e400`,
+		`99999999999999999999`, `"x"`, `"a\tb\"é"`, `{}`, `[]`,
+	}
+	for _, data := range inputs {
+		for _, tc := range []struct {
+			name      string
+			expected  interface{}
+			actual    interface{}
+			unmarshal func([]byte, interface{}) error
+		}{
+			{"bool", new(bool), new(bool), runtime.UnmarshalBool},
+			{"string", new(string), new(string), runtime.UnmarshalString},
+			{"int", new(int), new(int), runtime.UnmarshalInt},
+			{"int8", new(age), new(age), runtime.UnmarshalInt},
+			{"uint", new(uint), new(uint), runtime.UnmarshalUint},
+			{"uint16", new(count), new(count), runtime.UnmarshalUint},
+			{"float32", new(float32), new(float32), runtime.UnmarshalFloat},
+			{"float64", new(float64), new(float64), runtime.UnmarshalFloat},
+		} {
+			msg := tc.name + " " + data
+			expected := json.Unmarshal([]byte(data), tc.expected)
+			err := tc.unmarshal([]byte(data), tc.actual)
+			assertEq(t, msg, errString(expected), errString(err))
+			if err == nil {
+				assertEq(t, msg, reflect.ValueOf(tc.expected).Elem().Interface(), reflect.ValueOf(tc.actual).Elem().Interface())
+			}
+		}
+	}
+}
+
+func TestUnquote(t *testing.T) {
+	for _, tc := range []struct {
+		data     string
+		expected string
+	}{
+		{`"abc"`, `abc`},
+		{`"a\nb"`, "a\nb"},
+		{`"é😀"`, "é\U0001F600"},
+		{`null`, `null`},
+	} {
+		b, err := runtime.Unquote([]byte(tc.data))
+		assertEq(t, tc.data, "<nil>", errString(err))
+		assertEq(t, tc.data, tc.expected, string(b))
+	}
+	_, err := runtime.Unquote([]byte(`1`))
+	if _, ok := err.(*json.UnmarshalTypeError); !ok {
+		t.Fatalf("expected UnmarshalTypeError but got %v", err)
+	}
+}
+
+func TestLowerKey(t *testing.T) {
+	for _, tc := range []struct {
+		key      string
+		expected string
+	}{
+		{"Name", "name"},
+		{"NAME", "name"},
+		{"ÉTÉ", "été"},
+		{"name", ""},
+		{"été", ""},
+		{"", ""},
+	} {
+		assertEq(t, tc.key, tc.expected, string(runtime.LowerKey([]byte(tc.key))))
+	}
+	if runtime.LowerKey([]byte("name")) != nil {
+		t.Fatal("expected nil for the lower case key")
+	}
+}
@@ -1,5 +1,10 @@
 package json
 
+import (
+	"io"
+	"reflect"
+)
+
 type EncodeOption func(*Encoder) error
 
 func UnorderedMap() EncodeOption {
@@ -8,3 +13,139 @@ func UnorderedMap() EncodeOption {
 		return nil
 	}
 }
+
+// FilterFields restricts the output to the struct fields selected by paths.
+// A path is a dot-separated list of JSON keys such as "owner.email",
+// and selecting a field selects all fields of its value.
+// Slices, arrays and maps pass the filter to their elements.
+// The output of MarshalJSON and MarshalText is not filtered.
+func FilterFields(paths ...string) EncodeOption {
+	filter, err := newFieldFilter(paths)
+	return func(e *Encoder) error {
+		if err != nil {
+			return err
+		}
+		e.fieldFilter = filter
+		return nil
+	}
+}
+
+// StrictTagOptions makes Marshal return UnsupportedTagOptionError
+// if a struct field tag has an option that is not supported, such as a misspelled "omitempty".
+// By default unknown options are ignored like encoding/json.
+func StrictTagOptions() EncodeOption {
+	return func(e *Encoder) error {
+		e.strictTagOptions = true
+		return nil
+	}
+}
+
+// EncodeTrace writes each opcode executed by the encoder to w with the pointer stored in its slot,
+// in the same format as DumpEncodeProgram. It is intended for debugging and slows down the encoding.
+func EncodeTrace(w io.Writer) EncodeOption {
+	return func(e *Encoder) error {
+		e.trace = w
+		return nil
+	}
+}
+
+// CustomEncoder registers fn as the encoder of typ only for the marshaling with this option.
+// It takes precedence over the encoder registered by RegisterEncoder, and nil fn disables it.
+// The codes compiled with it are cached by the type and the address of fn,
+// so pass the same function for each call instead of a new closure.
+func CustomEncoder(typ reflect.Type, fn EncoderFunc) EncodeOption {
+	return func(e *Encoder) error {
+		e.setCustomEncoder(typ, fn)
+		return nil
+	}
+}
+
+// EncodeTimeFormat sets the format of time.Time, which is used instead of time.Time.MarshalJSON.
+func EncodeTimeFormat(f TimeFormat) EncodeOption {
+	return func(e *Encoder) error {
+		e.timeFormat = f
+		return nil
+	}
+}
+
+// EncodeDurationFormat sets the format of time.Duration.
+func EncodeDurationFormat(f DurationFormat) EncodeOption {
+	return func(e *Encoder) error {
+		e.durationFormat = f
+		return nil
+	}
+}
+
+// DecodeOption configures decoding. Pass it to UnmarshalWithOption or Decoder.DecodeWithOption.
+// UnmarshalWithOption applies the options to that call only, and Unmarshal decodes without options.
+// Decoder.DecodeWithOption sets the options on the Decoder, so they also apply to its later Decode calls.
+type DecodeOption func(*Decoder) error
+
+// DecodeDisallowUnknownFields is the DecodeOption version of Decoder.DisallowUnknownFields.
+func DecodeDisallowUnknownFields() DecodeOption {
+	return func(d *Decoder) error {
+		d.ctx.disallowUnknownFields = true
+		return nil
+	}
+}
+
+// DecodeUseNumber is the DecodeOption version of Decoder.UseNumber.
+func DecodeUseNumber() DecodeOption {
+	return func(d *Decoder) error {
+		d.ctx.useNumber = true
+		return nil
+	}
+}
+
+// DecodeCaseSensitive is the DecodeOption version of Decoder.CaseSensitive.
+func DecodeCaseSensitive() DecodeOption {
+	return func(d *Decoder) error {
+		d.ctx.caseSensitive = true
+		return nil
+	}
+}
+
+// DecodeCollectErrors is the DecodeOption version of Decoder.CollectErrors.
+func DecodeCollectErrors() DecodeOption {
+	return func(d *Decoder) error {
+		d.ctx.collectErrors = true
+		return nil
+	}
+}
+
+// DecodeStrictTagOptions is the DecodeOption version of Decoder.StrictTagOptions.
+func DecodeStrictTagOptions() DecodeOption {
+	return func(d *Decoder) error {
+		d.ctx.strictTagOptions = true
+		return nil
+	}
+}
+
+// DecodeCustomDecoder registers fn as the decoder of typ only for the unmarshaling with this option.
+// It takes precedence over the decoder registered by RegisterDecoder, and nil fn disables it.
+// The decoders compiled with it are cached by the type and the address of fn,
+// so pass the same function for each call instead of a new closure.
+func DecodeCustomDecoder(typ reflect.Type, fn DecoderFunc) DecodeOption {
+	return func(d *Decoder) error {
+		d.setCustomDecoder(typ, fn)
+		return nil
+	}
+}
+
+// DecodeTimeFormat sets the format of time.Time, which is used instead of time.Time.UnmarshalJSON.
+// null leaves time.Time unchanged.
+func DecodeTimeFormat(f TimeFormat) DecodeOption {
+	return func(d *Decoder) error {
+		d.ctx.timeFormat = f
+		return nil
+	}
+}
+
+// DecodeDurationFormat sets the format of time.Duration.
+// null leaves time.Duration unchanged.
+func DecodeDurationFormat(f DurationFormat) DecodeOption {
+	return func(d *Decoder) error {
+		d.ctx.durationFormat = f
+		return nil
+	}
+}
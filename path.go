@@ -0,0 +1,789 @@
+package json
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"unsafe"
+)
+
+// Path is a compiled JSONPath expression such as "$.store.book[*].author".
+//
+// The following syntax is supported.
+//
+//	$                  the root value
+//	.name ['name']     member of object ( ["name"] is also accepted )
+//	.* [*]             all members of object or all elements of array
+//	[0] [-1]           element of array ( negative index counts from the end )
+//	[start:end:step]   elements of array in the range
+//	[0,2] ['a','b']    union of selectors
+//	..name ..* ..[0]   the selectors applied to the value and all of its descendants
+//	[?(@.x op lit)]    elements or members that satisfy the comparison
+//
+// A filter compares a value under @ ( e.g. @.price or @['price'][0] ) with a number,
+// a quoted string, true, false or null by ==, !=, <, <=, > or >=.
+// [?(@.x)] selects the values that have x.
+//
+// Path is evaluated over raw bytes with the scanners of the decoder,
+// so it does not build Go values of the document.
+type Path struct {
+	str      string
+	segments []*pathSegment
+}
+
+type pathSegment struct {
+	descendant bool
+	selectors  []*pathSelector
+}
+
+type pathSelectorType int
+
+const (
+	pathSelectorName pathSelectorType = iota
+	pathSelectorWildcard
+	pathSelectorIndex
+	pathSelectorSlice
+	pathSelectorFilter
+)
+
+type pathSelector struct {
+	typ    pathSelectorType
+	name   string
+	index  int
+	slice  [3]*int // start, end, step
+	filter *pathFilter
+}
+
+type pathFilter struct {
+	path  []string    // relative path from @
+	op    string      // empty for the existence test
+	value interface{} // float64, string, bool or nil
+}
+
+// CompilePath parses a JSONPath expression and returns Path.
+func CompilePath(path string) (*Path, error) {
+	p := &pathParser{path: path}
+	segments, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &Path{str: path, segments: segments}, nil
+}
+
+func (p *Path) String() string {
+	return p.str
+}
+
+// Extract returns the values that match the path in data, in the document order
+// of each segment.
+func (p *Path) Extract(data []byte) ([]RawMessage, error) {
+	return extractPath(data, p.segments)
+}
+
+// ExtractReader returns the values that match the path in the document read from r,
+// in the same order as Extract. It walks the stream and skips the values that cannot match,
+// so only the matched values are kept in memory. A descendant segment ( .. ), a negative index
+// and a negative slice need the whole value they are applied to, so the value is buffered.
+func (p *Path) ExtractReader(r io.Reader) ([]RawMessage, error) {
+	s := newStream(r, &decodeRuntimeContext{})
+	s.read()
+	s.skipWhiteSpace()
+	if valueTypeName[s.char()] == "" {
+		return nil, errNotAtBeginningOfValue(s.totalOffset())
+	}
+	return walkPath(s, p.segments)
+}
+
+// Unmarshal decodes the values that match the path in data into v.
+// v must be a pointer to a slice, and each value is stored to an element of the slice.
+func (p *Path) Unmarshal(data []byte, v interface{}, opts ...DecodeOption) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return &InvalidUnmarshalError{Type: reflect.TypeOf(v)}
+	}
+	src := make([]byte, len(data)+1) // append nul byte to end
+	copy(src, data)
+	cursors, err := evalPath(src, p.segments)
+	if err != nil {
+		return err
+	}
+	var d Decoder
+	for _, opt := range opts {
+		if err := opt(&d); err != nil {
+			return err
+		}
+	}
+	sliceType := rv.Elem().Type()
+	ptrType := type2rtype(reflect.PtrTo(sliceType.Elem()))
+	dec, err := d.compileToGetDecoder(uintptr(unsafe.Pointer(ptrType)), ptrType)
+	if err != nil {
+		return err
+	}
+	slice := reflect.MakeSlice(sliceType, len(cursors), len(cursors))
+	for i, cursor := range cursors {
+		ptr := unsafe.Pointer(slice.Index(i).UnsafeAddr())
+		if _, err := dec.decode(&d.ctx, src, cursor, ptr); err != nil {
+			return d.decodeError(typeErrorWithIndex(err, i))
+		}
+	}
+	rv.Elem().Set(slice)
+	return nil
+}
+
+func extractPath(data []byte, segments []*pathSegment) ([]RawMessage, error) {
+	src := make([]byte, len(data)+1) // append nul byte to end
+	copy(src, data)
+	cursors, err := evalPath(src, segments)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]RawMessage, 0, len(cursors))
+	for _, cursor := range cursors {
+		end, err := skipValue(src, cursor)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, append(RawMessage(nil), data[cursor:end]...))
+	}
+	return values, nil
+}
+
+// evalPath returns the cursors at the beginning of the matched values in buf.
+func evalPath(buf []byte, segments []*pathSegment) ([]int64, error) {
+	root := skipWhiteSpace(buf, 0)
+	if valueTypeName[buf[root]] == "" {
+		return nil, errNotAtBeginningOfValue(root)
+	}
+	nodes := []int64{root}
+	for _, segment := range segments {
+		var matched []int64
+		for _, node := range nodes {
+			var err error
+			matched, err = segment.apply(buf, node, matched)
+			if err != nil {
+				return nil, err
+			}
+		}
+		nodes = matched
+	}
+	return nodes, nil
+}
+
+// pathChild is a member of object ( key is not nil ) or an element of array.
+type pathChild struct {
+	key     []byte // quoted key
+	escaped bool
+	cursor  int64
+}
+
+// pathChildren returns the members of object or the elements of array at cursor.
+func pathChildren(buf []byte, cursor int64) ([]pathChild, error) {
+	var children []pathChild
+	switch buf[cursor] {
+	case '{':
+		cursor = skipWhiteSpace(buf, cursor+1)
+		if buf[cursor] == '}' {
+			return nil, nil
+		}
+		for {
+			if buf[cursor] != '"' {
+				return nil, errExpected("object key", cursor)
+			}
+			start := cursor
+			c, escaped, err := scanString(buf, cursor)
+			if err != nil {
+				return nil, err
+			}
+			cursor = skipWhiteSpace(buf, c)
+			if buf[cursor] != ':' {
+				return nil, errExpected("colon after object key", cursor)
+			}
+			cursor = skipWhiteSpace(buf, cursor+1)
+			children = append(children, pathChild{key: buf[start:c], escaped: escaped, cursor: cursor})
+			cursor, err = skipValue(buf, cursor)
+			if err != nil {
+				return nil, err
+			}
+			cursor = skipWhiteSpace(buf, cursor)
+			switch buf[cursor] {
+			case ',':
+				cursor = skipWhiteSpace(buf, cursor+1)
+			case '}':
+				return children, nil
+			default:
+				return nil, errExpected("comma after object element", cursor)
+			}
+		}
+	case '[':
+		cursor = skipWhiteSpace(buf, cursor+1)
+		if buf[cursor] == ']' {
+			return nil, nil
+		}
+		for {
+			children = append(children, pathChild{cursor: cursor})
+			c, err := skipValue(buf, cursor)
+			if err != nil {
+				return nil, err
+			}
+			cursor = skipWhiteSpace(buf, c)
+			switch buf[cursor] {
+			case ',':
+				cursor = skipWhiteSpace(buf, cursor+1)
+			case ']':
+				return children, nil
+			default:
+				return nil, errExpected("comma after array element", cursor)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// apply appends the values selected from the value at cursor to dst.
+func (s *pathSegment) apply(buf []byte, cursor int64, dst []int64) ([]int64, error) {
+	children, err := pathChildren(buf, cursor)
+	if err != nil {
+		return nil, err
+	}
+	isArray := buf[cursor] == '['
+	for _, sel := range s.selectors {
+		if dst, err = sel.apply(buf, children, isArray, dst); err != nil {
+			return nil, err
+		}
+	}
+	if !s.descendant {
+		return dst, nil
+	}
+	for _, child := range children {
+		if dst, err = s.apply(buf, child.cursor, dst); err != nil {
+			return nil, err
+		}
+	}
+	return dst, nil
+}
+
+func (s *pathSelector) apply(buf []byte, children []pathChild, isArray bool, dst []int64) ([]int64, error) {
+	switch s.typ {
+	case pathSelectorName:
+		if isArray {
+			return dst, nil
+		}
+		for _, child := range children {
+			matched, err := matchKey(child.key, child.escaped, s.name)
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				dst = append(dst, child.cursor)
+			}
+		}
+	case pathSelectorWildcard:
+		for _, child := range children {
+			dst = append(dst, child.cursor)
+		}
+	case pathSelectorIndex:
+		if !isArray {
+			return dst, nil
+		}
+		idx := s.index
+		if idx < 0 {
+			idx += len(children)
+		}
+		if 0 <= idx && idx < len(children) {
+			dst = append(dst, children[idx].cursor)
+		}
+	case pathSelectorSlice:
+		if !isArray {
+			return dst, nil
+		}
+		for _, idx := range s.sliceIndexes(len(children)) {
+			dst = append(dst, children[idx].cursor)
+		}
+	case pathSelectorFilter:
+		for _, child := range children {
+			matched, err := s.filter.match(buf, child.cursor)
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				dst = append(dst, child.cursor)
+			}
+		}
+	}
+	return dst, nil
+}
+
+// sliceIndexes returns the indexes of array of length n selected by [start:end:step].
+func (s *pathSelector) sliceIndexes(n int) []int {
+	step := 1
+	if s.slice[2] != nil {
+		step = *s.slice[2]
+	}
+	if step == 0 {
+		return nil
+	}
+	normalize := func(i int) int {
+		if i < 0 {
+			return i + n
+		}
+		return i
+	}
+	clamp := func(i, min, max int) int {
+		if i < min {
+			return min
+		}
+		if i > max {
+			return max
+		}
+		return i
+	}
+	var indexes []int
+	if step > 0 {
+		start, end := 0, n
+		if s.slice[0] != nil {
+			start = normalize(*s.slice[0])
+		}
+		if s.slice[1] != nil {
+			end = normalize(*s.slice[1])
+		}
+		for i := clamp(start, 0, n); i < clamp(end, 0, n); i += step {
+			indexes = append(indexes, i)
+		}
+		return indexes
+	}
+	start, end := n-1, -n-1
+	if s.slice[0] != nil {
+		start = normalize(*s.slice[0])
+	}
+	if s.slice[1] != nil {
+		end = normalize(*s.slice[1])
+	}
+	for i := clamp(start, -1, n-1); clamp(end, -1, n-1) < i; i += step {
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+// match reports whether the value at cursor satisfies the filter.
+func (f *pathFilter) match(buf []byte, cursor int64) (bool, error) {
+	if len(f.path) > 0 {
+		if buf[cursor] != '{' && buf[cursor] != '[' {
+			return false, nil
+		}
+		c, err := lookupValue(buf[cursor:], f.path)
+		if err != nil {
+			if _, ok := err.(*PathError); ok {
+				return false, nil
+			}
+			return false, err
+		}
+		cursor += c
+	}
+	if f.op == "" {
+		return true, nil
+	}
+	value, ok, err := pathScalarValue(buf, cursor)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return f.op == "!=", nil
+	}
+	switch f.op {
+	case "==":
+		return value == f.value, nil
+	case "!=":
+		return value != f.value, nil
+	}
+	switch v := value.(type) {
+	case float64:
+		lit, ok := f.value.(float64)
+		if !ok {
+			return false, nil
+		}
+		switch f.op {
+		case "<":
+			return v < lit, nil
+		case "<=":
+			return v <= lit, nil
+		case ">":
+			return v > lit, nil
+		case ">=":
+			return v >= lit, nil
+		}
+	case string:
+		lit, ok := f.value.(string)
+		if !ok {
+			return false, nil
+		}
+		switch f.op {
+		case "<":
+			return v < lit, nil
+		case "<=":
+			return v <= lit, nil
+		case ">":
+			return v > lit, nil
+		case ">=":
+			return v >= lit, nil
+		}
+	}
+	return false, nil
+}
+
+// pathScalarValue returns the value at cursor as float64, string, bool or nil.
+// It reports false for object and array.
+func pathScalarValue(buf []byte, cursor int64) (interface{}, bool, error) {
+	switch buf[cursor] {
+	case '"':
+		c, escaped, err := scanString(buf, cursor)
+		if err != nil {
+			return nil, false, err
+		}
+		if !escaped {
+			return string(buf[cursor+1 : c-1]), true, nil
+		}
+		src := make([]byte, c-cursor+1)
+		copy(src, buf[cursor:c])
+		unescaped, _, err := newStringDecoder(stringType).decodeByte(src, 0)
+		if err != nil {
+			return nil, false, err
+		}
+		return string(unescaped), true, nil
+	case 't':
+		return true, true, nil
+	case 'f':
+		return false, true, nil
+	case 'n':
+		return nil, true, nil
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		end, err := skipValue(buf, cursor)
+		if err != nil {
+			return nil, false, err
+		}
+		f, err := strconv.ParseFloat(string(buf[cursor:end]), 64)
+		if err != nil {
+			return nil, false, err
+		}
+		return f, true, nil
+	}
+	return nil, false, nil
+}
+
+type pathParser struct {
+	path string
+	pos  int
+}
+
+func (p *pathParser) error(msg string) error {
+	return errInvalidPath(p.path, fmt.Sprintf("%s at %d", msg, p.pos))
+}
+
+func (p *pathParser) parse() ([]*pathSegment, error) {
+	if p.path == "" || p.path[0] != '$' {
+		return nil, errInvalidPath(p.path, "JSONPath must start with '$'")
+	}
+	p.pos = 1
+	var segments []*pathSegment
+	for p.pos < len(p.path) {
+		segment, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+func (p *pathParser) parseSegment() (*pathSegment, error) {
+	segment := &pathSegment{}
+	switch p.path[p.pos] {
+	case '.':
+		p.pos++
+		if p.pos < len(p.path) && p.path[p.pos] == '.' {
+			segment.descendant = true
+			p.pos++
+			if p.pos < len(p.path) && p.path[p.pos] == '[' {
+				selectors, err := p.parseBracket()
+				if err != nil {
+					return nil, err
+				}
+				segment.selectors = selectors
+				return segment, nil
+			}
+		}
+		if p.pos < len(p.path) && p.path[p.pos] == '*' {
+			p.pos++
+			segment.selectors = []*pathSelector{{typ: pathSelectorWildcard}}
+			return segment, nil
+		}
+		name := p.parseName()
+		if name == "" {
+			return nil, p.error("empty member name")
+		}
+		segment.selectors = []*pathSelector{{typ: pathSelectorName, name: name}}
+	case '[':
+		selectors, err := p.parseBracket()
+		if err != nil {
+			return nil, err
+		}
+		segment.selectors = selectors
+	default:
+		return nil, p.error(fmt.Sprintf("unexpected character %q", p.path[p.pos]))
+	}
+	return segment, nil
+}
+
+// parseName parses the member name of the dot notation.
+func (p *pathParser) parseName() string {
+	start := p.pos
+	for p.pos < len(p.path) {
+		switch p.path[p.pos] {
+		case '.', '[', ' ', ')', ']', '=', '!', '<', '>':
+			return p.path[start:p.pos]
+		}
+		p.pos++
+	}
+	return p.path[start:p.pos]
+}
+
+func (p *pathParser) skipWhiteSpace() {
+	for p.pos < len(p.path) && p.path[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *pathParser) char() byte {
+	if p.pos < len(p.path) {
+		return p.path[p.pos]
+	}
+	return nul
+}
+
+// parseBracket parses the selectors in [ ] separated by comma.
+func (p *pathParser) parseBracket() ([]*pathSelector, error) {
+	p.pos++
+	var selectors []*pathSelector
+	for {
+		p.skipWhiteSpace()
+		selector, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+		p.skipWhiteSpace()
+		switch p.char() {
+		case ',':
+			p.pos++
+		case ']':
+			p.pos++
+			return selectors, nil
+		default:
+			return nil, p.error("expected ',' or ']'")
+		}
+	}
+}
+
+func (p *pathParser) parseSelector() (*pathSelector, error) {
+	switch c := p.char(); c {
+	case '\'', '"':
+		name, err := p.parseQuoted()
+		if err != nil {
+			return nil, err
+		}
+		return &pathSelector{typ: pathSelectorName, name: name}, nil
+	case '*':
+		p.pos++
+		return &pathSelector{typ: pathSelectorWildcard}, nil
+	case '?':
+		p.pos++
+		filter, err := p.parseFilter()
+		if err != nil {
+			return nil, err
+		}
+		return &pathSelector{typ: pathSelectorFilter, filter: filter}, nil
+	case '-', ':', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return p.parseIndexOrSlice()
+	}
+	return nil, p.error("invalid selector")
+}
+
+// parseQuoted parses the quoted member name. \ escapes the next character.
+func (p *pathParser) parseQuoted() (string, error) {
+	quote := p.path[p.pos]
+	p.pos++
+	var name []byte
+	for p.pos < len(p.path) {
+		c := p.path[p.pos]
+		switch c {
+		case quote:
+			p.pos++
+			return string(name), nil
+		case '\\':
+			p.pos++
+			if p.pos == len(p.path) {
+				return "", p.error("unterminated quoted string")
+			}
+			c = p.path[p.pos]
+		}
+		name = append(name, c)
+		p.pos++
+	}
+	return "", p.error("unterminated quoted string")
+}
+
+func (p *pathParser) parseInt() (*int, error) {
+	start := p.pos
+	if p.char() == '-' {
+		p.pos++
+	}
+	for '0' <= p.char() && p.char() <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		return nil, nil
+	}
+	i, err := strconv.Atoi(p.path[start:p.pos])
+	if err != nil {
+		return nil, p.error(fmt.Sprintf("invalid integer %q", p.path[start:p.pos]))
+	}
+	return &i, nil
+}
+
+func (p *pathParser) parseIndexOrSlice() (*pathSelector, error) {
+	start, err := p.parseInt()
+	if err != nil {
+		return nil, err
+	}
+	p.skipWhiteSpace()
+	if p.char() != ':' {
+		if start == nil {
+			return nil, p.error("invalid index")
+		}
+		return &pathSelector{typ: pathSelectorIndex, index: *start}, nil
+	}
+	sel := &pathSelector{typ: pathSelectorSlice}
+	sel.slice[0] = start
+	for i := 1; i < 3 && p.char() == ':'; i++ {
+		p.pos++
+		p.skipWhiteSpace()
+		if sel.slice[i], err = p.parseInt(); err != nil {
+			return nil, err
+		}
+		p.skipWhiteSpace()
+	}
+	return sel, nil
+}
+
+// parseFilter parses ?(@.x op lit) or ?@.x op lit.
+func (p *pathParser) parseFilter() (*pathFilter, error) {
+	p.skipWhiteSpace()
+	paren := p.char() == '('
+	if paren {
+		p.pos++
+		p.skipWhiteSpace()
+	}
+	if p.char() != '@' {
+		return nil, p.error("filter must start with '@'")
+	}
+	p.pos++
+	filter := &pathFilter{}
+	path, err := p.parseRelativePath()
+	if err != nil {
+		return nil, err
+	}
+	filter.path = path
+	p.skipWhiteSpace()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if len(p.path[p.pos:]) >= len(op) && p.path[p.pos:p.pos+len(op)] == op {
+			filter.op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if filter.op != "" {
+		p.skipWhiteSpace()
+		if filter.value, err = p.parseLiteral(); err != nil {
+			return nil, err
+		}
+		p.skipWhiteSpace()
+	}
+	if paren {
+		if p.char() != ')' {
+			return nil, p.error("expected ')'")
+		}
+		p.pos++
+	}
+	return filter, nil
+}
+
+// parseRelativePath parses the member names and array indexes after @.
+func (p *pathParser) parseRelativePath() ([]string, error) {
+	var path []string
+	for {
+		switch p.char() {
+		case '.':
+			p.pos++
+			name := p.parseName()
+			if name == "" {
+				return nil, p.error("empty member name")
+			}
+			path = append(path, name)
+		case '[':
+			p.pos++
+			p.skipWhiteSpace()
+			switch p.char() {
+			case '\'', '"':
+				name, err := p.parseQuoted()
+				if err != nil {
+					return nil, err
+				}
+				path = append(path, name)
+			default:
+				idx, err := p.parseInt()
+				if err != nil {
+					return nil, err
+				}
+				if idx == nil || *idx < 0 {
+					return nil, p.error("invalid index in filter")
+				}
+				path = append(path, strconv.Itoa(*idx))
+			}
+			p.skipWhiteSpace()
+			if p.char() != ']' {
+				return nil, p.error("expected ']'")
+			}
+			p.pos++
+		default:
+			return path, nil
+		}
+	}
+}
+
+func (p *pathParser) parseLiteral() (interface{}, error) {
+	switch c := p.char(); c {
+	case '\'', '"':
+		return p.parseQuoted()
+	case 't', 'f', 'n':
+		for _, lit := range []struct {
+			str   string
+			value interface{}
+		}{{"true", true}, {"false", false}, {"null", nil}} {
+			if len(p.path[p.pos:]) >= len(lit.str) && p.path[p.pos:p.pos+len(lit.str)] == lit.str {
+				p.pos += len(lit.str)
+				return lit.value, nil
+			}
+		}
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		start := p.pos
+		for p.pos < len(p.path) && floatTable[p.path[p.pos]] {
+			p.pos++
+		}
+		f, err := strconv.ParseFloat(p.path[start:p.pos], 64)
+		if err != nil {
+			return nil, p.error(fmt.Sprintf("invalid number %q", p.path[start:p.pos]))
+		}
+		return f, nil
+	}
+	return nil, p.error("invalid literal")
+}
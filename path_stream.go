@@ -0,0 +1,251 @@
+package json
+
+import (
+	"bytes"
+)
+
+// walkPath returns the values selected by segments from the value at the cursor of s.
+// The values of each segment are grouped by selector in the same way as evalPath.
+func walkPath(s *stream, segments []*pathSegment) ([]RawMessage, error) {
+	s.skipWhiteSpace()
+	if len(segments) == 0 {
+		raw, err := captureStreamValue(s)
+		if err != nil {
+			return nil, err
+		}
+		return []RawMessage{raw}, nil
+	}
+	segment := segments[0]
+	if !segment.streamable() {
+		raw, err := captureStreamValue(s)
+		if err != nil {
+			return nil, err
+		}
+		return extractPath(raw, segments)
+	}
+	if c := s.char(); c != '{' && c != '[' {
+		// no selector selects a value from scalar
+		return nil, skipStreamValue(s)
+	}
+	hasFilter := segment.hasFilter()
+	results := make([][]RawMessage, len(segment.selectors))
+	selected := make([]int, 0, len(segment.selectors))
+	err := walkStreamChildren(s, func(key []byte, index int) error {
+		var child RawMessage
+		if hasFilter {
+			raw, err := captureStreamValue(s)
+			if err != nil {
+				return err
+			}
+			child = raw
+		}
+		selected = selected[:0]
+		for i, sel := range segment.selectors {
+			matched, err := sel.selects(key, index, child)
+			if err != nil {
+				return err
+			}
+			if matched {
+				selected = append(selected, i)
+			}
+		}
+		switch {
+		case len(selected) == 0:
+			if hasFilter {
+				return nil
+			}
+			return skipStreamValue(s)
+		case len(selected) == 1 && !hasFilter:
+			values, err := walkPath(s, segments[1:])
+			if err != nil {
+				return err
+			}
+			results[selected[0]] = append(results[selected[0]], values...)
+			return nil
+		}
+		if child == nil {
+			raw, err := captureStreamValue(s)
+			if err != nil {
+				return err
+			}
+			child = raw
+		}
+		for _, i := range selected {
+			values, err := extractPath(child, segments[1:])
+			if err != nil {
+				return err
+			}
+			results[i] = append(results[i], values...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var values []RawMessage
+	for _, v := range results {
+		values = append(values, v...)
+	}
+	return values, nil
+}
+
+// streamable reports whether the segment selects the children without looking at the other children,
+// so that it is applied while reading the children.
+func (s *pathSegment) streamable() bool {
+	if s.descendant {
+		return false
+	}
+	for _, sel := range s.selectors {
+		switch sel.typ {
+		case pathSelectorIndex:
+			if sel.index < 0 {
+				return false
+			}
+		case pathSelectorSlice:
+			if (sel.slice[0] != nil && *sel.slice[0] < 0) ||
+				(sel.slice[1] != nil && *sel.slice[1] < 0) ||
+				(sel.slice[2] != nil && *sel.slice[2] <= 0) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (s *pathSegment) hasFilter() bool {
+	for _, sel := range s.selectors {
+		if sel.typ == pathSelectorFilter {
+			return true
+		}
+	}
+	return false
+}
+
+// selects reports whether the streamable selector selects the member of object that has the quoted key,
+// or the element of array at index if key is nil. value is the child only for the filter.
+func (s *pathSelector) selects(key []byte, index int, value RawMessage) (bool, error) {
+	switch s.typ {
+	case pathSelectorName:
+		if key == nil {
+			return false, nil
+		}
+		return matchKey(key, bytes.IndexByte(key, '\\') >= 0, s.name)
+	case pathSelectorWildcard:
+		return true, nil
+	case pathSelectorIndex:
+		return key == nil && index == s.index, nil
+	case pathSelectorSlice:
+		if key != nil {
+			return false, nil
+		}
+		start, step := 0, 1
+		if s.slice[0] != nil {
+			start = *s.slice[0]
+		}
+		if s.slice[2] != nil {
+			step = *s.slice[2]
+		}
+		if index < start || (s.slice[1] != nil && index >= *s.slice[1]) {
+			return false, nil
+		}
+		return (index-start)%step == 0, nil
+	case pathSelectorFilter:
+		src := make([]byte, len(value)+1) // append nul byte to end
+		copy(src, value)
+		return s.filter.match(src, 0)
+	}
+	return false, nil
+}
+
+// walkStreamChildren calls fn at the beginning of each member of object with its quoted key,
+// or each element of array with its index. fn must read the child.
+// The bytes before each child are discarded from the buffer of s.
+func walkStreamChildren(s *stream, fn func(key []byte, index int) error) error {
+	isObject := s.char() == '{'
+	s.cursor++
+	s.skipWhiteSpace()
+	if (isObject && s.char() == '}') || (!isObject && s.char() == ']') {
+		s.cursor++
+		return nil
+	}
+	for index := 0; ; index++ {
+		s.reset()
+		var key []byte
+		if isObject {
+			if s.char() != '"' {
+				return errExpected("object key", s.totalOffset())
+			}
+			start := s.cursor
+			if err := s.skipValue(); err != nil {
+				return err
+			}
+			key = append(key, s.buf[start:s.cursor]...)
+			s.skipWhiteSpace()
+			if s.char() != ':' {
+				return errExpected("colon after object key", s.totalOffset())
+			}
+			s.cursor++
+			s.skipWhiteSpace()
+		}
+		if err := fn(key, index); err != nil {
+			return err
+		}
+		s.skipWhiteSpace()
+		switch s.char() {
+		case ',':
+			s.cursor++
+			s.skipWhiteSpace()
+		case '}':
+			if !isObject {
+				return errExpected("comma after array element", s.totalOffset())
+			}
+			s.cursor++
+			return nil
+		case ']':
+			if isObject {
+				return errExpected("comma after object element", s.totalOffset())
+			}
+			s.cursor++
+			return nil
+		case nul:
+			return errUnexpectedEndOfJSON("value", s.totalOffset())
+		default:
+			if isObject {
+				return errExpected("comma after object element", s.totalOffset())
+			}
+			return errExpected("comma after array element", s.totalOffset())
+		}
+	}
+}
+
+// captureStreamValue reads the value at the cursor of s and returns the copy of it.
+func captureStreamValue(s *stream) (RawMessage, error) {
+	s.skipWhiteSpace()
+	if valueTypeName[s.char()] == "" {
+		return nil, errNotAtBeginningOfValue(s.totalOffset())
+	}
+	start := s.cursor
+	if err := s.skipValue(); err != nil {
+		return nil, err
+	}
+	return append(RawMessage(nil), s.buf[start:s.cursor]...), nil
+}
+
+// skipStreamValue skips the value at the cursor of s.
+// Unlike stream.skipValue, it discards the bytes of object and array while reading them,
+// so that the buffer does not grow to the size of the value.
+func skipStreamValue(s *stream) error {
+	s.skipWhiteSpace()
+	switch s.char() {
+	case '{', '[':
+		return walkStreamChildren(s, func([]byte, int) error {
+			return skipStreamValue(s)
+		})
+	case nul:
+		return errUnexpectedEndOfJSON("value", s.totalOffset())
+	}
+	if valueTypeName[s.char()] == "" {
+		return errNotAtBeginningOfValue(s.totalOffset())
+	}
+	return s.skipValue()
+}
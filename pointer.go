@@ -0,0 +1,242 @@
+package json
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Get returns the JSON value that the JSON Pointer ( RFC 6901 ) refers to in data,
+// e.g. "/items/3/name". The empty pointer refers to the whole document.
+//
+// Get walks data with the skip functions used by the decoder and builds no Go values.
+// Only the bytes on the way to the value are scanned, so Get does not report
+// syntax errors in the rest of data.
+func Get(data []byte, pointer string) (RawMessage, error) {
+	path, err := parseJSONPointer(pointer)
+	if err != nil {
+		return nil, err
+	}
+	src := make([]byte, len(data)+1) // append nul byte to end
+	copy(src, data)
+	start, err := lookupValue(src, path)
+	if err != nil {
+		if pathErr, ok := err.(*PathError); ok {
+			pathErr.Path = pointer
+		}
+		return nil, err
+	}
+	end, err := skipValue(src, start)
+	if err != nil {
+		return nil, err
+	}
+	return append(RawMessage(nil), data[start:end]...), nil
+}
+
+// parseJSONPointer splits pointer into reference tokens and unescapes "~1" and "~0".
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, errInvalidPath(pointer, "JSON Pointer must start with '/'")
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		if strings.IndexByte(token, '~') < 0 {
+			continue
+		}
+		for j := 0; j < len(token); j++ {
+			if token[j] != '~' {
+				continue
+			}
+			if j+1 == len(token) || (token[j+1] != '0' && token[j+1] != '1') {
+				return nil, errInvalidPath(pointer, "invalid escape sequence in JSON Pointer")
+			}
+		}
+		token = strings.Replace(token, "~1", "/", -1)
+		tokens[i] = strings.Replace(token, "~0", "~", -1)
+	}
+	return tokens, nil
+}
+
+// parsePath parses path as JSON Pointer, or as JSONPath that selects a single value
+// by member names and array indexes, and returns the reference tokens.
+func parsePath(path string) ([]string, error) {
+	if path == "" || path[0] == '/' {
+		return parseJSONPointer(path)
+	}
+	if path[0] != '$' {
+		return nil, errInvalidPath(path, "path must be JSON Pointer or JSONPath starting with '$'")
+	}
+	segments, err := (&pathParser{path: path}).parse()
+	if err != nil {
+		return nil, err
+	}
+	tokens := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		if segment.descendant || len(segment.selectors) != 1 {
+			return nil, errInvalidPath(path, "path must select a single value")
+		}
+		switch sel := segment.selectors[0]; {
+		case sel.typ == pathSelectorName:
+			tokens = append(tokens, sel.name)
+		case sel.typ == pathSelectorIndex && sel.index >= 0:
+			tokens = append(tokens, strconv.Itoa(sel.index))
+		default:
+			return nil, errInvalidPath(path, "path must select a single value")
+		}
+	}
+	return tokens, nil
+}
+
+// lookupValue returns the cursor at the beginning of the value
+// that path refers to in buf. buf must be terminated by nul.
+func lookupValue(buf []byte, path []string) (int64, error) {
+	cursor := skipWhiteSpace(buf, 0)
+	for _, token := range path {
+		var err error
+		switch buf[cursor] {
+		case '{':
+			cursor, err = lookupObjectMember(buf, cursor, token)
+		case '[':
+			idx, ok := parseArrayIndex(token)
+			if !ok {
+				return 0, errPathNotFound(token)
+			}
+			cursor, err = lookupArrayElement(buf, cursor, idx)
+		default:
+			return 0, errPathNotFound(token)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if valueTypeName[buf[cursor]] == "" {
+		return 0, errNotAtBeginningOfValue(cursor)
+	}
+	return cursor, nil
+}
+
+// lookupObjectMember returns the cursor at the beginning of the value of key
+// in the object at cursor.
+func lookupObjectMember(buf []byte, cursor int64, key string) (int64, error) {
+	cursor = skipWhiteSpace(buf, cursor+1)
+	if buf[cursor] == '}' {
+		return 0, errPathNotFound(key)
+	}
+	for {
+		if buf[cursor] != '"' {
+			return 0, errExpected("object key", cursor)
+		}
+		start := cursor
+		c, escaped, err := scanString(buf, cursor)
+		if err != nil {
+			return 0, err
+		}
+		cursor = skipWhiteSpace(buf, c)
+		if buf[cursor] != ':' {
+			return 0, errExpected("colon after object key", cursor)
+		}
+		cursor = skipWhiteSpace(buf, cursor+1)
+		matched, err := matchKey(buf[start:c], escaped, key)
+		if err != nil {
+			return 0, err
+		}
+		if matched {
+			return cursor, nil
+		}
+		cursor, err = skipValue(buf, cursor)
+		if err != nil {
+			return 0, err
+		}
+		cursor = skipWhiteSpace(buf, cursor)
+		switch buf[cursor] {
+		case ',':
+			cursor = skipWhiteSpace(buf, cursor+1)
+		case '}':
+			return 0, errPathNotFound(key)
+		default:
+			return 0, errExpected("comma after object element", cursor)
+		}
+	}
+}
+
+// lookupArrayElement returns the cursor at the beginning of the idx-th element
+// of the array at cursor.
+func lookupArrayElement(buf []byte, cursor int64, idx int) (int64, error) {
+	cursor = skipWhiteSpace(buf, cursor+1)
+	if buf[cursor] == ']' {
+		return 0, errPathNotFound(strconv.Itoa(idx))
+	}
+	for i := 0; ; i++ {
+		if i == idx {
+			return cursor, nil
+		}
+		c, err := skipValue(buf, cursor)
+		if err != nil {
+			return 0, err
+		}
+		cursor = skipWhiteSpace(buf, c)
+		switch buf[cursor] {
+		case ',':
+			cursor = skipWhiteSpace(buf, cursor+1)
+		case ']':
+			return 0, errPathNotFound(strconv.Itoa(idx))
+		default:
+			return 0, errExpected("comma after array element", cursor)
+		}
+	}
+}
+
+// parseArrayIndex parses the array index of JSON Pointer.
+// Leading zeros and "-" ( the element after the last ) are not found in any array.
+func parseArrayIndex(token string) (int, bool) {
+	if token == "" || (token[0] == '0' && len(token) > 1) {
+		return 0, false
+	}
+	for i := 0; i < len(token); i++ {
+		if token[i] < '0' || '9' < token[i] {
+			return 0, false
+		}
+	}
+	idx, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, false
+	}
+	return idx, true
+}
+
+// scanString returns the cursor after the string at cursor
+// and whether the string contains escape sequences.
+func scanString(buf []byte, cursor int64) (int64, bool, error) {
+	escaped := false
+	for cursor++; ; cursor++ {
+		switch buf[cursor] {
+		case '\\':
+			escaped = true
+			cursor++
+			if buf[cursor] == nul {
+				return 0, false, errUnexpectedEndOfJSON("string", cursor)
+			}
+		case '"':
+			return cursor + 1, escaped, nil
+		case nul:
+			return 0, false, errUnexpectedEndOfJSON("string", cursor)
+		}
+	}
+}
+
+// matchKey reports whether the quoted JSON string raw is equal to key.
+func matchKey(raw []byte, escaped bool, key string) (bool, error) {
+	if !escaped {
+		return string(raw[1:len(raw)-1]) == key, nil
+	}
+	// decodeByte unescapes the string in place, so decode the copy of raw
+	src := make([]byte, len(raw)+1)
+	copy(src, raw)
+	unescaped, _, err := newStringDecoder(stringType).decodeByte(src, 0)
+	if err != nil {
+		return false, err
+	}
+	return string(unescaped) == key, nil
+}
@@ -0,0 +1,41 @@
+package json
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// Precompile compiles the encoders and decoders of the types of values and caches them,
+// so that the first Marshal and Unmarshal of the types do not spend time on compiling.
+// It is intended to be called at startup, e.g. json.Precompile(User{}, (*Order)(nil)).
+//
+// For type T of each value, or the type that the value points to if the value is a pointer,
+// Precompile compiles T and *T for Marshal and *T for Unmarshal with the default options.
+// It returns the error of the first type that cannot be compiled such as UnsupportedTypeError,
+// which Marshal or Unmarshal would return for the type.
+func Precompile(values ...interface{}) error {
+	enc := NewEncoder(nil)
+	defer enc.release()
+	var dec Decoder
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		typ := reflect.TypeOf(v)
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		ptrType := reflect.PtrTo(typ)
+		for _, t := range []reflect.Type{typ, ptrType} {
+			rt := type2rtype(t)
+			if _, err := enc.compileToGetOpcodeSet(uintptr(unsafe.Pointer(rt)), rt); err != nil {
+				return err
+			}
+		}
+		rt := type2rtype(ptrType)
+		if _, err := dec.compileToGetDecoder(uintptr(unsafe.Pointer(rt)), rt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
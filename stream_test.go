@@ -6,9 +6,12 @@ package json_test
 
 import (
 	"bytes"
-
+	"fmt"
+	"io"
+	"reflect"
 	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/goccy/go-json"
 )
@@ -456,3 +459,96 @@ func TestHTTPDecoding(t *testing.T) {
 	}
 }
 */
+
+func TestDecodeMixedTokenAndDecode(t *testing.T) {
+	type item struct {
+		ID   int               `json:"id"`
+		Name string            `json:"name"`
+		Tags []string          `json:"tags"`
+		Attr map[string]string `json:"attr"`
+	}
+	const src = ` {"meta": {"count": 3, "ok": false, "ratio": -1.5e2, "note": null}, "items" : [
+		{"id": 1, "name": "first", "tags": ["a", "b"], "attr": {}},
+		{"id": 22, "name": "second item", "tags": [], "attr": {"k": "v"}} ,
+		{"id": 333, "name": "", "tags": null, "attr": {"x": "y", "z": "w"}}
+	], "done": true} `
+	expected := []item{
+		{ID: 1, Name: "first", Tags: []string{"a", "b"}, Attr: map[string]string{}},
+		{ID: 22, Name: "second item", Tags: []string{}, Attr: map[string]string{"k": "v"}},
+		{ID: 333, Name: "", Attr: map[string]string{"x": "y", "z": "w"}},
+	}
+	decodeItems := func(t *testing.T, dec *json.Decoder) {
+		t.Helper()
+		expectToken := func(exp json.Token) {
+			t.Helper()
+			tk, err := dec.Token()
+			if err != nil {
+				t.Fatalf("unexpected error at %v: %v", exp, err)
+			}
+			if !reflect.DeepEqual(exp, tk) {
+				t.Fatalf("expected token %v but got %v", exp, tk)
+			}
+		}
+		expectToken(json.Delim('{'))
+		expectToken("meta")
+		var meta map[string]interface{}
+		if err := dec.Decode(&meta); err != nil {
+			t.Fatal(err)
+		}
+		expectedMeta := map[string]interface{}{"count": float64(3), "ok": false, "ratio": float64(-150), "note": nil}
+		if !reflect.DeepEqual(expectedMeta, meta) {
+			t.Fatalf("expected %v but got %v", expectedMeta, meta)
+		}
+		expectToken("items")
+		expectToken(json.Delim('['))
+		var items []item
+		for dec.More() {
+			var v item
+			if err := dec.Decode(&v); err != nil {
+				t.Fatal(err)
+			}
+			items = append(items, v)
+		}
+		if !reflect.DeepEqual(expected, items) {
+			t.Fatalf("expected %+v but got %+v", expected, items)
+		}
+		expectToken(json.Delim(']'))
+		expectToken("done")
+		expectToken(true)
+		expectToken(json.Delim('}'))
+		if _, err := dec.Token(); err != io.EOF {
+			t.Fatalf("expected io.EOF but got %v", err)
+		}
+	}
+	for size := 1; size <= len(src)+1; size++ {
+		t.Run(fmt.Sprintf("chunk size %d", size), func(t *testing.T) {
+			decodeItems(t, json.NewDecoderWithReadChunkSize(strings.NewReader(src), size))
+		})
+	}
+	t.Run("one byte reader", func(t *testing.T) {
+		decodeItems(t, json.NewDecoder(iotest.OneByteReader(strings.NewReader(src))))
+	})
+	t.Run("missing comma", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(`[{"id": 1} {"id": 2}]`))
+		if _, err := dec.Token(); err != nil {
+			t.Fatal(err)
+		}
+		var v item
+		if err := dec.Decode(&v); err != nil {
+			t.Fatal(err)
+		}
+		if err := dec.Decode(&v); err == nil {
+			t.Fatal("expected error for missing comma")
+		}
+	})
+	t.Run("value as object key", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(`{"id": 1}`))
+		if _, err := dec.Token(); err != nil {
+			t.Fatal(err)
+		}
+		var v int
+		if err := dec.Decode(&v); err == nil {
+			t.Fatal("expected error for decoding object key")
+		}
+	})
+}
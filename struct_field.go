@@ -3,6 +3,7 @@ package json
 import (
 	"reflect"
 	"strings"
+	"sync"
 	"unicode"
 )
 
@@ -29,11 +30,21 @@ func isIgnoredStructField(field reflect.StructField) bool {
 }
 
 type structTag struct {
-	key         string
-	isTaggedKey bool
-	isOmitEmpty bool
-	isString    bool
-	field       reflect.StructField
+	key            string
+	isTaggedKey    bool
+	isOmitEmpty    bool
+	isString       bool
+	isOmitZero     bool
+	unknownOptions []string
+	field          reflect.StructField
+}
+
+// structTagOptions is the set of options that follow the key name in the json struct tag.
+// A new option is supported by adding the function that sets its flag of structTag.
+var structTagOptions = map[string]func(*structTag){
+	"omitempty": func(st *structTag) { st.isOmitEmpty = true },
+	"string":    func(st *structTag) { st.isString = true },
+	"omitzero":  func(st *structTag) { st.isOmitZero = true },
 }
 
 type structTags []*structTag
@@ -76,9 +87,71 @@ func structTagFromField(field reflect.StructField) *structTag {
 		}
 	}
 	st.key = keyName
-	if len(opts) > 1 {
-		st.isOmitEmpty = opts[1] == "omitempty"
-		st.isString = opts[1] == "string"
+	for _, opt := range opts[1:] {
+		if opt == "" {
+			continue
+		}
+		if setOption, exists := structTagOptions[opt]; exists {
+			setOption(st)
+		} else {
+			st.unknownOptions = append(st.unknownOptions, opt)
+		}
 	}
 	return st
 }
+
+// structTagCheck keeps the result of checkStructTagOptions with the compiled codes of the type,
+// so that the type is checked once while the codes are cached.
+type structTagCheck struct {
+	once sync.Once
+	err  error
+}
+
+func (c *structTagCheck) check(typ *rtype) error {
+	c.once.Do(func() {
+		c.err = checkStructTagOptions(typ)
+	})
+	return c.err
+}
+
+// checkStructTagOptions returns UnsupportedTagOptionError
+// if typ or a type reachable from typ has a struct field tag with an unknown option.
+// The types behind interfaces are not checked because they are decided at runtime.
+func checkStructTagOptions(typ *rtype) error {
+	return checkStructTagOptionsWithVisited(rtype2type(typ), map[reflect.Type]struct{}{})
+}
+
+func checkStructTagOptionsWithVisited(typ reflect.Type, visited map[reflect.Type]struct{}) error {
+	if _, exists := visited[typ]; exists {
+		return nil
+	}
+	visited[typ] = struct{}{}
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return checkStructTagOptionsWithVisited(typ.Elem(), visited)
+	case reflect.Map:
+		if err := checkStructTagOptionsWithVisited(typ.Key(), visited); err != nil {
+			return err
+		}
+		return checkStructTagOptionsWithVisited(typ.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if isIgnoredStructField(field) {
+				continue
+			}
+			tag := structTagFromField(field)
+			if len(tag.unknownOptions) > 0 {
+				return &UnsupportedTagOptionError{
+					Type:   typ,
+					Field:  field.Name,
+					Option: tag.unknownOptions[0],
+				}
+			}
+			if err := checkStructTagOptionsWithVisited(field.Type, visited); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
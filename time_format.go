@@ -0,0 +1,77 @@
+package json
+
+import (
+	"reflect"
+	"time"
+)
+
+// TimeFormat is the format of time.Time for EncodeTimeFormat and DecodeTimeFormat.
+// The zero value is the default format, which is the same as time.Time.MarshalJSON and time.Time.UnmarshalJSON
+// ( RFC 3339 string with sub-second precision ).
+type TimeFormat struct {
+	layout string        // layout of time.Format for the format as string
+	escape bool          // whether the formatted string may have characters to escape
+	unit   time.Duration // unit of Unix time for the format as number
+}
+
+// TimeFormatLayout returns the TimeFormat for the string formatted with layout like time.Format.
+func TimeFormatLayout(layout string) TimeFormat {
+	escape := false
+	for i := 0; i < len(layout); i++ {
+		c := layout[i]
+		if c < 0x20 || c >= 0x80 || c == '"' || c == '\\' || c == '<' || c == '>' || c == '&' {
+			escape = true
+			break
+		}
+	}
+	return TimeFormat{layout: layout, escape: escape}
+}
+
+var (
+	// TimeFormatRFC3339 formats time.Time as RFC 3339 string without sub-second precision.
+	TimeFormatRFC3339 = TimeFormatLayout(time.RFC3339)
+
+	// TimeFormatRFC3339Nano formats time.Time as RFC 3339 string with sub-second precision.
+	TimeFormatRFC3339Nano = TimeFormatLayout(time.RFC3339Nano)
+
+	// TimeFormatUnix formats time.Time as the number of seconds elapsed since January 1, 1970 UTC.
+	TimeFormatUnix = TimeFormat{unit: time.Second}
+
+	// TimeFormatUnixMilli formats time.Time as the number of milliseconds elapsed since January 1, 1970 UTC.
+	TimeFormatUnixMilli = TimeFormat{unit: time.Millisecond}
+
+	// TimeFormatUnixNano formats time.Time as the number of nanoseconds elapsed since January 1, 1970 UTC.
+	TimeFormatUnixNano = TimeFormat{unit: time.Nanosecond}
+)
+
+func (f TimeFormat) isDefault() bool {
+	return f == TimeFormat{}
+}
+
+// unixTime returns t as the number of units elapsed since January 1, 1970 UTC.
+func (f TimeFormat) unixTime(t time.Time) int64 {
+	perSecond := int64(time.Second / f.unit)
+	return t.Unix()*perSecond + int64(t.Nanosecond())/int64(f.unit)
+}
+
+// timeFromUnix returns the local time of the number of units elapsed since January 1, 1970 UTC.
+func (f TimeFormat) timeFromUnix(v int64) time.Time {
+	perSecond := int64(time.Second / f.unit)
+	return time.Unix(v/perSecond, (v%perSecond)*int64(f.unit))
+}
+
+// DurationFormat is the format of time.Duration for EncodeDurationFormat and DecodeDurationFormat.
+type DurationFormat int
+
+const (
+	// DurationFormatNanoseconds formats time.Duration as the integer nanoseconds. This is the default.
+	DurationFormatNanoseconds DurationFormat = iota
+
+	// DurationFormatString formats time.Duration as string such as "1h2m0.5s" like time.Duration.String.
+	DurationFormatString
+)
+
+var (
+	timeType     = type2rtype(reflect.TypeOf(time.Time{}))
+	durationType = type2rtype(reflect.TypeOf(time.Duration(0)))
+)
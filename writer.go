@@ -0,0 +1,252 @@
+package json
+
+import (
+	"io"
+	"math"
+)
+
+// A Writer writes JSON values token by token with the same encoding as Encoder,
+// e.g. w.BeginObject(); w.Key("id"); w.Int(1); w.End().
+// It puts the commas, the colons and the indentation, and buffers the output
+// until Flush is called, so that writing values does not allocate once the buffer has grown.
+//
+// The first error, such as WriterError for a value without a key in an object,
+// stops the following writes and is returned by Err and Flush.
+// Top-level values are separated by a newline character.
+type Writer struct {
+	enc       Encoder
+	w         io.Writer
+	stack     []byte // '{' or '[' of the open objects and arrays
+	hasValue  bool   // a value has been written at the current level
+	wantValue bool   // a key has been written and its value is expected
+	err       error
+}
+
+// NewWriter returns a new writer that writes to w by Flush.
+// w can be nil if the output is only taken by Bytes.
+func NewWriter(w io.Writer) *Writer {
+	jw := &Writer{w: w}
+	jw.enc.buf = make([]byte, 0, bufSize)
+	jw.enc.enabledHTMLEscape = true
+	return jw
+}
+
+// SetEscapeHTML specifies whether problematic HTML characters should be escaped inside JSON quoted strings
+// in the same way as Encoder.SetEscapeHTML. The default is true.
+func (w *Writer) SetEscapeHTML(on bool) {
+	w.enc.SetEscapeHTML(on)
+}
+
+// SetIndent indents the output in the same way as Encoder.SetIndent.
+func (w *Writer) SetIndent(prefix, indent string) {
+	w.enc.SetIndent(prefix, indent)
+}
+
+// Reset discards the buffered output and the error, and makes w write to out.
+// The settings of SetEscapeHTML and SetIndent are kept.
+func (w *Writer) Reset(out io.Writer) {
+	w.w = out
+	w.enc.buf = w.enc.buf[:0]
+	w.stack = w.stack[:0]
+	w.hasValue = false
+	w.wantValue = false
+	w.err = nil
+}
+
+// Bytes returns the buffered output, which is valid until the next write.
+func (w *Writer) Bytes() []byte {
+	return w.enc.buf
+}
+
+// Err returns the first error that occurred.
+func (w *Writer) Err() error {
+	return w.err
+}
+
+// Flush writes the buffered output to the underlying writer.
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.w == nil || len(w.enc.buf) == 0 {
+		return nil
+	}
+	if _, err := w.w.Write(w.enc.buf); err != nil {
+		w.err = err
+		return err
+	}
+	w.enc.buf = w.enc.buf[:0]
+	return nil
+}
+
+// BeginObject begins a JSON object, which is ended by End.
+func (w *Writer) BeginObject() {
+	w.begin('{')
+}
+
+// BeginArray begins a JSON array, which is ended by End.
+func (w *Writer) BeginArray() {
+	w.begin('[')
+}
+
+// End ends the innermost object or array.
+func (w *Writer) End() {
+	if w.err != nil {
+		return
+	}
+	if len(w.stack) == 0 {
+		w.err = errWriter("End without BeginObject or BeginArray")
+		return
+	}
+	if w.wantValue {
+		w.err = errWriter("End after Key without value")
+		return
+	}
+	c := w.stack[len(w.stack)-1]
+	w.stack = w.stack[:len(w.stack)-1]
+	if w.hasValue {
+		w.newline()
+	}
+	if c == '{' {
+		w.enc.encodeByte('}')
+	} else {
+		w.enc.encodeByte(']')
+	}
+	w.hasValue = true
+}
+
+// Key writes the key of the next value in the object.
+func (w *Writer) Key(k string) {
+	if w.err != nil {
+		return
+	}
+	if len(w.stack) == 0 || w.stack[len(w.stack)-1] != '{' {
+		w.err = errWriter("Key outside of object")
+		return
+	}
+	if w.wantValue {
+		w.err = errWriter("Key after Key without value")
+		return
+	}
+	if w.hasValue {
+		w.enc.encodeByte(',')
+	}
+	w.newline()
+	w.enc.encodeString(k)
+	if w.enc.enabledIndent {
+		w.enc.encodeBytes([]byte{':', ' '})
+	} else {
+		w.enc.encodeByte(':')
+	}
+	w.wantValue = true
+}
+
+// String writes s as a JSON string.
+func (w *Writer) String(s string) {
+	if w.beginValue() {
+		w.enc.encodeString(s)
+		w.hasValue = true
+	}
+}
+
+// Int writes v as a JSON number.
+func (w *Writer) Int(v int64) {
+	if w.beginValue() {
+		w.enc.encodeInt64(v)
+		w.hasValue = true
+	}
+}
+
+// Uint writes v as a JSON number.
+func (w *Writer) Uint(v uint64) {
+	if w.beginValue() {
+		w.enc.encodeUint64(v)
+		w.hasValue = true
+	}
+}
+
+// Float writes v as a JSON number. NaN and infinity are UnsupportedValueError.
+func (w *Writer) Float(v float64) {
+	if w.err != nil {
+		return
+	}
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		w.err = errUnsupportedFloat(v)
+		return
+	}
+	if w.beginValue() {
+		w.enc.encodeFloat64(v)
+		w.hasValue = true
+	}
+}
+
+// Bool writes v as a JSON boolean.
+func (w *Writer) Bool(v bool) {
+	if w.beginValue() {
+		w.enc.encodeBool(v)
+		w.hasValue = true
+	}
+}
+
+// Null writes a JSON null.
+func (w *Writer) Null() {
+	if w.beginValue() {
+		w.enc.encodeNull()
+		w.hasValue = true
+	}
+}
+
+// Raw writes b as a value without validating or indenting it, so b must be a valid JSON value.
+func (w *Writer) Raw(b []byte) {
+	if w.beginValue() {
+		w.enc.encodeBytes(b)
+		w.hasValue = true
+	}
+}
+
+func (w *Writer) begin(c byte) {
+	if !w.beginValue() {
+		return
+	}
+	w.enc.encodeByte(c)
+	w.stack = append(w.stack, c)
+	w.hasValue = false
+}
+
+// beginValue writes the separator before a value and reports whether the value can be written.
+func (w *Writer) beginValue() bool {
+	if w.err != nil {
+		return false
+	}
+	if w.wantValue {
+		w.wantValue = false
+		return true
+	}
+	if len(w.stack) == 0 {
+		if w.hasValue {
+			w.enc.encodeByte('\n')
+		}
+		return true
+	}
+	if w.stack[len(w.stack)-1] == '{' {
+		w.err = errWriter("value in object without Key")
+		return false
+	}
+	if w.hasValue {
+		w.enc.encodeByte(',')
+	}
+	w.newline()
+	return true
+}
+
+// newline starts the line of the current level if the indent is enabled.
+func (w *Writer) newline() {
+	if !w.enc.enabledIndent {
+		return
+	}
+	w.enc.encodeByte('\n')
+	w.enc.encodeBytes(w.enc.prefix)
+	for i := 0; i < len(w.stack); i++ {
+		w.enc.encodeBytes(w.enc.indentStr)
+	}
+}